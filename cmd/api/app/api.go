@@ -28,6 +28,8 @@ import (
 
 	"github.com/karmada-io/dashboard/cmd/api/app/options"
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/apply"                    // Importing route packages forces route registration
+	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/audit"                    // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/auth"                     // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/cluster"                  // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/clusteroverridepolicy"    // Importing route packages forces route registration
@@ -37,6 +39,7 @@ import (
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/cronjob"                  // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/daemonset"                // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/deployment"               // Importing route packages forces route registration
+	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/export"                   // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/ingress"                  // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/job"                      // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/member"                   // Importing route packages forces route registration
@@ -48,9 +51,13 @@ import (
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/service"                  // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/statefulset"              // Importing route packages forces route registration
 	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/unstructured"             // Importing route packages forces route registration
+	_ "github.com/karmada-io/dashboard/cmd/api/app/routes/watch"                    // Importing route packages forces route registration
+	"github.com/karmada-io/dashboard/pkg/audit"
 	"github.com/karmada-io/dashboard/pkg/client"
 	"github.com/karmada-io/dashboard/pkg/config"
 	"github.com/karmada-io/dashboard/pkg/environment"
+	"github.com/karmada-io/dashboard/pkg/history"
+	"github.com/karmada-io/dashboard/pkg/informer"
 )
 
 // NewAPICommand creates a *cobra.Command object with default parameters
@@ -129,6 +136,8 @@ func run(ctx context.Context, opts *options.Options) error {
 		client.WithKubeContext(opts.KarmadaContext),
 		// 设置 karmada 的 insecure tls skip verify
 		client.WithInsecureTLSSkipVerify(opts.SkipKarmadaApiserverTLSVerify),
+		// 设置请求客户端缓存的容量和空闲过期时间
+		client.WithClientCache(opts.ClientCacheSize, opts.ClientCacheTTL),
 	)
 
 	// 初始化 kubernetes 的 kubeconfig
@@ -144,12 +153,19 @@ func run(ctx context.Context, opts *options.Options) error {
 	)
 	// 确保 API 服务器连接或退出
 	ensureAPIServerConnectionOrDie()
+	// 启动 Karmada 对象的 Informer，缓存同步完成前 /readyz 返回 503
+	informer.Init(ctx, client.InClusterKarmadaClient(), informer.WithMemberClusterCache(opts.EnableMemberClusterCache),
+		informer.WithClusterEventHandler(initHistory(ctx, opts)))
+	// 初始化审计日志的 Sink
+	initAudit(opts)
 	// 启动服务
 	serve(opts)
 	// 初始化 dashboard 的配置
 	config.InitDashboardConfig(client.InClusterClient(), ctx.Done())
 	// 等待上下文结束
 	<-ctx.Done()
+	// 发送尚未写入的审计记录
+	audit.Shutdown()
 	// 退出程序
 	os.Exit(0)
 	return nil
@@ -176,6 +192,46 @@ func ensureAPIServerConnectionOrDie() {
 	klog.InfoS("Successful initial request to the Karmada apiserver", "version", karmadaVersionInfo.String())
 }
 
+// 初始化审计日志的 Sink，SQLite 优先于文件用于查询审计记录
+func initAudit(opts *options.Options) {
+	var sinks []audit.Sink
+	if opts.AuditSQLiteFile != "" {
+		sink, err := audit.NewSQLiteSink(opts.AuditSQLiteFile)
+		if err != nil {
+			klog.Fatalf("Failed to open audit database %s: %v", opts.AuditSQLiteFile, err)
+		}
+		sinks = append(sinks, sink)
+	}
+	if opts.AuditLogFile != "" {
+		sink, err := audit.NewFileSink(opts.AuditLogFile)
+		if err != nil {
+			klog.Fatalf("Failed to open audit log file %s: %v", opts.AuditLogFile, err)
+		}
+		sinks = append(sinks, sink)
+	}
+	if opts.AuditWebhookURL != "" {
+		sinks = append(sinks, audit.NewWebhookSink(opts.AuditWebhookURL))
+	}
+	audit.Init(sinks...)
+	klog.InfoS("Initialized audit sinks", "count", len(sinks))
+}
+
+// 初始化集群历史记录，返回注册到集群 Informer 的 Recorder
+func initHistory(ctx context.Context, opts *options.Options) *history.Recorder {
+	var store history.Store = history.NewMemoryStore()
+	if opts.ClusterHistorySQLiteFile != "" {
+		sqliteStore, err := history.NewSQLiteStore(opts.ClusterHistorySQLiteFile)
+		if err != nil {
+			klog.Fatalf("Failed to open cluster history database %s: %v", opts.ClusterHistorySQLiteFile, err)
+		}
+		store = sqliteStore
+	}
+	recorder := history.NewRecorder(store, opts.ClusterHistoryRetention)
+	history.Init(recorder)
+	go recorder.Run(ctx)
+	return recorder
+}
+
 // 启动服务
 func serve(opts *options.Options) {
 	// 设置 insecure 地址
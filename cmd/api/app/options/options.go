@@ -18,6 +18,7 @@ package options
 
 import (
 	"net"
+	"time"
 
 	"github.com/spf13/pflag"
 )
@@ -38,6 +39,14 @@ type Options struct {
 	Namespace                     string
 	DisableCSRFProtection         bool
 	OpenAPIEnabled                bool
+	ClientCacheSize               int
+	ClientCacheTTL                time.Duration
+	EnableMemberClusterCache      bool
+	AuditLogFile                  string
+	AuditSQLiteFile               string
+	AuditWebhookURL               string
+	ClusterHistorySQLiteFile      string
+	ClusterHistoryRetention       time.Duration
 }
 
 // NewOptions returns initialized Options.
@@ -65,4 +74,12 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.Namespace, "namespace", "karmada-dashboard", "Namespace to use when accessing Dashboard specific resources, i.e. configmap")
 	fs.BoolVar(&o.DisableCSRFProtection, "disable-csrf-protection", false, "allows disabling CSRF protection")
 	fs.BoolVar(&o.OpenAPIEnabled, "openapi-enabled", false, "enables OpenAPI v2 endpoint under '/apidocs.json'")
+	fs.IntVar(&o.ClientCacheSize, "client-cache-size", 256, "Max number of user identities (token plus impersonation headers) whose Karmada clients are cached")
+	fs.DurationVar(&o.ClientCacheTTL, "client-cache-ttl", 10*time.Minute, "Time after which the cached Karmada clients of an idle user identity are evicted")
+	fs.StringVar(&o.AuditLogFile, "audit-log-file", "", "Path of the JSON lines file that audit events of mutating requests are appended to, auditing to a file is disabled when empty")
+	fs.StringVar(&o.AuditSQLiteFile, "audit-sqlite-file", "", "Path of the SQLite database that audit events of mutating requests are stored in, auditing to SQLite is disabled when empty")
+	fs.StringVar(&o.AuditWebhookURL, "audit-webhook-url", "", "URL that audit events of mutating requests are posted to as JSON, auditing to a webhook is disabled when empty")
+	fs.StringVar(&o.ClusterHistorySQLiteFile, "cluster-history-sqlite-file", "", "Path of the SQLite database that the history of cluster conditions, versions and resource summaries is stored in, the history is kept in memory when empty")
+	fs.DurationVar(&o.ClusterHistoryRetention, "cluster-history-retention", 7*24*time.Hour, "Time for which the history of cluster conditions, versions and resource summaries is kept")
+	fs.BoolVar(&o.EnableMemberClusterCache, "enable-member-cluster-cache", false, "enables informers of member cluster nodes, which are started on first access of each member cluster")
 }
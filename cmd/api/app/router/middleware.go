@@ -17,30 +17,48 @@ limitations under the License.
 package router
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/audit"
 	"github.com/karmada-io/dashboard/pkg/client"
 )
 
+const (
+	// WatchSubprotocol is the WebSocket subprotocol spoken by the watch endpoints.
+	WatchSubprotocol = "watch.dashboard.karmada.io"
+	// bearerTokenSubprotocolPrefix is the prefix of the WebSocket subprotocol carrying a base64url encoded bearer
+	// token, which is the same convention as kube-apiserver.
+	bearerTokenSubprotocolPrefix = "base64url.bearer.authorization.k8s.io."
+	// maxAuditedResponseSize 是为审计记录保留的失败响应体的最大长度
+	maxAuditedResponseSize = 64 << 10
+)
+
 // EnsureMemberClusterMiddleware ensures that the member cluster exists.
 // 确保成员集群存在。
 func EnsureMemberClusterMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// InClusterKarmadaClient 获取集群的karmada客户端
-		karmadaClient := client.InClusterKarmadaClient()
+		// 使用请求中的令牌获取karmada客户端
+		karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+		if err != nil {
+			common.Abort(c, err)
+			return
+		}
 		// 获取成员集群的名称
-		_, err := karmadaClient.ClusterV1alpha1().Clusters().Get(context.TODO(), c.Param("clustername"), metav1.GetOptions{})
+		_, err = karmadaClient.ClusterV1alpha1().Clusters().Get(context.TODO(), c.Param("clustername"), metav1.GetOptions{})
 		if err != nil {
 			// 如果成员集群不存在，返回错误信息
-			c.AbortWithStatusJSON(http.StatusOK, common.BaseResponse{
-				Code: 500,
-				Msg:  err.Error(),
-			})
+			common.Abort(c, err)
 			return
 		}
 		// 如果成员集群存在，继续处理请求
@@ -63,4 +81,88 @@ func CorsMiddleware() gin.HandlerFunc {
 
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// WebSocketTokenMiddleware moves the bearer token of a WebSocket handshake from the subprotocol into the Authorization
+// header, since browsers can not set headers on WebSocket requests.
+// WebSocketTokenMiddleware 将 WebSocket 握手子协议中的令牌设置到授权头中，浏览器无法为 WebSocket 请求设置请求头
+func WebSocketTokenMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !websocket.IsWebSocketUpgrade(c.Request) || client.HasAuthorizationHeader(c.Request) {
+			c.Next()
+			return
+		}
+		for _, protocol := range websocket.Subprotocols(c.Request) {
+			if !strings.HasPrefix(protocol, bearerTokenSubprotocolPrefix) {
+				continue
+			}
+			token, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(protocol, bearerTokenSubprotocolPrefix))
+			if err != nil {
+				common.Abort(c, errors.NewBadRequest("invalid bearer token subprotocol"))
+				return
+			}
+			client.SetAuthorizationHeader(c.Request, string(token))
+			break
+		}
+		c.Next()
+	}
+}
+
+// AuditMiddleware records every mutating request with the user, source IP, target object, the change made to the
+// object and the outcome to the configured audit sinks.
+// AuditMiddleware 将每个变更请求的用户、来源 IP、操作对象、对象的变更和结果记录到审计 Sink
+func AuditMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !audit.Enabled() || !audit.IsMutating(c.Request.Method) {
+			c.Next()
+			return
+		}
+		// 读取请求体以确定操作的对象，并恢复请求体供后续处理使用
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			common.Abort(c, errors.NewBadRequest("failed to read request body"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		resource := c.Param("kind")
+		if resource == "" {
+			resource = routeResource(c.FullPath())
+		}
+		target := audit.ResolveTarget(resource, c.Param("namespace"), c.Param("name"), body)
+		target.Cluster = c.Param("clustername")
+		recording := audit.Start(c.Request, c.ClientIP(), target)
+
+		writer := &auditResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		recording.Finish(writer.Status(), writer.body.Bytes())
+	}
+}
+
+// routeResource 返回路由中的资源名称，例如 /api/v1/member/:clustername/deployment/:namespace/:name 中的 deployment
+func routeResource(fullPath string) string {
+	path := strings.TrimPrefix(fullPath, "/api/v1")
+	path = strings.TrimPrefix(path, "/member/:clustername")
+	resource, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return resource
+}
+
+// auditResponseWriter 在转发响应的同时保留失败响应的响应体，用于记录错误信息
+type auditResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write 写入响应体，失败响应的响应体同时被保留
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	if w.Status() >= http.StatusBadRequest && w.body.Len() < maxAuditedResponseSize {
+		w.body.Write(data[:min(len(data), maxAuditedResponseSize-w.body.Len())])
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString 写入字符串响应体，失败响应的响应体同时被保留
+func (w *auditResponseWriter) WriteString(data string) (int, error) {
+	return w.Write([]byte(data))
+}
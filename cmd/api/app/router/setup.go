@@ -17,9 +17,13 @@ limitations under the License.
 package router
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"k8s.io/component-base/metrics/legacyregistry"
 
 	"github.com/karmada-io/dashboard/pkg/environment"
+	"github.com/karmada-io/dashboard/pkg/informer"
 )
 
 // router 是 Gin 引擎实例
@@ -48,6 +52,10 @@ func init() {
 	v1 = router.Group("/api/v1")
 	// 为全局API添加CORS中间件
 	v1.Use(CorsMiddleware())
+	// 支持 WebSocket 请求通过子协议携带令牌
+	v1.Use(WebSocketTokenMiddleware())
+	// 记录变更请求的审计日志
+	v1.Use(AuditMiddleware())
 	// 创建 /api/v1/member/:clustername 的路由组
 	member = v1.Group("/member/:clustername")
 	// 使用 EnsureMemberClusterMiddleware 中间件
@@ -61,8 +69,15 @@ func init() {
 	})
 	// 创建 /readyz 的路由
 	router.GET("/readyz", func(c *gin.Context) {
-		c.String(200, "readyz")
+		// Karmada 对象的缓存同步完成前不接收流量
+		if !informer.HasSynced() {
+			c.String(http.StatusServiceUnavailable, "informer caches not synced")
+			return
+		}
+		c.String(http.StatusOK, "readyz")
 	})
+	// 创建 /metrics 的路由，暴露 Prometheus 指标
+	router.GET("/metrics", gin.WrapH(legacyregistry.Handler()))
 }
 
 // V1 returns the router group for /api/v1 which for resources in control plane endpoints.
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/apply"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+)
+
+// 使用服务端应用（server-side apply）应用多文档 YAML 或 JSON
+func handleApply(c *gin.Context) {
+	applyRequest := new(v1.ApplyRequest)
+	if err := c.ShouldBind(applyRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	mapper, err := client.GetRESTMapperFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	results, err := apply.NewApplier(dynamicClient, mapper).Apply(c.Request.Context(), applyRequest.Content, apply.Options{
+		Namespace: applyRequest.Namespace,
+		DryRun:    applyRequest.DryRun,
+		Force:     applyRequest.Force,
+	})
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, v1.ApplyResponse{Results: results})
+}
+
+// 初始化路由
+func init() {
+	r := router.V1()
+	// 应用多文档 YAML 或 JSON
+	r.POST("/apply", handleApply)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"github.com/gin-gonic/gin"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/audit"
+	"github.com/karmada-io/dashboard/pkg/client"
+)
+
+// 查询审计记录，拥有 Karmada 控制面全部权限的用户可以查看所有用户的记录，其他用户只能查看自己的记录
+func handleGetAuditEvents(c *gin.Context) {
+	user, err := client.GetUserFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	events, err := audit.Events()
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	err = client.Authorize(c.Request, authorizationv1.ResourceAttributes{Verb: "*", Group: "*", Resource: "*"})
+	if errors.IsForbidden(err) {
+		events = eventsOf(events, user.Username)
+	} else if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dataSelect := common.ParseDataSelectPathParameter(c)
+	common.Success(c, audit.SelectEvents(events, dataSelect))
+}
+
+// eventsOf 返回指定用户的审计记录
+func eventsOf(events []audit.Event, username string) []audit.Event {
+	var result []audit.Event
+	for _, event := range events {
+		if event.User == username {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// 初始化路由
+func init() {
+	r := router.V1()
+	// 查询审计记录
+	r.GET("/audit", handleGetAuditEvents)
+}
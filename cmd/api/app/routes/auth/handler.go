@@ -23,6 +23,7 @@ import (
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
 )
 
 // handleLogin 处理登录请求
@@ -30,10 +31,10 @@ func handleLogin(c *gin.Context) {
 	// 创建一个 LoginRequest 对象
 	loginRequest := new(v1.LoginRequest)
 	// 绑定请求参数
-	if err := c.Bind(loginRequest); err != nil {
+	if err := c.ShouldBind(loginRequest); err != nil {
 		klog.ErrorS(err, "Could not read login request")
 		// 返回失败响应
-		common.Fail(c, err)
+		common.Fail(c, errors.NewBindingError(err))
 		return
 	}
 	// 调用 login 函数处理登录请求
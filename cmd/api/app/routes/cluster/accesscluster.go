@@ -25,15 +25,19 @@ import (
 	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
 	cmdutil "github.com/karmada-io/karmada/pkg/karmadactl/util"
 	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	"github.com/karmada-io/karmada/pkg/util/names"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/operation"
 )
 
 const (
@@ -46,9 +50,6 @@ const (
 	// KarmadaAgentName is the name of karmada-agent
 	// KarmadaAgentName 是 karmada-agent 的名称
 	KarmadaAgentName = "karmada-agent"
-	// KarmadaAgentImage is the image of karmada-agent
-	// KarmadaAgentImage 是 karmada-agent 的镜像
-	KarmadaAgentImage = "karmada/karmada-agent:latest"
 	// ClusterNamespace is the namespace of cluster
 	// ClusterNamespace 是集群的命名空间
 	ClusterNamespace = "karmada-cluster"
@@ -57,9 +58,9 @@ const (
 var (
 	// karmadaAgentLabels 是 karmada-agent 的标签
 	karmadaAgentLabels   = map[string]string{"app": KarmadaAgentName}
-	// karmadaAgentReplicas 是 karmada-agent 的副本数
+	// karmadaAgentReplicas 是 karmada-agent 的默认副本数
 	karmadaAgentReplicas = int32(2)
-	// timeout 是超时时间
+	// timeout 是等待 karmada-agent 和集群就绪的超时时间
 	timeout              = 5 * time.Minute
 )
 
@@ -77,14 +78,66 @@ type pullModeOption struct {
 	memberClusterName      string
 	// memberClusterEndpoint 是成员集群的端点
 	memberClusterEndpoint  string
+	// clusterProvider、clusterRegion 和 clusterZones 是 karmada-agent 上报的集群信息
+	clusterProvider string
+	clusterRegion   string
+	clusterZones    []string
+	// agent 是 karmada-agent 的部署参数
+	agent *agentSpec
+	// controlPlaneClient 和 karmadaRestConfig 携带请求用户的凭据，用于在控制面中为 karmada-agent 创建受限凭据
+	controlPlaneClient kubeclient.Interface
+	karmadaRestConfig  *rest.Config
+	// namespaceCreated 记录成员集群中的命名空间是否由本次接入创建，回滚时只删除本次创建的命名空间
+	namespaceCreated bool
+}
+
+// joinSteps 返回拉取模式下接入集群的步骤
+func (o *pullModeOption) joinSteps() []operation.Step {
+	return []operation.Step{
+		{Name: stepNamespace, Run: o.ensureNamespace, Rollback: o.deleteNamespace},
+		{Name: stepSecret, Run: o.createSecretsInMemberCluster, Rollback: o.deleteSecrets},
+		{Name: stepRBAC, Run: o.createRBACInMemberCluster, Rollback: o.deleteRBAC},
+		{Name: stepAgent, Run: o.deployAgent, Rollback: o.deleteAgent},
+		{Name: stepClusterReady, Run: func(ctx context.Context) (string, error) {
+			return waitForClusterReady(ctx, o.karmadaClient, o.memberClusterName)
+		}},
+	}
+}
+
+// ensureNamespace 确保成员集群中部署 karmada-agent 的命名空间存在
+func (o *pullModeOption) ensureNamespace(ctx context.Context) (string, error) {
+	// It's necessary to set the label of namespace to make sure that the namespace is created by Karmada.
+	labels := map[string]string{
+		karmadautil.ManagedByKarmadaLabel: karmadautil.ManagedByKarmadaLabelValue,
+	}
+	created, err := ensureMemberNamespace(ctx, o.memberClusterClient, o.memberClusterNamespace, labels)
+	if err != nil {
+		return "", err
+	}
+	o.namespaceCreated = created
+	return namespaceMessage(o.memberClusterNamespace, created), nil
 }
 
-// createSecretAndRBACInMemberCluster 在成员集群中创建所需的秘密和RBAC
-func (o pullModeOption) createSecretAndRBACInMemberCluster() error {
+// deleteNamespace 删除本次接入创建的命名空间
+func (o *pullModeOption) deleteNamespace(ctx context.Context) error {
+	if !o.namespaceCreated {
+		return nil
+	}
+	_, err := deleteObjects(ctx, o.memberClusterClient, []objectRef{{kind: "Namespace", name: o.memberClusterNamespace}})
+	return err
+}
+
+// createSecretsInMemberCluster 在控制面中为 karmada-agent 生成受限凭据，并在成员集群中创建 karmada-agent 使用的 Secret
+func (o *pullModeOption) createSecretsInMemberCluster(_ context.Context) (string, error) {
+	agentCfg, err := generateAgentKubeconfig(o.controlPlaneClient, o.karmadaRestConfig, o.memberClusterName)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate kubeconfig for karmada-agent: %w", err)
+	}
+	o.karmadaAgentCfg = agentCfg
 	// 序列化 karmada-agent 的 kubeconfig
 	configBytes, err := clientcmd.Write(*o.karmadaAgentCfg)
 	if err != nil {
-		return fmt.Errorf("failure while serializing karmada-agent kubeConfig. %w", err)
+		return "", fmt.Errorf("failure while serializing karmada-agent kubeConfig. %w", err)
 	}
 
 	// 创建 karmada-kubeconfig 秘密
@@ -104,8 +157,32 @@ func (o pullModeOption) createSecretAndRBACInMemberCluster() error {
 	// create karmada-kubeconfig secret to be used by karmada-agent component.
 	// 创建karmada-kubeconfig秘密，供karmada-agent组件使用。
 	if err := cmdutil.CreateOrUpdateSecret(o.memberClusterClient, kubeConfigSecret); err != nil {
-		return fmt.Errorf("create secret %s failed: %v", kubeConfigSecret.Name, err)
+		return "", fmt.Errorf("create secret %s failed: %v", kubeConfigSecret.Name, err)
+	}
+
+	// 创建使用 dashboard 配置中镜像仓库凭据的拉取镜像 Secret
+	if o.agent.registrySecret != nil {
+		if err := cmdutil.CreateOrUpdateSecret(o.memberClusterClient, o.agent.registrySecret); err != nil {
+			return "", fmt.Errorf("create secret %s failed: %v", o.agent.registrySecret.Name, err)
+		}
+		return fmt.Sprintf("created secrets %s and %s", KarmadaKubeconfigName, o.agent.registrySecret.Name), nil
+	}
+	return fmt.Sprintf("created secret %s", KarmadaKubeconfigName), nil
+}
+
+// deleteSecrets 删除成员集群中 karmada-agent 使用的 Secret，以及控制面中为 karmada-agent 创建的凭据
+func (o *pullModeOption) deleteSecrets(ctx context.Context) error {
+	refs := []objectRef{{kind: "Secret", namespace: o.memberClusterNamespace, name: KarmadaKubeconfigName}}
+	if o.agent.registrySecret != nil {
+		refs = append(refs, objectRef{kind: "Secret", namespace: o.memberClusterNamespace, name: o.agent.registrySecret.Name})
 	}
+	_, memberErr := deleteObjects(ctx, o.memberClusterClient, refs)
+	_, controlPlaneErr := deleteObjects(ctx, o.controlPlaneClient, agentCredentialObjects(o.memberClusterName))
+	return utilerrors.NewAggregate([]error{memberErr, controlPlaneErr})
+}
+
+// createRBACInMemberCluster 在成员集群中为 karmada-agent 创建 ServiceAccount 和 RBAC
+func (o *pullModeOption) createRBACInMemberCluster(_ context.Context) (string, error) {
 
 	// 创建 karmada-agent ClusterRole
 	clusterRole := &rbacv1.ClusterRole{
@@ -128,7 +205,7 @@ func (o pullModeOption) createSecretAndRBACInMemberCluster() error {
 	// create a karmada-agent ClusterRole in member cluster.
 	// 在成员集群中创建karmada-agent ClusterRole。
 	if err := cmdutil.CreateOrUpdateClusterRole(o.memberClusterClient, clusterRole); err != nil {
-		return err
+		return "", err
 	}
 
 	// 创建 karmada-agent ServiceAccount
@@ -141,9 +218,8 @@ func (o pullModeOption) createSecretAndRBACInMemberCluster() error {
 
 	// create service account for karmada-agent
 	// 在成员集群中创建karmada-agent ServiceAccount。
-	_, err = karmadautil.EnsureServiceAccountExist(o.memberClusterClient, sa, false)
-	if err != nil {
-		return err
+	if _, err := karmadautil.EnsureServiceAccountExist(o.memberClusterClient, sa, false); err != nil {
+		return "", err
 	}
 
 	// 创建 karmada-agent ClusterRoleBinding
@@ -168,14 +244,24 @@ func (o pullModeOption) createSecretAndRBACInMemberCluster() error {
 	// grant karmada-agent clusterrole to karmada-agent service account
 	// 授予karmada-agent ClusterRole给karmada-agent ServiceAccount。
 	if err := cmdutil.CreateOrUpdateClusterRoleBinding(o.memberClusterClient, clusterRoleBinding); err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	return fmt.Sprintf("created ServiceAccount %s, ClusterRole and ClusterRoleBinding %s", sa.Name, KarmadaAgentName), nil
+}
+
+// deleteRBAC 删除成员集群中为 karmada-agent 创建的 ServiceAccount 和 RBAC
+func (o *pullModeOption) deleteRBAC(ctx context.Context) error {
+	_, err := deleteObjects(ctx, o.memberClusterClient, []objectRef{
+		{kind: "ClusterRoleBinding", name: KarmadaAgentName},
+		{kind: "ClusterRole", name: KarmadaAgentName},
+		{kind: "ServiceAccount", namespace: o.memberClusterNamespace, name: KarmadaAgentServiceAccountName},
+	})
+	return err
 }
 
 // makeKarmadaAgentDeployment 生成karmada-agent Deployment
-func (o pullModeOption) makeKarmadaAgentDeployment() *appsv1.Deployment {
+func (o *pullModeOption) makeKarmadaAgentDeployment() *appsv1.Deployment {
 	karmadaAgent := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apps/v1",
@@ -188,35 +274,19 @@ func (o pullModeOption) makeKarmadaAgentDeployment() *appsv1.Deployment {
 		},
 	}
 
-	//controllers := []string{"*"}
+	imagePullSecrets := make([]corev1.LocalObjectReference, 0, len(o.agent.imagePullSecrets))
+	for _, name := range o.agent.imagePullSecrets {
+		imagePullSecrets = append(imagePullSecrets, corev1.LocalObjectReference{Name: name})
+	}
 	podSpec := corev1.PodSpec{
-		ImagePullSecrets: []corev1.LocalObjectReference{
-			{
-				Name: "fronted-cn-beijing",
-			},
-		},
+		ImagePullSecrets:   imagePullSecrets,
 		ServiceAccountName: KarmadaAgentServiceAccountName,
 		Containers: []corev1.Container{
 			{
-				Name:  KarmadaAgentName,
-				Image: KarmadaAgentImage,
-				Command: []string{
-					"/bin/karmada-agent",
-					"--karmada-kubeconfig=/etc/kubeconfig/karmada-kubeconfig",
-					fmt.Sprintf("--cluster-name=%s", o.memberClusterName),
-					fmt.Sprintf("--cluster-api-endpoint=%s", o.memberClusterEndpoint),
-					//fmt.Sprintf("--cluster-provider=%s", clusterProvider),
-					//fmt.Sprintf("--cluster-region=%s", clusterRegion),
-					//fmt.Sprintf("--cluster-zones=%s", strings.Join(clusterZones, ",")),
-					//fmt.Sprintf("--controllers=%s", strings.Join(controllers, ",")),
-					//fmt.Sprintf("--proxy-server-address=%s", proxyServerAddress),
-					//fmt.Sprintf("--leader-elect-resource-namespace=%s", o.memberClusterNamespace),
-					"--feature-gates=CustomizedClusterResourceModeling=true,MultiClusterService=true",
-					"--cluster-status-update-frequency=10s",
-					"--bind-address=0.0.0.0",
-					"--secure-port=10357",
-					"--v=4",
-				},
+				Name:      KarmadaAgentName,
+				Image:     o.agent.image,
+				Command:   o.agentArgs(),
+				Resources: o.agent.resources,
 				VolumeMounts: []corev1.VolumeMount{
 					{
 						Name:      "kubeconfig",
@@ -235,12 +305,8 @@ func (o pullModeOption) makeKarmadaAgentDeployment() *appsv1.Deployment {
 				},
 			},
 		},
-		Tolerations: []corev1.Toleration{
-			{
-				Key:      "node-role.kubernetes.io/master",
-				Operator: corev1.TolerationOpExists,
-			},
-		},
+		Tolerations:  o.agent.tolerations,
+		NodeSelector: o.agent.nodeSelector,
 	}
 	// PodTemplateSpec
 	podTemplateSpec := corev1.PodTemplateSpec{
@@ -253,7 +319,7 @@ func (o pullModeOption) makeKarmadaAgentDeployment() *appsv1.Deployment {
 	}
 	// DeploymentSpec
 	karmadaAgent.Spec = appsv1.DeploymentSpec{
-		Replicas: &karmadaAgentReplicas,
+		Replicas: &o.agent.replicas,
 		Template: podTemplateSpec,
 		Selector: &metav1.LabelSelector{
 			MatchLabels: karmadaAgentLabels,
@@ -263,37 +329,27 @@ func (o pullModeOption) makeKarmadaAgentDeployment() *appsv1.Deployment {
 	return karmadaAgent
 }
 
-// accessClusterInPullMode 在拉取模式下访问集群
-func accessClusterInPullMode(opts *pullModeOption) error {
-	_, exist, err := karmadautil.GetClusterWithKarmadaClient(opts.karmadaClient, opts.memberClusterName)
-	if err != nil {
-		return err
+// deployAgent 在成员集群中部署 karmada-agent 并等待其就绪
+func (o *pullModeOption) deployAgent(ctx context.Context) (string, error) {
+	karmadaAgentDeployment := o.makeKarmadaAgentDeployment()
+	if _, err := o.memberClusterClient.AppsV1().Deployments(o.memberClusterNamespace).Create(ctx, karmadaAgentDeployment, metav1.CreateOptions{}); err != nil {
+		return "", err
 	}
-	if exist {
-		return fmt.Errorf("failed to register as cluster with name %s already exists", opts.memberClusterName)
+	// WaitForDeploymentRollout 的超时时间以秒为单位
+	if err := cmdutil.WaitForDeploymentRollout(o.memberClusterClient, karmadaAgentDeployment, int(timeout/time.Second)); err != nil {
+		return "", err
 	}
-	// It's necessary to set the label of namespace to make sure that the namespace is created by Karmada.
-	labels := map[string]string{
-		karmadautil.ManagedByKarmadaLabel: karmadautil.ManagedByKarmadaLabelValue,
-	}
-	// ensure namespace where the karmada-agent resources be deployed exists in the member cluster
-	if _, err = karmadautil.EnsureNamespaceExistWithLabels(opts.memberClusterClient, opts.memberClusterNamespace, false, labels); err != nil {
-		return err
-	}
-	if err = opts.createSecretAndRBACInMemberCluster(); err != nil {
-		return err
-	}
-	karmadaAgentDeployment := opts.makeKarmadaAgentDeployment()
-	if _, err = opts.memberClusterClient.AppsV1().Deployments(opts.memberClusterNamespace).Create(context.TODO(), karmadaAgentDeployment, metav1.CreateOptions{}); err != nil {
-		return err
-	}
-	// TODO: deployment ready cannot exactly express that cluster is ready
-	// It should also check cluster resource on karmada control-plane
-	// maybe karmadactl should optimized it
-	if err = cmdutil.WaitForDeploymentRollout(opts.memberClusterClient, karmadaAgentDeployment, int(timeout)); err != nil {
+	return fmt.Sprintf("%s is available with %d replicas of %s", KarmadaAgentName, o.agent.replicas, o.agent.image), nil
+}
+
+// deleteAgent 删除 karmada-agent，以及 karmada-agent 可能已在控制面中注册的 Cluster 对象
+func (o *pullModeOption) deleteAgent(ctx context.Context) error {
+	_, err := deleteObjects(ctx, o.memberClusterClient, []objectRef{{kind: "Deployment", namespace: o.memberClusterNamespace, name: KarmadaAgentName}})
+	if err != nil {
 		return err
 	}
-	return nil
+	_, err = deleteClusterObject(ctx, o.karmadaClient, o.memberClusterName)
+	return err
 }
 
 // pushModeOption 推送模式选项
@@ -302,57 +358,147 @@ type pushModeOption struct {
 	clusterName             string
 	karmadaRestConfig       *rest.Config
 	memberClusterRestConfig *rest.Config
+	// controlPlaneClient 和 memberClusterClient 分别访问 Karmada 控制面和成员集群
+	controlPlaneClient  kubeclient.Interface
+	memberClusterClient kubeclient.Interface
+	// registerOption 是注册集群的参数，在步骤之间传递从成员集群获取的凭据
+	registerOption karmadautil.ClusterRegisterOption
+	// namespaceCreated 记录成员集群中的命名空间是否由本次接入创建
+	namespaceCreated bool
 }
 
-// accessClusterInPushMode 在推送模式下访问集群
-func accessClusterInPushMode(opts *pushModeOption) error {
-	registerOption := karmadautil.ClusterRegisterOption{
-		ClusterNamespace:   ClusterNamespace,
-		ClusterName:        opts.clusterName,
-		ReportSecrets:      []string{karmadautil.KubeCredentials, karmadautil.KubeImpersonator},
-		ControlPlaneConfig: opts.karmadaRestConfig,
-		ClusterConfig:      opts.memberClusterRestConfig,
-	}
-
+// newPushModeOption 创建推送模式选项，并检查成员集群是否已以其他名称注册
+func newPushModeOption(karmadaClient karmadaclientset.Interface, clusterName string, karmadaRestConfig, memberClusterRestConfig *rest.Config) (*pushModeOption, error) {
 	// 创建控制平面客户端
-	controlPlaneKubeClient := kubeclient.NewForConfigOrDie(opts.karmadaRestConfig)
+	controlPlaneKubeClient, err := kubeclient.NewForConfig(karmadaRestConfig)
+	if err != nil {
+		return nil, err
+	}
 	// 创建成员集群客户端
-	memberClusterKubeClient := kubeclient.NewForConfigOrDie(opts.memberClusterRestConfig)
+	memberClusterKubeClient, err := kubeclient.NewForConfig(memberClusterRestConfig)
+	if err != nil {
+		return nil, err
+	}
 	// 获取成员集群ID
 	id, err := karmadautil.ObtainClusterID(memberClusterKubeClient)
 	if err != nil {
 		klog.ErrorS(err, "ObtainClusterID failed")
-		return err
+		return nil, err
 	}
 	// 检查集群ID是否唯一
-	exist, name, err := karmadautil.IsClusterIdentifyUnique(opts.karmadaClient, id)
+	exist, name, err := karmadautil.IsClusterIdentifyUnique(karmadaClient, id)
 	if err != nil {
 		klog.ErrorS(err, "Check ClusterIdentify failed")
-		return err
+		return nil, err
 	}
 	// 如果集群ID不唯一，返回错误
 	if !exist {
-		return fmt.Errorf("the same cluster has been registered with name %s", name)
+		return nil, fmt.Errorf("the same cluster has been registered with name %s", name)
+	}
+	return &pushModeOption{
+		karmadaClient:           karmadaClient,
+		clusterName:             clusterName,
+		karmadaRestConfig:       karmadaRestConfig,
+		memberClusterRestConfig: memberClusterRestConfig,
+		controlPlaneClient:      controlPlaneKubeClient,
+		memberClusterClient:     memberClusterKubeClient,
+		registerOption: karmadautil.ClusterRegisterOption{
+			ClusterNamespace:   ClusterNamespace,
+			ClusterName:        clusterName,
+			ReportSecrets:      []string{karmadautil.KubeCredentials, karmadautil.KubeImpersonator},
+			ControlPlaneConfig: karmadaRestConfig,
+			ClusterConfig:      memberClusterRestConfig,
+			ClusterID:          id,
+		},
+	}, nil
+}
+
+// joinSteps 返回推送模式下接入集群的步骤
+func (o *pushModeOption) joinSteps() []operation.Step {
+	return []operation.Step{
+		{Name: stepNamespace, Run: o.ensureNamespace, Rollback: o.deleteNamespace},
+		{Name: stepRBAC, Run: o.obtainCredentials, Rollback: o.deleteRBAC},
+		{Name: stepSecret, Run: o.registerCluster, Rollback: o.deregisterCluster},
+		{Name: stepClusterReady, Run: func(ctx context.Context) (string, error) {
+			return waitForClusterReady(ctx, o.karmadaClient, o.clusterName)
+		}},
 	}
-	// 设置集群ID
-	registerOption.ClusterID = id
+}
+
+// ensureNamespace 确保成员集群中保存 Karmada 凭据的命名空间存在
+func (o *pushModeOption) ensureNamespace(ctx context.Context) (string, error) {
+	labels := map[string]string{
+		karmadautil.KarmadaSystemLabel: karmadautil.KarmadaSystemLabelValue,
+	}
+	created, err := ensureMemberNamespace(ctx, o.memberClusterClient, ClusterNamespace, labels)
+	if err != nil {
+		return "", err
+	}
+	o.namespaceCreated = created
+	return namespaceMessage(ClusterNamespace, created), nil
+}
+
+// deleteNamespace 删除本次接入创建的命名空间
+func (o *pushModeOption) deleteNamespace(ctx context.Context) error {
+	if !o.namespaceCreated {
+		return nil
+	}
+	_, err := deleteObjects(ctx, o.memberClusterClient, []objectRef{{kind: "Namespace", name: ClusterNamespace}})
+	return err
+}
+
+// obtainCredentials 在成员集群中为 Karmada 创建 ServiceAccount 和 RBAC，并获取其令牌
+func (o *pushModeOption) obtainCredentials(_ context.Context) (string, error) {
 	// 获取成员集群凭证
-	clusterSecret, impersonatorSecret, err := karmadautil.ObtainCredentialsFromMemberCluster(memberClusterKubeClient, registerOption)
+	clusterSecret, impersonatorSecret, err := karmadautil.ObtainCredentialsFromMemberCluster(o.memberClusterClient, o.registerOption)
 	if err != nil {
 		klog.ErrorS(err, "ObtainCredentialsFromMemberCluster failed")
-		return err
+		return "", err
 	}
 	// 设置集群凭证
-	registerOption.Secret = *clusterSecret
-	registerOption.ImpersonatorSecret = *impersonatorSecret
+	o.registerOption.Secret = *clusterSecret
+	o.registerOption.ImpersonatorSecret = *impersonatorSecret
+	return fmt.Sprintf("created ServiceAccount %s and its RBAC", names.GenerateServiceAccountName(o.clusterName)), nil
+}
+
+// deleteRBAC 删除成员集群中为 Karmada 创建的 ServiceAccount 和 RBAC
+func (o *pushModeOption) deleteRBAC(ctx context.Context) error {
+	_, err := deleteObjects(ctx, o.memberClusterClient, pushModeMemberObjects(o.clusterName))
+	return err
+}
+
+// registerCluster 在控制面中创建保存成员集群凭据的 Secret 和 Cluster 对象
+func (o *pushModeOption) registerCluster(_ context.Context) (string, error) {
 	// 注册集群
-	err = karmadautil.RegisterClusterInControllerPlane(registerOption, controlPlaneKubeClient, generateClusterInControllerPlane)
-	if err != nil {
-		return err
+	if err := karmadautil.RegisterClusterInControllerPlane(o.registerOption, o.controlPlaneClient, generateClusterInControllerPlane); err != nil {
+		return "", err
 	}
 	// 打印成功信息
-	klog.Infof("cluster(%s) is joined successfully\n", opts.clusterName)
-	return nil
+	klog.Infof("cluster(%s) is joined successfully\n", o.clusterName)
+	return fmt.Sprintf("created secrets %s and %s and the Cluster object", o.clusterName, names.GenerateImpersonationSecretName(o.clusterName)), nil
+}
+
+// deregisterCluster 删除控制面中的 Cluster 对象和保存成员集群凭据的 Secret
+func (o *pushModeOption) deregisterCluster(ctx context.Context) error {
+	if _, err := deleteClusterObject(ctx, o.karmadaClient, o.clusterName); err != nil {
+		return err
+	}
+	_, err := deleteObjects(ctx, o.controlPlaneClient, []objectRef{
+		{kind: "Secret", namespace: ClusterNamespace, name: o.clusterName},
+		{kind: "Secret", namespace: ClusterNamespace, name: names.GenerateImpersonationSecretName(o.clusterName)},
+	})
+	return err
+}
+
+// pushModeMemberObjects 返回推送模式下在成员集群中为 Karmada 创建的 ServiceAccount 和 RBAC
+func pushModeMemberObjects(clusterName string) []objectRef {
+	serviceAccountName := names.GenerateServiceAccountName(clusterName)
+	return []objectRef{
+		{kind: "ClusterRoleBinding", name: names.GenerateRoleName(serviceAccountName)},
+		{kind: "ClusterRole", name: names.GenerateRoleName(serviceAccountName)},
+		{kind: "ServiceAccount", namespace: ClusterNamespace, name: serviceAccountName},
+		{kind: "ServiceAccount", namespace: ClusterNamespace, name: names.GenerateServiceAccountName("impersonator")},
+	}
 }
 
 // generateClusterInControllerPlane 在控制平面中生成集群对象
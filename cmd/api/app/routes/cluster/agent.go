@@ -0,0 +1,254 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/config"
+)
+
+const (
+	// defaultAgentRegistry 是未配置镜像仓库时 karmada-agent 使用的镜像仓库
+	defaultAgentRegistry = "docker.io/karmada"
+	// agentRegistrySecretName 是在成员集群中为 karmada-agent 创建的拉取镜像 Secret 的名称
+	agentRegistrySecretName = "karmada-agent-registry"
+	// karmadaControllerManagerName 是用于确定 Karmada 控制面版本的 Deployment 名称
+	karmadaControllerManagerName = "karmada-controller-manager"
+)
+
+// defaultAgentTolerations 是 karmada-agent 默认的容忍度，允许调度到控制面节点
+var defaultAgentTolerations = []corev1.Toleration{
+	{
+		Key:      "node-role.kubernetes.io/master",
+		Operator: corev1.TolerationOpExists,
+	},
+	{
+		Key:      "node-role.kubernetes.io/control-plane",
+		Operator: corev1.TolerationOpExists,
+	},
+}
+
+// agentSpec 是解析默认值后的 karmada-agent 部署参数
+type agentSpec struct {
+	image    string
+	replicas int32
+	// imagePullSecrets 是 Pod 引用的拉取镜像 Secret
+	imagePullSecrets []string
+	// registrySecret 是需要在成员集群中创建的拉取镜像 Secret，镜像仓库没有配置凭据时为空
+	registrySecret *corev1.Secret
+	resources      corev1.ResourceRequirements
+	tolerations    []corev1.Toleration
+	nodeSelector   map[string]string
+	featureGates   map[string]bool
+	extraArgs      []string
+}
+
+// resolveAgentSpec 根据请求中的选项、dashboard 配置和 Karmada 控制面的版本解析 karmada-agent 的部署参数，
+// controlPlaneClient 是使用请求用户凭据的 Karmada 控制面客户端
+func resolveAgentSpec(ctx context.Context, controlPlaneClient kubeclient.Interface, opts *v1.AgentOptions, namespace string) (*agentSpec, error) {
+	if opts == nil {
+		opts = &v1.AgentOptions{}
+	}
+	spec := &agentSpec{
+		image:            opts.Image,
+		replicas:         karmadaAgentReplicas,
+		imagePullSecrets: opts.ImagePullSecrets,
+		resources:        opts.Resources,
+		tolerations:      opts.Tolerations,
+		nodeSelector:     opts.NodeSelector,
+		featureGates:     opts.FeatureGates,
+		extraArgs:        opts.ExtraArgs,
+	}
+	if opts.Replicas != nil {
+		if *opts.Replicas < 1 {
+			return nil, errors.NewBadRequest("the replicas of karmada-agent must be at least 1")
+		}
+		spec.replicas = *opts.Replicas
+	}
+	if len(spec.tolerations) == 0 {
+		spec.tolerations = defaultAgentTolerations
+	}
+
+	registry, err := resolveAgentRegistry(opts)
+	if err != nil {
+		return nil, err
+	}
+	if registry != nil && registry.User != "" {
+		if spec.registrySecret, err = makeRegistrySecret(registry, namespace); err != nil {
+			return nil, err
+		}
+		spec.imagePullSecrets = append(spec.imagePullSecrets, spec.registrySecret.Name)
+	}
+	if spec.image == "" {
+		if spec.image, err = karmadaImage(ctx, controlPlaneClient, opts, registry, KarmadaAgentName); err != nil {
+			return nil, err
+		}
+	}
+	return spec, nil
+}
+
+// karmadaImage 返回 Karmada 组件的镜像，镜像仓库和版本与 karmada-agent 的选项相同
+func karmadaImage(ctx context.Context, controlPlaneClient kubeclient.Interface, opts *v1.AgentOptions, registry *config.DockerRegistry, component string) (string, error) {
+	repository := defaultAgentRegistry
+	if opts.Registry != "" {
+		repository = opts.Registry
+	} else if registry != nil {
+		repository = registry.URL
+	}
+	version := opts.Version
+	if version == "" {
+		var err error
+		if version, err = controlPlaneVersion(ctx, controlPlaneClient); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(trimScheme(repository), "/"), component, version), nil
+}
+
+// resolveAgentRegistry 返回 karmada-agent 使用的 dashboard 配置中的镜像仓库。优先使用 RegistryName 指定的仓库，其次是与
+// Registry 地址相同的仓库；两者都未设置时使用配置中的第一个仓库。没有匹配的仓库时返回 nil
+func resolveAgentRegistry(opts *v1.AgentOptions) (*config.DockerRegistry, error) {
+	registries := config.GetDashboardConfig().DockerRegistries
+	switch {
+	case opts.RegistryName != "":
+		for i := range registries {
+			if registries[i].Name == opts.RegistryName {
+				return &registries[i], nil
+			}
+		}
+		return nil, errors.NewBadRequest(fmt.Sprintf("docker registry %q is not configured in the dashboard", opts.RegistryName))
+	case opts.Registry != "":
+		for i := range registries {
+			if trimScheme(registries[i].URL) == trimScheme(opts.Registry) {
+				return &registries[i], nil
+			}
+		}
+		return nil, nil
+	case opts.Image == "" && len(registries) > 0:
+		return &registries[0], nil
+	default:
+		return nil, nil
+	}
+}
+
+// makeRegistrySecret 使用镜像仓库的凭据生成 kubernetes.io/dockerconfigjson 类型的 Secret
+func makeRegistrySecret(registry *config.DockerRegistry, namespace string) (*corev1.Secret, error) {
+	host, _, _ := strings.Cut(trimScheme(registry.URL), "/")
+	auth := map[string]interface{}{
+		"auths": map[string]interface{}{
+			host: map[string]string{
+				"username": registry.User,
+				"password": registry.Password,
+				"auth":     base64.StdEncoding.EncodeToString([]byte(registry.User + ":" + registry.Password)),
+			},
+		},
+	}
+	content, err := json.Marshal(auth)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentRegistrySecretName,
+			Namespace: namespace,
+			Labels:    karmadaAgentLabels,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: content},
+	}, nil
+}
+
+// trimScheme 去除镜像仓库地址中的协议前缀
+func trimScheme(url string) string {
+	url = strings.TrimPrefix(url, "https://")
+	return strings.TrimPrefix(url, "http://")
+}
+
+// controlPlaneVersion 使用请求用户的凭据读取 karmada-controller-manager 的镜像版本作为 Karmada 控制面的版本。
+// 用户无权读取或找不到该 Deployment 时返回错误，需要在请求中指定 karmada-agent 的版本或镜像
+func controlPlaneVersion(ctx context.Context, controlPlaneClient kubeclient.Interface) (string, error) {
+	deployments, err := controlPlaneClient.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", karmadaControllerManagerName).String(),
+	})
+	if err != nil {
+		return "", errors.NewBadRequest(fmt.Sprintf("cannot read the version of the Karmada control plane, set the version or the image of karmada-agent: %v", err))
+	}
+	for _, deployment := range deployments.Items {
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			if version := imageTag(container.Image); version != "" {
+				return version, nil
+			}
+		}
+	}
+	return "", errors.NewBadRequest(fmt.Sprintf("cannot find the version of the Karmada control plane in %s, set the version or the image of karmada-agent", karmadaControllerManagerName))
+}
+
+// imageTag 返回镜像的标签，没有标签时返回空字符串
+func imageTag(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	slash := strings.LastIndex(image, "/")
+	colon := strings.LastIndex(image, ":")
+	if colon <= slash {
+		return ""
+	}
+	return image[colon+1:]
+}
+
+// agentArgs 返回 karmada-agent 的命令行参数
+func (o pullModeOption) agentArgs() []string {
+	args := []string{
+		"/bin/karmada-agent",
+		"--karmada-kubeconfig=/etc/kubeconfig/karmada-kubeconfig",
+		fmt.Sprintf("--cluster-name=%s", o.memberClusterName),
+		fmt.Sprintf("--cluster-api-endpoint=%s", o.memberClusterEndpoint),
+		fmt.Sprintf("--leader-elect-resource-namespace=%s", o.memberClusterNamespace),
+		"--cluster-status-update-frequency=10s",
+		"--bind-address=0.0.0.0",
+		"--secure-port=10357",
+	}
+	if o.clusterProvider != "" {
+		args = append(args, fmt.Sprintf("--cluster-provider=%s", o.clusterProvider))
+	}
+	if o.clusterRegion != "" {
+		args = append(args, fmt.Sprintf("--cluster-region=%s", o.clusterRegion))
+	}
+	if len(o.clusterZones) > 0 {
+		args = append(args, fmt.Sprintf("--cluster-zones=%s", strings.Join(o.clusterZones, ",")))
+	}
+	if len(o.agent.featureGates) > 0 {
+		gates := make([]string, 0, len(o.agent.featureGates))
+		for name, enabled := range o.agent.featureGates {
+			gates = append(gates, fmt.Sprintf("%s=%t", name, enabled))
+		}
+		sort.Strings(gates)
+		args = append(args, fmt.Sprintf("--feature-gates=%s", strings.Join(gates, ",")))
+	}
+	return append(args, o.agent.extraArgs...)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+)
+
+// controllerManager 返回使用指定镜像的 karmada-controller-manager Deployment
+func controllerManager(image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: karmadaControllerManagerName, Namespace: "karmada-system"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "manager", Image: image}}},
+			},
+		},
+	}
+}
+
+func TestImageTag(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "docker.io/karmada/karmada-agent:v1.13.0", want: "v1.13.0"},
+		{image: "registry.local:5000/karmada/karmada-agent:v1.13.0", want: "v1.13.0"},
+		{image: "registry.local:5000/karmada/karmada-agent", want: ""},
+		{image: "karmada-agent@sha256:0123", want: ""},
+		{image: "karmada-agent:v1.13.0@sha256:0123", want: "v1.13.0"},
+	}
+	for _, tt := range tests {
+		if got := imageTag(tt.image); got != tt.want {
+			t.Errorf("imageTag(%q) = %q, want %q", tt.image, got, tt.want)
+		}
+	}
+}
+
+func TestResolveAgentSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		objects    []appsv1.Deployment
+		opts       *v1.AgentOptions
+		wantImage  string
+		wantErr    bool
+		wantCustom bool
+	}{
+		{
+			name:      "version of the control plane",
+			objects:   []appsv1.Deployment{*controllerManager("docker.io/karmada/karmada-controller-manager:v1.13.0")},
+			wantImage: "docker.io/karmada/karmada-agent:v1.13.0",
+		},
+		{
+			name:      "requested version and registry",
+			opts:      &v1.AgentOptions{Registry: "https://registry.local/karmada/", Version: "v1.12.0"},
+			wantImage: "registry.local/karmada/karmada-agent:v1.12.0",
+		},
+		{
+			name:      "requested image",
+			opts:      &v1.AgentOptions{Image: "registry.local/karmada-agent:dev"},
+			wantImage: "registry.local/karmada-agent:dev",
+		},
+		{
+			name:    "unknown version of the control plane",
+			wantErr: true,
+		},
+		{
+			name:    "invalid replicas",
+			opts:    &v1.AgentOptions{Image: "karmada-agent:dev", Replicas: ptr.To[int32](0)},
+			wantErr: true,
+		},
+		{
+			name:    "unknown registry",
+			opts:    &v1.AgentOptions{RegistryName: "missing", Version: "v1.13.0"},
+			wantErr: true,
+		},
+		{
+			name: "custom tolerations",
+			opts: &v1.AgentOptions{
+				Version:     "v1.13.0",
+				Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+			},
+			wantImage:  "docker.io/karmada/karmada-agent:v1.13.0",
+			wantCustom: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			for i := range tt.objects {
+				if err := client.Tracker().Add(&tt.objects[i]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			spec, err := resolveAgentSpec(context.TODO(), client, tt.opts, "karmada-system")
+			if tt.wantErr {
+				if !apierrors.IsBadRequest(err) {
+					t.Fatalf("resolveAgentSpec() error = %v, want BadRequest", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveAgentSpec() error = %v", err)
+			}
+			if spec.image != tt.wantImage {
+				t.Errorf("resolveAgentSpec() image = %q, want %q", spec.image, tt.wantImage)
+			}
+			if spec.replicas != karmadaAgentReplicas {
+				t.Errorf("resolveAgentSpec() replicas = %d, want %d", spec.replicas, karmadaAgentReplicas)
+			}
+			if custom := !reflect.DeepEqual(spec.tolerations, defaultAgentTolerations); custom != tt.wantCustom {
+				t.Errorf("resolveAgentSpec() tolerations = %v", spec.tolerations)
+			}
+		})
+	}
+}
+
+func TestAgentArgs(t *testing.T) {
+	option := pullModeOption{
+		memberClusterName:      "member1",
+		memberClusterEndpoint:  "https://member1:6443",
+		memberClusterNamespace: "karmada-system",
+		clusterRegion:          "east",
+		clusterZones:           []string{"a", "b"},
+		agent: &agentSpec{
+			featureGates: map[string]bool{"Failover": true, "CustomizedClusterResourceModeling": false},
+			extraArgs:    []string{"--v=4"},
+		},
+	}
+	want := []string{
+		"/bin/karmada-agent",
+		"--karmada-kubeconfig=/etc/kubeconfig/karmada-kubeconfig",
+		"--cluster-name=member1",
+		"--cluster-api-endpoint=https://member1:6443",
+		"--leader-elect-resource-namespace=karmada-system",
+		"--cluster-status-update-frequency=10s",
+		"--bind-address=0.0.0.0",
+		"--secure-port=10357",
+		"--cluster-region=east",
+		"--cluster-zones=a,b",
+		"--feature-gates=CustomizedClusterResourceModeling=false,Failover=true",
+		"--v=4",
+	}
+	if got := option.agentArgs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("agentArgs() = %v, want %v", got, want)
+	}
+}
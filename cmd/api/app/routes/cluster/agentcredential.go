@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/karmada-io/karmada/pkg/karmadactl/register"
+	cmdutil "github.com/karmada-io/karmada/pkg/karmadactl/util"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	"github.com/karmada-io/karmada/pkg/util/names"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// agentServiceAccountName 返回 karmada-agent 在 Karmada 控制面中使用的 ServiceAccount 名称
+func agentServiceAccountName(clusterName string) string {
+	return fmt.Sprintf("karmada-agent-%s", clusterName)
+}
+
+// generateAgentKubeconfig creates a ServiceAccount for the karmada-agent of the given cluster in Karmada control plane,
+// grants it the same permissions that `karmadactl register` grants to an agent, and returns a kubeconfig with the
+// token of this ServiceAccount. The kubeconfig is installed into the member cluster, so it must never carry the
+// dashboard's own credentials.
+// generateAgentKubeconfig 在 Karmada 控制面中为 karmada-agent 创建仅具备所需权限的 ServiceAccount，并返回使用其令牌的 kubeconfig
+func generateAgentKubeconfig(controlPlaneClient kubeclient.Interface, controlPlaneConfig *rest.Config, clusterName string) (*clientcmdapi.Config, error) {
+	// 确保集群命名空间和执行命名空间存在，角色绑定需要创建在这两个命名空间中
+	for _, namespace := range []string{ClusterNamespace, names.GenerateExecutionSpaceName(clusterName)} {
+		if _, err := karmadautil.EnsureNamespaceExist(controlPlaneClient, namespace, false); err != nil {
+			return nil, err
+		}
+	}
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentServiceAccountName(clusterName),
+			Namespace: ClusterNamespace,
+		},
+	}
+	if _, err := karmadautil.EnsureServiceAccountExist(controlPlaneClient, sa, false); err != nil {
+		return nil, err
+	}
+
+	// 复用 karmadactl register 为 agent 生成的最小权限 RBAC，并将主体替换为上面的 ServiceAccount
+	subjects := []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      sa.Name,
+		Namespace: sa.Namespace,
+	}}
+	rbacResources := register.GenerateRBACResources(clusterName, ClusterNamespace)
+	for _, clusterRole := range rbacResources.ClusterRoles {
+		if err := cmdutil.CreateOrUpdateClusterRole(controlPlaneClient, clusterRole); err != nil {
+			return nil, err
+		}
+	}
+	for _, clusterRoleBinding := range rbacResources.ClusterRoleBindings {
+		clusterRoleBinding.Subjects = subjects
+		if err := cmdutil.CreateOrUpdateClusterRoleBinding(controlPlaneClient, clusterRoleBinding); err != nil {
+			return nil, err
+		}
+	}
+	for _, role := range rbacResources.Roles {
+		if err := cmdutil.CreateOrUpdateRole(controlPlaneClient, role); err != nil {
+			return nil, err
+		}
+	}
+	for _, roleBinding := range rbacResources.RoleBindings {
+		roleBinding.Subjects = subjects
+		if err := cmdutil.CreateOrUpdateRoleBinding(controlPlaneClient, roleBinding); err != nil {
+			return nil, err
+		}
+	}
+
+	tokenSecret, err := karmadautil.WaitForServiceAccountSecretCreation(controlPlaneClient, sa)
+	if err != nil {
+		return nil, err
+	}
+
+	caData := tokenSecret.Data[corev1.ServiceAccountRootCAKey]
+	if len(caData) == 0 {
+		// CA 可能以文件形式配置，需要读取文件内容写入 kubeconfig
+		tlsConfig := rest.CopyConfig(controlPlaneConfig)
+		if err = rest.LoadTLSFiles(tlsConfig); err != nil {
+			return nil, err
+		}
+		caData = tlsConfig.TLSClientConfig.CAData
+	}
+	agentCfg := clientcmdapi.NewConfig()
+	agentCfg.Clusters[KarmadaAgentName] = &clientcmdapi.Cluster{
+		Server:                   controlPlaneConfig.Host,
+		CertificateAuthorityData: caData,
+		InsecureSkipTLSVerify:    controlPlaneConfig.TLSClientConfig.Insecure,
+	}
+	agentCfg.AuthInfos[KarmadaAgentName] = &clientcmdapi.AuthInfo{
+		Token: string(tokenSecret.Data[corev1.ServiceAccountTokenKey]),
+	}
+	agentCfg.Contexts[KarmadaAgentName] = &clientcmdapi.Context{
+		Cluster:  KarmadaAgentName,
+		AuthInfo: KarmadaAgentName,
+	}
+	agentCfg.CurrentContext = KarmadaAgentName
+	return agentCfg, nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
+	"github.com/karmada-io/dashboard/pkg/resource/cluster"
+)
+
+// 获取各集群提供的 API 资源矩阵
+func handleGetClusterAPIEnablements(c *gin.Context) {
+	listers, err := informer.ListersForList(c.Request, "", types.ResourceKindCluster)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	clusters, err := listers.Clusters.List(labels.Everything())
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, cluster.GetAPIEnablementMatrix(clusters))
+}
+
+// 检查传播策略或已分发资源的目标集群是否提供被分发的资源类型
+func handleGetClusterAPIEnablementCheck(c *gin.Context) {
+	req := new(v1.CheckAPIEnablementRequest)
+	if err := c.ShouldBindQuery(req); err != nil {
+		common.Fail(c, errors.NewBadRequest(err.Error()))
+		return
+	}
+	var placement *policyv1alpha1.Placement
+	var gvks []schema.GroupVersionKind
+	var targets []string
+	var err error
+	switch req.PolicyKind {
+	case "":
+		gvks, targets, err = propagatedResource(c.Request, req)
+	case "PropagationPolicy", "ClusterPropagationPolicy":
+		gvks, placement, err = propagationPolicy(c.Request, req)
+	default:
+		err = errors.NewBadRequest(fmt.Sprintf("unsupported policyKind %q, expected PropagationPolicy or ClusterPropagationPolicy", req.PolicyKind))
+	}
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+
+	listers, err := informer.ListersForList(c.Request, "", types.ResourceKindCluster)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	clusters, err := listers.Clusters.List(labels.Everything())
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if req.PolicyKind != "" {
+		clusters = cluster.PlacementClusters(placement, clusters)
+	} else {
+		clusters = scheduledClusters(clusters, targets)
+	}
+	common.Success(c, cluster.CheckAPIEnablement(clusters, gvks))
+}
+
+// propagationPolicy 返回传播策略选择的资源类型和调度策略
+func propagationPolicy(request *http.Request, req *v1.CheckAPIEnablementRequest) ([]schema.GroupVersionKind, *policyv1alpha1.Placement, error) {
+	var spec *policyv1alpha1.PropagationSpec
+	if req.PolicyKind == "ClusterPropagationPolicy" {
+		listers, err := informer.ListersForGet(request, types.ResourceKindClusterPropagationPolicy, "", req.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		policy, err := listers.ClusterPropagationPolicies.Get(req.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		spec = &policy.Spec
+	} else {
+		listers, err := informer.ListersForGet(request, types.ResourceKindPropagationPolicy, req.Namespace, req.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		policy, err := listers.PropagationPolicies.PropagationPolicies(req.Namespace).Get(req.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		spec = &policy.Spec
+	}
+	gvks := make([]schema.GroupVersionKind, 0, len(spec.ResourceSelectors))
+	for _, selector := range spec.ResourceSelectors {
+		gvks = append(gvks, schema.FromAPIVersionAndKind(selector.APIVersion, selector.Kind))
+	}
+	return gvks, &spec.Placement, nil
+}
+
+// propagatedResource 返回资源的类型和其绑定调度到的集群
+func propagatedResource(request *http.Request, req *v1.CheckAPIEnablementRequest) ([]schema.GroupVersionKind, []string, error) {
+	if req.APIVersion == "" || req.Kind == "" {
+		return nil, nil, errors.NewBadRequest("apiVersion and kind are required when policyKind is empty")
+	}
+	gvk := schema.FromAPIVersionAndKind(req.APIVersion, req.Kind)
+	bindingKind, bindingName := cluster.BindingOf(req.Kind, req.Namespace, req.Name)
+	listers, err := informer.ListersForGet(request, bindingKind, req.Namespace, bindingName)
+	if err != nil {
+		return nil, nil, err
+	}
+	targets, err := cluster.ScheduledClusters(listers.ResourceBindings, listers.ClusterResourceBindings, req.Kind, req.Namespace, req.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []schema.GroupVersionKind{gvk}, targets, nil
+}
+
+// scheduledClusters 返回名称在 targets 中的集群
+func scheduledClusters(clusters []*clusterv1alpha1.Cluster, targets []string) []*clusterv1alpha1.Cluster {
+	scheduled := make([]*clusterv1alpha1.Cluster, 0, len(targets))
+	for _, memberCluster := range clusters {
+		for _, target := range targets {
+			if memberCluster.Name == target {
+				scheduled = append(scheduled, memberCluster)
+				break
+			}
+		}
+	}
+	return scheduled
+}
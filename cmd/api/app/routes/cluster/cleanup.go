@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	"github.com/karmada-io/karmada/pkg/karmadactl/register"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// objectRef 描述接入集群时创建、需要在回滚或移除集群时删除的对象
+type objectRef struct {
+	kind      string
+	namespace string
+	name      string
+	// ownedBy 不为空时只删除带有这些标签的对象，用于保留成员集群中并非由 Karmada 创建的同名对象
+	ownedBy map[string]string
+}
+
+// String 返回对象的描述，例如 Secret karmada-system/karmada-kubeconfig
+func (r objectRef) String() string {
+	if r.namespace == "" {
+		return fmt.Sprintf("%s %s", r.kind, r.name)
+	}
+	return fmt.Sprintf("%s %s/%s", r.kind, r.namespace, r.name)
+}
+
+// deleteObjects 依次删除对象并忽略已不存在的对象，返回实际删除的对象，删除失败时继续删除其余对象
+func deleteObjects(ctx context.Context, kubeClient kubeclient.Interface, refs []objectRef) ([]string, error) {
+	var removed []string
+	var errs []error
+	background := metav1.DeletePropagationBackground
+	options := metav1.DeleteOptions{PropagationPolicy: &background}
+	for _, ref := range refs {
+		owned, err := isOwned(ctx, kubeClient, ref)
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to check %s: %w", ref, err))
+		}
+		if !owned {
+			continue
+		}
+		switch ref.kind {
+		case "Deployment":
+			err = kubeClient.AppsV1().Deployments(ref.namespace).Delete(ctx, ref.name, options)
+		case "Secret":
+			err = kubeClient.CoreV1().Secrets(ref.namespace).Delete(ctx, ref.name, options)
+		case "ServiceAccount":
+			err = kubeClient.CoreV1().ServiceAccounts(ref.namespace).Delete(ctx, ref.name, options)
+		case "Namespace":
+			err = kubeClient.CoreV1().Namespaces().Delete(ctx, ref.name, options)
+		case "ClusterRole":
+			err = kubeClient.RbacV1().ClusterRoles().Delete(ctx, ref.name, options)
+		case "ClusterRoleBinding":
+			err = kubeClient.RbacV1().ClusterRoleBindings().Delete(ctx, ref.name, options)
+		case "Role":
+			err = kubeClient.RbacV1().Roles(ref.namespace).Delete(ctx, ref.name, options)
+		case "RoleBinding":
+			err = kubeClient.RbacV1().RoleBindings(ref.namespace).Delete(ctx, ref.name, options)
+		default:
+			err = fmt.Errorf("unsupported kind %s", ref.kind)
+		}
+		switch {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", ref, err))
+		default:
+			removed = append(removed, ref.String())
+		}
+	}
+	return removed, utilerrors.NewAggregate(errs)
+}
+
+// isOwned 检查对象是否带有 ownedBy 中的标签，ownedBy 为空时不读取对象
+func isOwned(ctx context.Context, kubeClient kubeclient.Interface, ref objectRef) (bool, error) {
+	if len(ref.ownedBy) == 0 {
+		return true, nil
+	}
+	var objectLabels map[string]string
+	switch ref.kind {
+	case "Namespace":
+		namespace, err := kubeClient.CoreV1().Namespaces().Get(ctx, ref.name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		objectLabels = namespace.Labels
+	default:
+		return false, fmt.Errorf("unsupported kind %s", ref.kind)
+	}
+	if !labels.SelectorFromSet(ref.ownedBy).Matches(labels.Set(objectLabels)) {
+		klog.InfoS("Keeping object not created by Karmada", "object", ref.String())
+		return false, nil
+	}
+	return true, nil
+}
+
+// deleteClusterObject 删除 Karmada 控制面中的 Cluster 对象，对象不存在时返回 false
+func deleteClusterObject(ctx context.Context, karmadaClient karmadaclientset.Interface, name string) (bool, error) {
+	err := karmadaClient.ClusterV1alpha1().Clusters().Delete(ctx, name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// agentCredentialObjects 返回 generateAgentKubeconfig 在 Karmada 控制面中为 karmada-agent 创建的 ServiceAccount 和 RBAC
+func agentCredentialObjects(clusterName string) []objectRef {
+	refs := []objectRef{{kind: "ServiceAccount", namespace: ClusterNamespace, name: agentServiceAccountName(clusterName)}}
+	rbacResources := register.GenerateRBACResources(clusterName, ClusterNamespace)
+	for _, obj := range rbacResources.List() {
+		refs = append(refs, objectRef{kind: obj.Kind, namespace: obj.Namespace, name: obj.Name})
+	}
+	return refs
+}
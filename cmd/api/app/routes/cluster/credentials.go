@@ -0,0 +1,465 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	"github.com/karmada-io/karmada/pkg/util/names"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/operation"
+)
+
+const (
+	// operationRotateCredentials 是轮换集群凭据操作的类型
+	operationRotateCredentials = "rotateCredentials"
+	// rotateCredentialsTimeout 是轮换集群凭据操作的总超时时间
+	rotateCredentialsTimeout = 5 * time.Minute
+	// defaultTokenExpirationSeconds 是新签发的 ServiceAccount 令牌的默认有效期
+	defaultTokenExpirationSeconds int64 = 365 * 24 * 60 * 60
+	// minTokenExpirationSeconds 是 TokenRequest API 允许的最短有效期
+	minTokenExpirationSeconds int64 = 10 * 60
+	// clusterReadyWindow 是更新凭据后观察集群保持 Ready 的时长，需覆盖 Karmada 更新集群状态的周期
+	clusterReadyWindow = 40 * time.Second
+	// serviceAccountUserPrefix 是 ServiceAccount 令牌中 sub 声明的前缀
+	serviceAccountUserPrefix = "system:serviceaccount:"
+)
+
+// 轮换集群凭据操作的步骤
+const (
+	stepCredentials = "credentials"
+	stepVerify      = "verify"
+	stepRevoke      = "revoke"
+)
+
+// rotatedSecret 是控制面中一个保存成员集群凭据的 Secret 及其轮换状态
+type rotatedSecret struct {
+	ref *clusterv1alpha1.LocalSecretReference
+	// serviceAccount 是令牌在成员集群中所属的 ServiceAccount，位于 ClusterNamespace 中
+	serviceAccount string
+	// original 是轮换前的 Secret 数据，用于回滚和吊销旧令牌
+	original map[string][]byte
+	// token 和 caData 是新的凭据
+	token  []byte
+	caData []byte
+}
+
+// String 返回 Secret 的命名空间和名称
+func (s *rotatedSecret) String() string {
+	return fmt.Sprintf("%s/%s", s.ref.Namespace, s.ref.Name)
+}
+
+// credentialRotation 是轮换推送模式集群凭据的选项
+type credentialRotation struct {
+	karmadaClient      karmadaclientset.Interface
+	controlPlaneClient kubeclient.Interface
+	cluster            *clusterv1alpha1.Cluster
+	mode               v1.CredentialRotationMode
+	expirationSeconds  int64
+	// memberClusterConfig 和 memberClusterClient 使用请求中的 kubeconfig 或当前保存的凭据访问成员集群
+	memberClusterConfig *rest.Config
+	memberClusterClient kubeclient.Interface
+	// verifiedClient 使用已验证的新凭据访问成员集群
+	verifiedClient kubeclient.Interface
+	// secrets 是 SecretRef 和 ImpersonatorSecretRef 引用的 Secret，第一个保存 Karmada 访问成员集群的凭据
+	secrets []*rotatedSecret
+}
+
+// rotationSteps 返回轮换集群凭据的步骤
+func (o *credentialRotation) rotationSteps() []operation.Step {
+	return []operation.Step{
+		{Name: stepCredentials, Run: o.obtainCredentials},
+		{Name: stepVerify, Run: o.verifyCredentials},
+		{Name: stepSecret, Run: o.updateSecrets, Rollback: o.restoreSecrets},
+		{Name: stepClusterReady, Run: o.waitForClusterStaysReady},
+		{Name: stepRevoke, Run: o.revokeTokens},
+	}
+}
+
+// obtainCredentials 获取新凭据：kubeconfig 模式使用 kubeconfig 中的令牌，并为 impersonator 签发新令牌；remint 模式为两个 ServiceAccount 签发新令牌
+func (o *credentialRotation) obtainCredentials(ctx context.Context) (string, error) {
+	caData, err := o.caData()
+	if err != nil {
+		return "", err
+	}
+	var messages []string
+	for i, secret := range o.secrets {
+		secret.caData = caData
+		if i == 0 && o.mode == v1.CredentialRotationKubeconfig {
+			secret.token = []byte(o.memberClusterConfig.BearerToken)
+			messages = append(messages, fmt.Sprintf("using the token of the kubeconfig for %s", secret))
+			continue
+		}
+		tokenRequest, err := o.memberClusterClient.CoreV1().ServiceAccounts(ClusterNamespace).CreateToken(ctx, secret.serviceAccount,
+			&authenticationv1.TokenRequest{Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &o.expirationSeconds}}, metav1.CreateOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to mint a token for ServiceAccount %s/%s: %w", ClusterNamespace, secret.serviceAccount, err)
+		}
+		secret.token = []byte(tokenRequest.Status.Token)
+		messages = append(messages, fmt.Sprintf("minted a token for ServiceAccount %s/%s, expires at %s",
+			ClusterNamespace, secret.serviceAccount, tokenRequest.Status.ExpirationTimestamp.UTC().Format(time.RFC3339)))
+	}
+	return strings.Join(messages, "; "), nil
+}
+
+// caData 返回成员集群的 CA，优先使用请求中 kubeconfig 的 CA，否则沿用当前保存的 CA
+func (o *credentialRotation) caData() ([]byte, error) {
+	config := rest.CopyConfig(o.memberClusterConfig)
+	if err := rest.LoadTLSFiles(config); err != nil {
+		return nil, err
+	}
+	if len(config.TLSClientConfig.CAData) > 0 {
+		return config.TLSClientConfig.CAData, nil
+	}
+	return o.secrets[0].original[clusterv1alpha1.SecretCADataKey], nil
+}
+
+// verifyCredentials 使用新凭据访问成员集群，检查 Karmada 管理资源和 impersonator 模拟用户的权限
+func (o *credentialRotation) verifyCredentials(ctx context.Context) (string, error) {
+	attributes := []authorizationv1.ResourceAttributes{
+		{Verb: "*", Group: "*", Resource: "*"},
+		{Verb: "impersonate", Resource: "users"},
+	}
+	for i, secret := range o.secrets {
+		config, err := karmadautil.BuildClusterConfig(o.cluster.Name,
+			func(string) (*clusterv1alpha1.Cluster, error) { return o.cluster, nil },
+			func(string, string) (*corev1.Secret, error) {
+				return &corev1.Secret{Data: map[string][]byte{
+					clusterv1alpha1.SecretTokenKey:  secret.token,
+					clusterv1alpha1.SecretCADataKey: secret.caData,
+				}}, nil
+			})
+		if err != nil {
+			return "", err
+		}
+		memberClient, err := kubeclient.NewForConfig(config)
+		if err != nil {
+			return "", err
+		}
+		review, err := memberClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes[i]},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return "", fmt.Errorf("the new credentials for %s are rejected by cluster %s: %w", secret, o.cluster.Name, err)
+		}
+		if !review.Status.Allowed {
+			return "", fmt.Errorf("the new credentials for %s are not allowed to %s in cluster %s", secret, describeAttributes(attributes[i]), o.cluster.Name)
+		}
+		if i == 0 {
+			o.verifiedClient = memberClient
+		}
+	}
+	return fmt.Sprintf("verified the new credentials against %s", o.cluster.Spec.APIEndpoint), nil
+}
+
+// updateSecrets 将新凭据写入控制面中的 Secret
+func (o *credentialRotation) updateSecrets(ctx context.Context) (string, error) {
+	var updated []string
+	for _, secret := range o.secrets {
+		data := map[string][]byte{}
+		for key, value := range secret.original {
+			data[key] = value
+		}
+		data[clusterv1alpha1.SecretTokenKey] = secret.token
+		if len(secret.caData) > 0 {
+			data[clusterv1alpha1.SecretCADataKey] = secret.caData
+		}
+		if err := o.writeSecret(ctx, secret.ref, data); err != nil {
+			return "", err
+		}
+		updated = append(updated, secret.String())
+	}
+	return fmt.Sprintf("updated secrets %s", strings.Join(updated, ", ")), nil
+}
+
+// restoreSecrets 将控制面中的 Secret 恢复为轮换前的凭据
+func (o *credentialRotation) restoreSecrets(ctx context.Context) error {
+	for _, secret := range o.secrets {
+		if err := o.writeSecret(ctx, secret.ref, secret.original); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSecret 更新 Secret 的数据，冲突时重试
+func (o *credentialRotation) writeSecret(ctx context.Context, ref *clusterv1alpha1.LocalSecretReference, data map[string][]byte) error {
+	return wait.ExponentialBackoffWithContext(ctx, wait.Backoff{Steps: 5, Duration: 100 * time.Millisecond, Factor: 2}, func(ctx context.Context) (bool, error) {
+		secret, err := o.controlPlaneClient.CoreV1().Secrets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		secret.Data = data
+		_, err = o.controlPlaneClient.CoreV1().Secrets(ref.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		if apierrors.IsConflict(err) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+}
+
+// waitForClusterStaysReady 观察集群在 clusterReadyWindow 内保持 Ready，Karmada 在下一次更新集群状态时会使用新凭据
+func (o *credentialRotation) waitForClusterStaysReady(ctx context.Context) (string, error) {
+	err := wait.PollUntilContextTimeout(ctx, clusterReadyPollInterval, clusterReadyWindow, true, func(ctx context.Context) (bool, error) {
+		cluster, err := o.karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, o.cluster.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, clusterv1alpha1.ClusterConditionReady)
+		if condition == nil || condition.Status != metav1.ConditionTrue {
+			reason := "the cluster has not reported its status"
+			if condition != nil {
+				reason = condition.Message
+			}
+			return false, fmt.Errorf("cluster %s is not Ready with the new credentials: %s", o.cluster.Name, reason)
+		}
+		return false, nil
+	})
+	// 观察窗口结束时轮询以超时返回，此时集群一直保持 Ready
+	if !wait.Interrupted(err) || ctx.Err() != nil {
+		return "", err
+	}
+	return fmt.Sprintf("cluster %s stayed Ready for %s", o.cluster.Name, clusterReadyWindow), nil
+}
+
+// revokeTokens 删除成员集群中保存旧令牌的 ServiceAccount 令牌 Secret，使旧令牌失效。
+// 通过 TokenRequest 签发的旧令牌无法吊销，只能等待其过期。此时新凭据已经生效，吊销失败只作为结果说明返回。
+func (o *credentialRotation) revokeTokens(ctx context.Context) (string, error) {
+	var messages []string
+	for _, secret := range o.secrets {
+		oldToken := secret.original[clusterv1alpha1.SecretTokenKey]
+		if bytes.Equal(oldToken, secret.token) {
+			continue
+		}
+		tokenSecret, err := o.verifiedClient.CoreV1().Secrets(ClusterNamespace).Get(ctx, secret.serviceAccount, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("failed to revoke the previous token for %s: %v", secret, err))
+			continue
+		}
+		if tokenSecret.Type != corev1.SecretTypeServiceAccountToken || !bytes.Equal(tokenSecret.Data[corev1.ServiceAccountTokenKey], oldToken) {
+			continue
+		}
+		if err = o.verifiedClient.CoreV1().Secrets(ClusterNamespace).Delete(ctx, tokenSecret.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			messages = append(messages, fmt.Sprintf("failed to revoke the previous token for %s: %v", secret, err))
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("revoked the previous token for %s by deleting Secret %s/%s", secret, ClusterNamespace, tokenSecret.Name))
+	}
+	if len(messages) == 0 {
+		return "no Secret based token to revoke, the previous tokens expire on their own", nil
+	}
+	return strings.Join(messages, "; "), nil
+}
+
+// 轮换推送模式集群的凭据，轮换在后台进行，返回可以查询进度的操作
+func handlePostClusterCredentials(c *gin.Context) {
+	rotateRequest := new(v1.RotateClusterCredentialsRequest)
+	if err := c.ShouldBind(rotateRequest); err != nil {
+		klog.ErrorS(err, "Could not read rotate credentials request")
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	o, err := newCredentialRotation(c, c.Param("name"), rotateRequest)
+	if err != nil {
+		klog.ErrorS(err, "Prepare credential rotation failed", "cluster", c.Param("name"))
+		common.Fail(c, err)
+		return
+	}
+	op, err := clusterOperations.Start(operationRotateCredentials, o.cluster.Name, rotateCredentialsTimeout, o.rotationSteps())
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	klog.InfoS("Started credential rotation", "cluster", o.cluster.Name, "mode", rotateRequest.Mode, "operation", op.ID)
+	common.Success(c, op)
+}
+
+// newCredentialRotation 校验轮换请求，读取当前凭据并创建访问成员集群的客户端
+func newCredentialRotation(c *gin.Context, name string, rotateRequest *v1.RotateClusterCredentialsRequest) (*credentialRotation, error) {
+	switch rotateRequest.Mode {
+	case v1.CredentialRotationKubeconfig:
+		if rotateRequest.MemberClusterKubeConfig == "" {
+			return nil, errors.NewBadRequest("memberClusterKubeconfig is required in kubeconfig mode")
+		}
+	case v1.CredentialRotationRemint:
+	default:
+		return nil, errors.NewBadRequest(fmt.Sprintf("unknown rotation mode %q, expected kubeconfig or remint", rotateRequest.Mode))
+	}
+	expirationSeconds := defaultTokenExpirationSeconds
+	if rotateRequest.ExpirationSeconds != nil {
+		expirationSeconds = *rotateRequest.ExpirationSeconds
+	}
+	if expirationSeconds < minTokenExpirationSeconds {
+		return nil, errors.NewBadRequest(fmt.Sprintf("expirationSeconds must be at least %d", minTokenExpirationSeconds))
+	}
+
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		return nil, err
+	}
+	controlPlaneClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		return nil, err
+	}
+	cluster, secrets, err := clusterSecrets(c, karmadaClient, controlPlaneClient, name)
+	if err != nil {
+		return nil, err
+	}
+	o := &credentialRotation{
+		karmadaClient:      karmadaClient,
+		controlPlaneClient: controlPlaneClient,
+		cluster:            cluster,
+		mode:               rotateRequest.Mode,
+		expirationSeconds:  expirationSeconds,
+	}
+	for i, secret := range secrets {
+		serviceAccount := names.GenerateServiceAccountName(cluster.Name)
+		if i == 1 {
+			serviceAccount = names.GenerateServiceAccountName("impersonator")
+		}
+		o.secrets = append(o.secrets, &rotatedSecret{
+			ref:            secretRef(cluster, i),
+			serviceAccount: serviceAccount,
+			original:       secret.Data,
+		})
+	}
+
+	if rotateRequest.MemberClusterKubeConfig != "" {
+		o.memberClusterConfig, err = client.LoadRestConfigFromKubeConfig(rotateRequest.MemberClusterKubeConfig)
+	} else {
+		o.memberClusterConfig, err = karmadautil.BuildClusterConfig(cluster.Name,
+			func(string) (*clusterv1alpha1.Cluster, error) { return cluster, nil },
+			func(string, string) (*corev1.Secret, error) { return secrets[0], nil })
+	}
+	if err != nil {
+		return nil, err
+	}
+	if o.mode == v1.CredentialRotationKubeconfig && o.memberClusterConfig.BearerToken == "" {
+		return nil, errors.NewBadRequest("the kubeconfig does not carry a bearer token, Karmada can only access push mode clusters with tokens")
+	}
+	if o.memberClusterClient, err = kubeclient.NewForConfig(o.memberClusterConfig); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// clusterSecrets 获取推送模式集群及其 SecretRef 和 ImpersonatorSecretRef 引用的 Secret
+func clusterSecrets(c *gin.Context, karmadaClient karmadaclientset.Interface, controlPlaneClient kubeclient.Interface, name string) (*clusterv1alpha1.Cluster, []*corev1.Secret, error) {
+	cluster, err := karmadaClient.ClusterV1alpha1().Clusters().Get(c, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if cluster.Spec.SyncMode != clusterv1alpha1.Push {
+		return nil, nil, errors.NewBadRequest(fmt.Sprintf("cluster %s is in %s mode, only the credentials of push mode clusters are stored in Karmada", name, cluster.Spec.SyncMode))
+	}
+	if cluster.Spec.SecretRef == nil {
+		return nil, nil, errors.NewBadRequest(fmt.Sprintf("cluster %s does not reference a secret", name))
+	}
+	var secrets []*corev1.Secret
+	for i := 0; secretRef(cluster, i) != nil; i++ {
+		ref := secretRef(cluster, i)
+		secret, err := controlPlaneClient.CoreV1().Secrets(ref.Namespace).Get(c, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return cluster, secrets, nil
+}
+
+// secretRef 按顺序返回集群的 SecretRef 和 ImpersonatorSecretRef，超出范围时返回 nil
+func secretRef(cluster *clusterv1alpha1.Cluster, i int) *clusterv1alpha1.LocalSecretReference {
+	switch i {
+	case 0:
+		return cluster.Spec.SecretRef
+	case 1:
+		return cluster.Spec.ImpersonatorSecretRef
+	default:
+		return nil
+	}
+}
+
+// 获取推送模式集群的凭据及其过期时间
+func handleGetClusterCredentials(c *gin.Context) {
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	controlPlaneClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	_, secrets, err := clusterSecrets(c, karmadaClient, controlPlaneClient, c.Param("name"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result := v1.ClusterCredentials{}
+	for i, secret := range secrets {
+		credential := describeCredential(secret)
+		if i == 0 {
+			result.Credentials = credential
+		} else {
+			result.Impersonator = credential
+		}
+	}
+	common.Success(c, result)
+}
+
+// describeCredential 从 Secret 中的令牌解析其所属的 ServiceAccount 和过期时间，令牌不是 JWT 时只返回 Secret 名称
+func describeCredential(secret *corev1.Secret) *v1.ClusterCredential {
+	credential := &v1.ClusterCredential{Secret: fmt.Sprintf("%s/%s", secret.Namespace, secret.Name)}
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(string(secret.Data[clusterv1alpha1.SecretTokenKey]), claims); err != nil {
+		return credential
+	}
+	if expires, err := claims.GetExpirationTime(); err == nil && expires != nil {
+		credential.Expires = &metav1.Time{Time: expires.Time}
+	}
+	if subject, err := claims.GetSubject(); err == nil && strings.HasPrefix(subject, serviceAccountUserPrefix) {
+		credential.ServiceAccount = strings.Replace(strings.TrimPrefix(subject, serviceAccountUserPrefix), ":", "/", 1)
+	}
+	return credential
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/karmada-io/dashboard/pkg/operation"
+)
+
+// credentialSecret 返回控制面中保存成员集群令牌的 Secret
+func credentialSecret(name, token string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ClusterNamespace, Name: name},
+		Data: map[string][]byte{
+			clusterv1alpha1.SecretTokenKey:  []byte(token),
+			clusterv1alpha1.SecretCADataKey: []byte("ca"),
+		},
+	}
+}
+
+// testRotation 返回轮换 member1 凭据的选项，新令牌已经签发
+func testRotation(cluster *clusterv1alpha1.Cluster) *credentialRotation {
+	o := &credentialRotation{
+		karmadaClient:      karmadafake.NewSimpleClientset(cluster),
+		controlPlaneClient: fake.NewSimpleClientset(credentialSecret("member1", "old"), credentialSecret("member1-impersonator", "old-impersonator")),
+		cluster:            cluster,
+	}
+	for i, tokens := range [][2]string{{"old", "new"}, {"old-impersonator", "new-impersonator"}} {
+		ref := secretRef(cluster, i)
+		o.secrets = append(o.secrets, &rotatedSecret{
+			ref:      ref,
+			original: credentialSecret(ref.Name, tokens[0]).Data,
+			token:    []byte(tokens[1]),
+		})
+	}
+	return o
+}
+
+// storedToken 返回控制面中 Secret 保存的令牌
+func storedToken(t *testing.T, o *credentialRotation, name string) string {
+	t.Helper()
+	secret, err := o.controlPlaneClient.CoreV1().Secrets(ClusterNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(secret.Data[clusterv1alpha1.SecretTokenKey])
+}
+
+func TestRotationSteps(t *testing.T) {
+	steps := (&credentialRotation{}).rotationSteps()
+	var names, rollbacks []string
+	for _, step := range steps {
+		names = append(names, step.Name)
+		if step.Rollback != nil {
+			rollbacks = append(rollbacks, step.Name)
+		}
+	}
+	if want := []string{stepCredentials, stepVerify, stepSecret, stepClusterReady, stepRevoke}; !reflect.DeepEqual(names, want) {
+		t.Errorf("rotationSteps() = %v, want %v", names, want)
+	}
+	// 只有写入 Secret 的步骤修改了控制面，旧令牌在集群保持 Ready 之后才吊销
+	if want := []string{stepSecret}; !reflect.DeepEqual(rollbacks, want) {
+		t.Errorf("rotationSteps() steps with rollback = %v, want %v", rollbacks, want)
+	}
+}
+
+func TestRotationRollback(t *testing.T) {
+	cluster := pushCluster("member1")
+	cluster.Status.Conditions = []metav1.Condition{{
+		Type: clusterv1alpha1.ClusterConditionReady, Status: metav1.ConditionFalse, Message: "Unauthorized",
+	}}
+	o := testRotation(cluster)
+	steps := o.rotationSteps()
+
+	store := operation.NewStore(time.Hour)
+	op, err := store.Start(operationRotateCredentials, cluster.Name, time.Minute, steps[2:])
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	for op.Phase == operation.PhaseRunning {
+		time.Sleep(10 * time.Millisecond)
+		if op, err = store.Get(op.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var phases []operation.StepPhase
+	for _, step := range op.Steps {
+		phases = append(phases, step.Phase)
+	}
+	want := []operation.StepPhase{operation.StepRolledBack, operation.StepFailed, operation.StepPending}
+	if op.Phase != operation.PhaseFailed || !reflect.DeepEqual(phases, want) {
+		t.Fatalf("rotation finished with phase %s and steps %v, want steps %v", op.Phase, phases, want)
+	}
+	if got := storedToken(t, o, "member1"); got != "old" {
+		t.Errorf("token after rollback = %q, want old", got)
+	}
+	if got := storedToken(t, o, "member1-impersonator"); got != "old-impersonator" {
+		t.Errorf("impersonator token after rollback = %q, want old-impersonator", got)
+	}
+}
+
+func TestUpdateSecrets(t *testing.T) {
+	o := testRotation(pushCluster("member1"))
+	if _, err := o.updateSecrets(context.TODO()); err != nil {
+		t.Fatalf("updateSecrets() error = %v", err)
+	}
+	if got := storedToken(t, o, "member1"); got != "new" {
+		t.Errorf("token = %q, want new", got)
+	}
+	if got := storedToken(t, o, "member1-impersonator"); got != "new-impersonator" {
+		t.Errorf("impersonator token = %q, want new-impersonator", got)
+	}
+	if err := o.restoreSecrets(context.TODO()); err != nil {
+		t.Fatalf("restoreSecrets() error = %v", err)
+	}
+	if got := storedToken(t, o, "member1"); got != "old" {
+		t.Errorf("restored token = %q, want old", got)
+	}
+}
+
+func TestRevokeTokens(t *testing.T) {
+	o := testRotation(pushCluster("member1"))
+	o.secrets[0].serviceAccount = "karmada-member1"
+	o.secrets[1].serviceAccount = "karmada-impersonator"
+	tokenSecret := func(name, token string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ClusterNamespace, Name: name},
+			Type:       corev1.SecretTypeServiceAccountToken,
+			Data:       map[string][]byte{corev1.ServiceAccountTokenKey: []byte(token)},
+		}
+	}
+	member := fake.NewSimpleClientset(
+		tokenSecret("karmada-member1", "old"),
+		tokenSecret("karmada-impersonator", "another"),
+	)
+	o.verifiedClient = member
+	if _, err := o.revokeTokens(context.TODO()); err != nil {
+		t.Fatalf("revokeTokens() error = %v", err)
+	}
+	if _, err := member.CoreV1().Secrets(ClusterNamespace).Get(context.TODO(), "karmada-member1", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("the Secret of the previous token is not deleted: %v", err)
+	}
+	if _, err := member.CoreV1().Secrets(ClusterNamespace).Get(context.TODO(), "karmada-impersonator", metav1.GetOptions{}); err != nil {
+		t.Errorf("the Secret of another token is deleted: %v", err)
+	}
+}
+
+func TestDescribeCredential(t *testing.T) {
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "system:serviceaccount:karmada-cluster:karmada-member1",
+		"exp": expires.Unix(),
+	}).SignedString([]byte("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	credential := describeCredential(credentialSecret("member1", token))
+	if credential.Secret != "karmada-cluster/member1" || credential.ServiceAccount != "karmada-cluster/karmada-member1" {
+		t.Errorf("describeCredential() = %+v", credential)
+	}
+	if credential.Expires == nil || !credential.Expires.Time.Equal(expires) {
+		t.Errorf("describeCredential() expires = %v, want %v", credential.Expires, expires)
+	}
+
+	credential = describeCredential(credentialSecret("member1", "opaque"))
+	if credential.Secret != "karmada-cluster/member1" || credential.ServiceAccount != "" || credential.Expires != nil {
+		t.Errorf("describeCredential() of an opaque token = %+v", credential)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
+	"github.com/karmada-io/dashboard/pkg/resource/cluster"
+)
+
+// 禁止向集群调度新的工作负载，返回更新后的集群
+func handlePostClusterCordon(c *gin.Context) {
+	memberCluster, err := updateClusterTaints(c, func(memberCluster *clusterv1alpha1.Cluster) {
+		cluster.AddTaint(memberCluster, cluster.CordonTaint)
+	})
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, memberCluster)
+}
+
+// 恢复集群的调度并停止驱逐，返回更新后的集群
+func handlePostClusterUncordon(c *gin.Context) {
+	memberCluster, err := updateClusterTaints(c, func(memberCluster *clusterv1alpha1.Cluster) {
+		cluster.RemoveTaints(memberCluster, cluster.CordonTaint, cluster.DrainTaint)
+	})
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, memberCluster)
+}
+
+// 驱逐集群上的工作负载，返回驱逐的进度
+func handlePostClusterDrain(c *gin.Context) {
+	memberCluster, err := updateClusterTaints(c, func(memberCluster *clusterv1alpha1.Cluster) {
+		cluster.AddTaint(memberCluster, cluster.CordonTaint)
+		cluster.AddTaint(memberCluster, cluster.DrainTaint)
+	})
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := drainStatus(c.Request, memberCluster)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// 获取驱逐集群的进度
+func handleGetClusterDrain(c *gin.Context) {
+	name := c.Param("name")
+	listers, err := informer.ListersForGet(c.Request, types.ResourceKindCluster, "", name)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	memberCluster, err := listers.Clusters.Get(name)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := drainStatus(c.Request, memberCluster)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// updateClusterTaints 以 mutate 修改路径参数中集群的污点
+func updateClusterTaints(c *gin.Context, mutate func(memberCluster *clusterv1alpha1.Cluster)) (*clusterv1alpha1.Cluster, error) {
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		return nil, err
+	}
+	memberCluster, err := patchCluster(c, karmadaClient, c.Param("name"), mutate)
+	if err != nil {
+		klog.ErrorS(err, "Update cluster taints failed", "cluster", c.Param("name"))
+		return nil, err
+	}
+	return memberCluster, nil
+}
+
+// drainStatus 检查请求的用户是否可以列出绑定，并返回驱逐集群的进度
+func drainStatus(request *http.Request, memberCluster *clusterv1alpha1.Cluster) (*cluster.DrainStatus, error) {
+	listers, err := informer.ListersForList(request, "", types.ResourceKindResourceBinding, types.ResourceKindClusterResourceBinding)
+	if err != nil {
+		return nil, err
+	}
+	rbs, err := listers.ResourceBindings.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	crbs, err := listers.ClusterResourceBindings.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return cluster.GetDrainStatus(memberCluster, rbs, crbs), nil
+}
@@ -17,33 +17,38 @@ limitations under the License.
 package cluster
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
-	corev1 "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	"github.com/karmada-io/karmada/pkg/util/names"
 	"k8s.io/klog/v2"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
+	"github.com/karmada-io/dashboard/pkg/operation"
 	"github.com/karmada-io/dashboard/pkg/resource/cluster"
 )
 
 // 获取集群列表
 func handleGetClusterList(c *gin.Context) {
-	// 获取Karmada客户端
-	karmadaClient := client.InClusterKarmadaClient()
+	// 检查用户权限并获取缓存的Lister
+	listers, err := informer.ListersForList(c.Request, "", types.ResourceKindCluster)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	// 解析数据选择路径参数
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	// 获取集群列表
-	result, err := cluster.GetClusterList(karmadaClient, dataSelect)
+	result, err := cluster.GetClusterList(listers.Clusters, dataSelect)
 	if err != nil {
 		// 打印错误信息
 		klog.ErrorS(err, "GetClusterList failed")
@@ -57,12 +62,16 @@ func handleGetClusterList(c *gin.Context) {
 
 // 获取集群详情
 func handleGetClusterDetail(c *gin.Context) {
-	// 获取Karmada客户端
-	karmadaClient := client.InClusterKarmadaClient()
 	// 获取集群名称
 	name := c.Param("name")
+	// 检查用户权限并获取缓存的Lister
+	listers, err := informer.ListersForGet(c.Request, types.ResourceKindCluster, "", name)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	// 获取集群详情
-	result, err := cluster.GetClusterDetail(karmadaClient, name)
+	result, err := cluster.GetClusterDetail(listers.Clusters, name)
 	if err != nil {
 		// 打印错误信息
 		klog.ErrorS(err, "GetClusterDetail failed")
@@ -74,7 +83,7 @@ func handleGetClusterDetail(c *gin.Context) {
 	common.Success(c, result)
 }
 
-// 创建集群
+// 创建集群，接入在后台进行，返回可以查询进度的接入操作
 func handlePostCluster(c *gin.Context) {
 	// 获取集群请求
 	clusterRequest := new(v1.PostClusterRequest)
@@ -83,7 +92,7 @@ func handlePostCluster(c *gin.Context) {
 		// 打印错误信息
 		klog.ErrorS(err, "Could not read cluster request")
 		// 返回错误
-		common.Fail(c, err)
+		common.Fail(c, errors.NewBindingError(err))
 		return
 	}
 	// 解析成员集群端点
@@ -97,81 +106,109 @@ func handlePostCluster(c *gin.Context) {
 	}
 	clusterRequest.MemberClusterEndpoint = memberClusterEndpoint
 	// 获取Karmada客户端
-	karmadaClient := client.InClusterKarmadaClient()
-	// 如果同步模式为拉取模式
-	if clusterRequest.SyncMode == v1alpha1.Pull {
-		memberClusterClient, err := client.KubeClientSetFromKubeConfig(clusterRequest.MemberClusterKubeConfig)
-		if err != nil {
-			klog.ErrorS(err, "Generate kubeclient from memberClusterKubeconfig failed")
-			common.Fail(c, err)
-			return
-		}
-		_, apiConfig, err := client.GetKarmadaConfig()
-		if err != nil {
-			klog.ErrorS(err, "Get apiConfig for karmada failed")
-			common.Fail(c, err)
-			return
-		}
-		// 创建拉取模式选项
-		opts := &pullModeOption{
-			karmadaClient:          karmadaClient,
-			karmadaAgentCfg:        apiConfig,
-			memberClusterNamespace: clusterRequest.MemberClusterNamespace,
-			memberClusterClient:    memberClusterClient,
-			memberClusterName:      clusterRequest.MemberClusterName,
-			memberClusterEndpoint:  clusterRequest.MemberClusterEndpoint,
-		}
-		// 访问集群
-		if err = accessClusterInPullMode(opts); err != nil {
-			klog.ErrorS(err, "accessClusterInPullMode failed")
-			common.Fail(c, err)
-		} else {
-			klog.Infof("accessClusterInPullMode success")
-			common.Success(c, "ok")
-		}
-	} else if clusterRequest.SyncMode == v1alpha1.Push {
-		// 获取成员集群REST配置
-		memberClusterRestConfig, err := client.LoadRestConfigFromKubeConfig(clusterRequest.MemberClusterKubeConfig)
-		if err != nil {
-			klog.ErrorS(err, "Generate rest config from memberClusterKubeconfig failed")
-			// 返回错误
-			common.Fail(c, err)
-			return
-		}
-		// 获取Karmada配置
-		restConfig, _, err := client.GetKarmadaConfig()
-		if err != nil {
-			klog.ErrorS(err, "Get restConfig failed")
-			// 返回错误
-			common.Fail(c, err)
-			return
-		}
-		opts := &pushModeOption{
-			karmadaClient:           karmadaClient,
-			clusterName:             clusterRequest.MemberClusterName,
-			karmadaRestConfig:       restConfig,
-			memberClusterRestConfig: memberClusterRestConfig,
-		}
-		// 访问集群
-		if err := accessClusterInPushMode(opts); err != nil {
-			klog.ErrorS(err, "accessClusterInPushMode failed")
-			// 返回错误
-			common.Fail(c, err)
-			return
-		}
-		// 打印成功信息
-		klog.Infof("accessClusterInPushMode success")
-		// 返回成功
-		common.Success(c, "ok")
-	} else {
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	_, exist, err := karmadautil.GetClusterWithKarmadaClient(karmadaClient, clusterRequest.MemberClusterName)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if exist {
+		common.Fail(c, errors.NewAlreadyExists(v1alpha1.Resource("clusters"), clusterRequest.MemberClusterName))
+		return
+	}
+	var steps []operation.Step
+	switch clusterRequest.SyncMode {
+	case v1alpha1.Pull:
+		steps, err = pullModeJoinSteps(c, clusterRequest, karmadaClient)
+	case v1alpha1.Push:
+		steps, err = pushModeJoinSteps(c, clusterRequest, karmadaClient)
+	default:
+		err = fmt.Errorf("unknown sync mode %s", clusterRequest.SyncMode)
+	}
+	if err != nil {
 		// 打印错误信息
-		klog.Errorf("Unknown sync mode %s", clusterRequest.SyncMode)
+		klog.ErrorS(err, "Prepare cluster join failed", "cluster", clusterRequest.MemberClusterName)
 		// 返回错误
-		common.Fail(c, fmt.Errorf("unknown sync mode %s", clusterRequest.SyncMode))
+		common.Fail(c, err)
+		return
+	}
+	op, err := clusterOperations.Start(operationJoin, clusterRequest.MemberClusterName, joinTimeout, steps)
+	if err != nil {
+		common.Fail(c, err)
+		return
 	}
+	klog.InfoS("Started cluster join", "cluster", clusterRequest.MemberClusterName, "syncMode", clusterRequest.SyncMode, "operation", op.ID)
+	common.Success(c, op)
 }
 
-// 更新集群
+// pullModeJoinSteps 准备拉取模式下接入集群的步骤
+func pullModeJoinSteps(c *gin.Context, clusterRequest *v1.PostClusterRequest, karmadaClient karmadaclientset.Interface) ([]operation.Step, error) {
+	memberClusterClient, err := client.KubeClientSetFromKubeConfig(clusterRequest.MemberClusterKubeConfig)
+	if err != nil {
+		klog.ErrorS(err, "Generate kubeclient from memberClusterKubeconfig failed")
+		return nil, err
+	}
+	// 以调用者身份为 karmada-agent 创建受限凭据，不能将 dashboard 自身的凭据下发到成员集群
+	controlPlaneClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		return nil, err
+	}
+	restConfig, err := client.GetKarmadaConfigFromRequest(c.Request)
+	if err != nil {
+		return nil, err
+	}
+	memberClusterNamespace := clusterRequest.MemberClusterNamespace
+	if memberClusterNamespace == "" {
+		memberClusterNamespace = names.NamespaceKarmadaSystem
+	}
+	// 解析 karmada-agent 的镜像、副本数等部署参数
+	agent, err := resolveAgentSpec(c.Request.Context(), controlPlaneClient, clusterRequest.Agent, memberClusterNamespace)
+	if err != nil {
+		return nil, err
+	}
+	// 创建拉取模式选项
+	opts := &pullModeOption{
+		karmadaClient:          karmadaClient,
+		memberClusterNamespace: memberClusterNamespace,
+		memberClusterClient:    memberClusterClient,
+		memberClusterName:      clusterRequest.MemberClusterName,
+		memberClusterEndpoint:  clusterRequest.MemberClusterEndpoint,
+		clusterProvider:        clusterRequest.ClusterProvider,
+		clusterRegion:          clusterRequest.ClusterRegion,
+		clusterZones:           clusterRequest.ClusterZones,
+		agent:                  agent,
+		controlPlaneClient:     controlPlaneClient,
+		karmadaRestConfig:      restConfig,
+	}
+	return opts.joinSteps(), nil
+}
+
+// pushModeJoinSteps 准备推送模式下接入集群的步骤
+func pushModeJoinSteps(c *gin.Context, clusterRequest *v1.PostClusterRequest, karmadaClient karmadaclientset.Interface) ([]operation.Step, error) {
+	// 获取成员集群REST配置
+	memberClusterRestConfig, err := client.LoadRestConfigFromKubeConfig(clusterRequest.MemberClusterKubeConfig)
+	if err != nil {
+		klog.ErrorS(err, "Generate rest config from memberClusterKubeconfig failed")
+		return nil, err
+	}
+	// 获取携带请求令牌的Karmada配置
+	restConfig, err := client.GetKarmadaConfigFromRequest(c.Request)
+	if err != nil {
+		klog.ErrorS(err, "Get restConfig failed")
+		return nil, err
+	}
+	opts, err := newPushModeOption(karmadaClient, clusterRequest.MemberClusterName, restConfig, memberClusterRestConfig)
+	if err != nil {
+		return nil, err
+	}
+	return opts.joinSteps(), nil
+}
+
+// 更新集群，返回更新后的集群
 func handlePutCluster(c *gin.Context) {
 	clusterRequest := new(v1.PutClusterRequest)
 	name := c.Param("name")
@@ -179,41 +216,23 @@ func handlePutCluster(c *gin.Context) {
 		// 打印错误信息
 		klog.ErrorS(err, "Could not read handlePutCluster request")
 		// 返回错误
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	if err := validatePutClusterRequest(clusterRequest); err != nil {
 		common.Fail(c, err)
 		return
 	}
 	// 获取Karmada客户端
-	karmadaClient := client.InClusterKarmadaClient()
-	memberCluster, err := karmadaClient.ClusterV1alpha1().Clusters().Get(context.TODO(), name, metav1.GetOptions{})
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
 	if err != nil {
-		// 打印错误信息
-		klog.ErrorS(err, "Get cluster failed")
-		// 返回错误
 		common.Fail(c, err)
 		return
 	}
-	// 假设前端可以获取整个标签和污点
-	labels := make(map[string]string)
-	if clusterRequest.Labels != nil {
-		for _, labelItem := range *clusterRequest.Labels {
-			labels[labelItem.Key] = labelItem.Value
-		}
-		memberCluster.Labels = labels
-	}
-	// 假设前端可以获取整个污点
-	taints := make([]corev1.Taint, 0)
-	if clusterRequest.Taints != nil {
-		for _, taintItem := range *clusterRequest.Taints {
-			taints = append(taints, corev1.Taint{
-				Key:    taintItem.Key,
-				Value:  taintItem.Value,
-				Effect: taintItem.Effect,
-			})
-		}
-		memberCluster.Spec.Taints = taints
-	}
 	// 更新集群
-	_, err = karmadaClient.ClusterV1alpha1().Clusters().Update(context.TODO(), memberCluster, metav1.UpdateOptions{})
+	memberCluster, err := patchCluster(c, karmadaClient, name, func(cluster *v1alpha1.Cluster) {
+		applyClusterUpdate(cluster, clusterRequest)
+	})
 	if err != nil {
 		// 打印错误信息
 		klog.ErrorS(err, "Update cluster failed")
@@ -221,68 +240,64 @@ func handlePutCluster(c *gin.Context) {
 		common.Fail(c, err)
 		return
 	}
-	// 返回成功
-	common.Success(c, "ok")
+	// 返回更新后的集群
+	common.Success(c, memberCluster)
 }
 
-// 删除集群
+// 删除集群，提供成员集群的 kubeconfig 时同时清理接入时在成员集群中创建的对象
 func handleDeleteCluster(c *gin.Context) {
-	// 获取上下文
-	ctx := context.Context(c)
 	// 获取删除集群请求
 	clusterRequest := new(v1.DeleteClusterRequest)
 	// 解析删除集群请求
-	if err := c.ShouldBindUri(&clusterRequest); err != nil {
+	if err := c.ShouldBindUri(clusterRequest); err != nil {
 		// 返回错误
-		common.Fail(c, err)
+		common.Fail(c, errors.NewBindingError(err))
 		return
 	}
-	clusterName := clusterRequest.MemberClusterName
+	// 请求体是可选的，未提供时只移除控制面中的对象
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(clusterRequest); err != nil {
+			common.Fail(c, errors.NewBindingError(err))
+			return
+		}
+	}
 	// 获取Karmada客户端
-	karmadaClient := client.InClusterKarmadaClient()
-	// 等待时间
-	waitDuration := time.Second * 60
-
-	err := karmadaClient.ClusterV1alpha1().Clusters().Delete(ctx, clusterName, metav1.DeleteOptions{})
-	if apierrors.IsNotFound(err) {
-		// 返回错误
-		common.Fail(c, fmt.Errorf("no cluster object %s found in karmada control Plane", clusterName))
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
 		return
 	}
+	controlPlaneClient, err := client.GetKubeClientFromRequest(c.Request)
 	if err != nil {
-		// 打印错误信息
-		klog.Errorf("Failed to delete cluster object. cluster name: %s, error: %v", clusterName, err)
-		// 返回错误
 		common.Fail(c, err)
 		return
 	}
-
-	// make sure the given cluster object has been deleted
-	err = wait.PollUntilContextTimeout(ctx, 1*time.Second, waitDuration, true, func(ctx context.Context) (done bool, err error) {
-		// 获取集群
-		_, err = karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, clusterName, metav1.GetOptions{})
-		if apierrors.IsNotFound(err) {
-			return true, nil
-		}
-		if err != nil {
-			// 打印错误信息
-			klog.Errorf("Failed to get cluster %s. err: %v", clusterName, err)
-			// 返回错误
-			return false, err
+	opts := &unjoinOption{
+		karmadaClient:          karmadaClient,
+		controlPlaneClient:     controlPlaneClient,
+		clusterName:            clusterRequest.MemberClusterName,
+		memberClusterNamespace: clusterRequest.MemberClusterNamespace,
+		force:                  clusterRequest.Force,
+		response:               v1.DeleteClusterResponse{Removed: []string{}},
+	}
+	if opts.memberClusterNamespace == "" {
+		opts.memberClusterNamespace = names.NamespaceKarmadaSystem
+	}
+	if clusterRequest.MemberClusterKubeConfig != "" {
+		if opts.memberClusterClient, err = client.KubeClientSetFromKubeConfig(clusterRequest.MemberClusterKubeConfig); err != nil {
+			common.Fail(c, err)
+			return
 		}
-		// 打印信息
-		klog.Infof("Waiting for the cluster object %s to be deleted", clusterName)
-		// 返回false
-		return false, nil
-	})
-	if err != nil {
+	}
+	if err = opts.unjoin(c.Request.Context()); err != nil {
 		// 打印错误信息
-		klog.Errorf("Failed to delete cluster object. cluster name: %s, error: %v", clusterName, err)
+		klog.ErrorS(err, "Failed to remove cluster", "cluster", clusterRequest.MemberClusterName, "removed", opts.response.Removed)
 		// 返回错误
 		common.Fail(c, err)
 		return
 	}
-	common.Success(c, "ok")
+	klog.InfoS("Removed cluster", "cluster", clusterRequest.MemberClusterName, "removed", opts.response.Removed, "warnings", len(opts.response.Warnings))
+	common.Success(c, opts.response)
 }
 
 // 解析kubeconfig中的endpoint
@@ -307,8 +322,31 @@ func init() {
 	r.GET("/cluster/:name", handleGetClusterDetail)
 	// 创建集群
 	r.POST("/cluster", handlePostCluster)
+	// 预检集群接入
+	r.POST("/cluster/preflight", handlePostClusterPreflight)
+	// 获取集群操作的进度
+	r.GET("/cluster/operation/:id", handleGetClusterOperation)
+	// 管理注册拉取模式集群的引导令牌
+	r.GET("/registration/token", handleGetBootstrapTokens)
+	r.POST("/registration/token", handlePostBootstrapToken)
+	r.DELETE("/registration/token/:id", handleDeleteBootstrapToken)
+	// 获取 karmada-agent 等待审批的证书签名请求
+	r.GET("/registration/csr", handleGetAgentCSRs)
 	// 更新集群
 	r.PUT("/cluster/:name", handlePutCluster)
 	// 删除集群
 	r.DELETE("/cluster/:name", handleDeleteCluster)
+	// 禁止调度、驱逐和恢复集群
+	r.POST("/cluster/:name/cordon", handlePostClusterCordon)
+	r.POST("/cluster/:name/uncordon", handlePostClusterUncordon)
+	r.POST("/cluster/:name/drain", handlePostClusterDrain)
+	r.GET("/cluster/:name/drain", handleGetClusterDrain)
+	// 查看各集群提供的 API 资源，检查目标集群是否提供被分发的资源类型
+	r.GET("/cluster/apienablement", handleGetClusterAPIEnablements)
+	r.GET("/cluster/apienablement/check", handleGetClusterAPIEnablementCheck)
+	// 查看集群的状态历史和可用性
+	r.GET("/cluster/:name/history", handleGetClusterHistory)
+	// 查看和轮换推送模式集群的凭据
+	r.GET("/cluster/:name/credentials", handleGetClusterCredentials)
+	r.POST("/cluster/:name/credentials", handlePostClusterCredentials)
 }
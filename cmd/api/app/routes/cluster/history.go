@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/history"
+	"github.com/karmada-io/dashboard/pkg/informer"
+)
+
+// defaultHistoryWindow 是未指定 window 时查询的时间窗口
+const defaultHistoryWindow = 24 * time.Hour
+
+// 获取集群在时间窗口内的状态变化和可用性，集群被删除后历史记录在保留时间内仍可查询
+func handleGetClusterHistory(c *gin.Context) {
+	name := c.Param("name")
+	if _, err := informer.ListersForGet(c.Request, types.ResourceKindCluster, "", name); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	window := defaultHistoryWindow
+	if value := c.Query("window"); value != "" {
+		var err error
+		if window, err = time.ParseDuration(value); err != nil || window <= 0 {
+			common.Fail(c, errors.NewBadRequest(fmt.Sprintf("invalid window %q, expected a positive duration such as 24h", value)))
+			return
+		}
+	}
+	if retention := history.Retention(); retention > 0 && window > retention {
+		window = retention
+	}
+	until := time.Now()
+	since := until.Add(-window)
+
+	timeline, err := history.Timeline(name, since, until)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	availability, err := history.ClusterAvailability(name, since, until)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, v1.ClusterHistory{Timeline: timeline, Availability: availability})
+}
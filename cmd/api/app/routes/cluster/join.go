@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/operation"
+)
+
+const (
+	// operationJoin 是接入集群操作的类型
+	operationJoin = "join"
+	// joinTimeout 是接入集群操作的总超时时间
+	joinTimeout = 15 * time.Minute
+	// operationRetention 是结束的操作状态的保留时间
+	operationRetention = time.Hour
+	// clusterReadyPollInterval 是检查集群是否 Ready 的间隔
+	clusterReadyPollInterval = 2 * time.Second
+)
+
+// 接入集群操作的步骤
+const (
+	stepNamespace    = "namespace"
+	stepSecret       = "secret"
+	stepRBAC         = "rbac"
+	stepAgent        = "agent"
+	stepClusterReady = "clusterReady"
+)
+
+// clusterOperations 保存后台运行的集群操作，例如接入集群和轮换凭据
+var clusterOperations = operation.NewStore(operationRetention)
+
+// 获取集群操作的进度
+func handleGetClusterOperation(c *gin.Context) {
+	op, err := clusterOperations.Get(c.Param("id"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	// 只有可以读取该集群的用户可以查看其操作进度
+	if err = client.Authorize(c.Request, authorizationv1.ResourceAttributes{
+		Verb:     "get",
+		Group:    clusterv1alpha1.GroupName,
+		Resource: "clusters",
+		Name:     op.Target,
+	}); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, op)
+}
+
+// ensureMemberNamespace 确保成员集群中的命名空间存在，返回命名空间是否由本次调用创建
+func ensureMemberNamespace(ctx context.Context, memberClient kubeclient.Interface, namespace string, labels map[string]string) (bool, error) {
+	_, err := memberClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err == nil {
+		return false, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	if _, err = karmadautil.EnsureNamespaceExistWithLabels(memberClient, namespace, false, labels); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// namespaceMessage 返回命名空间步骤的结果描述
+func namespaceMessage(namespace string, created bool) string {
+	if created {
+		return fmt.Sprintf("created namespace %s", namespace)
+	}
+	return fmt.Sprintf("namespace %s already exists", namespace)
+}
+
+// waitForClusterReady 等待 Cluster 对象被注册且 Ready 条件为 True
+func waitForClusterReady(ctx context.Context, karmadaClient karmadaclientset.Interface, name string) (string, error) {
+	var cluster *clusterv1alpha1.Cluster
+	reason := "the cluster is not registered yet"
+	err := wait.PollUntilContextTimeout(ctx, clusterReadyPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		cluster, err = karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				reason = err.Error()
+			}
+			return false, nil
+		}
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, clusterv1alpha1.ClusterConditionReady)
+		switch {
+		case condition == nil:
+			reason = "the cluster has not reported its status yet"
+			return false, nil
+		case condition.Status != metav1.ConditionTrue:
+			reason = condition.Message
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("cluster %s is not Ready: %s", name, reason)
+	}
+	return fmt.Sprintf("cluster %s is Ready, Kubernetes %s", name, cluster.Status.KubernetesVersion), nil
+}
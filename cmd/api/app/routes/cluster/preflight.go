@@ -0,0 +1,364 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	clustervalidation "github.com/karmada-io/karmada/pkg/apis/cluster/validation"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	"github.com/karmada-io/karmada/pkg/util/names"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+)
+
+const (
+	// preflightTimeout 是预检访问成员集群的超时时间
+	preflightTimeout = 10 * time.Second
+	// maxMinorVersionSkew 是成员集群与 Karmada 控制面 Kubernetes 次版本号的最大差距，超出时给出警告
+	maxMinorVersionSkew = 3
+)
+
+// preflight 收集集群接入预检的检查结果
+type preflight struct {
+	response v1.PreflightResponse
+}
+
+// add 添加一项检查结果，并更新整体结果
+func (p *preflight) add(name string, status v1.PreflightStatus, format string, args ...interface{}) {
+	p.response.Checks = append(p.response.Checks, v1.PreflightCheck{
+		Name:    name,
+		Status:  status,
+		Message: fmt.Sprintf(format, args...),
+	})
+	if status == v1.PreflightFail || (status == v1.PreflightWarn && p.response.Status == v1.PreflightPass) {
+		p.response.Status = status
+	}
+}
+
+// 预检集群接入，只读取而不修改 Karmada 控制面和成员集群中的任何对象
+func handlePostClusterPreflight(c *gin.Context) {
+	clusterRequest := new(v1.PostClusterRequest)
+	if err := c.ShouldBind(clusterRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	controlPlaneClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, runPreflight(c.Request, karmadaClient, controlPlaneClient, clusterRequest))
+}
+
+// runPreflight 依次执行集群接入的各项检查，成员集群无法访问时跳过依赖成员集群的检查
+func runPreflight(request *http.Request, karmadaClient karmadaclientset.Interface, controlPlaneClient kubeclient.Interface,
+	clusterRequest *v1.PostClusterRequest) *v1.PreflightResponse {
+	ctx := request.Context()
+	p := &preflight{response: v1.PreflightResponse{Status: v1.PreflightPass}}
+	if clusterRequest.SyncMode != v1alpha1.Push && clusterRequest.SyncMode != v1alpha1.Pull {
+		p.add("syncMode", v1.PreflightFail, "unknown sync mode %q, expected Push or Pull", clusterRequest.SyncMode)
+		return &p.response
+	}
+	p.checkClusterName(ctx, karmadaClient, clusterRequest.MemberClusterName)
+	p.checkControlPlanePermissions(request, clusterRequest.SyncMode)
+	p.checkExecutionNamespace(ctx, controlPlaneClient, clusterRequest.MemberClusterName)
+
+	memberConfig, err := client.LoadRestConfigFromKubeConfig(clusterRequest.MemberClusterKubeConfig)
+	if err != nil {
+		p.add("kubeconfig", v1.PreflightFail, "cannot parse the kubeconfig of the member cluster: %v", err)
+		return &p.response
+	}
+	p.add("kubeconfig", v1.PreflightPass, "the kubeconfig points to %s", memberConfig.Host)
+
+	memberConfig = rest.CopyConfig(memberConfig)
+	memberConfig.Timeout = preflightTimeout
+	memberClient, err := kubeclient.NewForConfig(memberConfig)
+	if err != nil {
+		p.add("reachability", v1.PreflightFail, "cannot create a client for the member cluster: %v", err)
+		return &p.response
+	}
+	memberVersion, err := memberClient.Discovery().ServerVersion()
+	if err != nil {
+		p.add("reachability", v1.PreflightFail, "the API server of the member cluster is not reachable: %v", err)
+		return &p.response
+	}
+	p.add("reachability", v1.PreflightPass, "the API server of the member cluster is reachable")
+
+	p.checkVersionSkew(karmadaClient, memberVersion.GitVersion)
+	p.checkClusterID(karmadaClient, memberClient)
+	namespace := ClusterNamespace
+	if clusterRequest.SyncMode == v1alpha1.Pull {
+		namespace = clusterRequest.MemberClusterNamespace
+		if namespace == "" {
+			namespace = names.NamespaceKarmadaSystem
+		}
+		p.checkAgent(ctx, controlPlaneClient, clusterRequest.Agent, namespace)
+	}
+	p.checkMemberPermissions(ctx, memberClient, clusterRequest.SyncMode, namespace)
+	p.checkMemberNamespace(ctx, memberClient, clusterRequest.SyncMode, namespace)
+	return &p.response
+}
+
+// checkClusterName 检查集群名称是否合法且未被使用
+func (p *preflight) checkClusterName(ctx context.Context, karmadaClient karmadaclientset.Interface, name string) {
+	if errs := clustervalidation.ValidateClusterName(name); len(errs) > 0 {
+		p.add("clusterName", v1.PreflightFail, "invalid cluster name %q: %s", name, strings.Join(errs, ", "))
+		return
+	}
+	_, err := karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		p.add("clusterName", v1.PreflightFail, "a cluster named %s is already registered", name)
+	case apierrors.IsNotFound(err):
+		p.add("clusterName", v1.PreflightPass, "the cluster name %s is available", name)
+	default:
+		p.add("clusterName", v1.PreflightFail, "cannot check the cluster name: %v", err)
+	}
+}
+
+// checkClusterID 检查成员集群的 ID 是否已被其他集群注册
+func (p *preflight) checkClusterID(karmadaClient karmadaclientset.Interface, memberClient kubeclient.Interface) {
+	id, err := karmadautil.ObtainClusterID(memberClient)
+	if err != nil {
+		p.add("clusterID", v1.PreflightFail, "cannot read the ID of the member cluster: %v", err)
+		return
+	}
+	unique, name, err := karmadautil.IsClusterIdentifyUnique(karmadaClient, id)
+	switch {
+	case err != nil:
+		p.add("clusterID", v1.PreflightFail, "cannot check the cluster ID: %v", err)
+	case !unique:
+		p.add("clusterID", v1.PreflightFail, "the same cluster is already registered with name %s", name)
+	default:
+		p.add("clusterID", v1.PreflightPass, "the cluster ID %s is not registered yet", id)
+	}
+}
+
+// checkVersionSkew 比较成员集群与 Karmada 控制面的 Kubernetes 版本
+func (p *preflight) checkVersionSkew(karmadaClient karmadaclientset.Interface, memberGitVersion string) {
+	controlPlaneVersion, err := karmadaClient.Discovery().ServerVersion()
+	if err != nil {
+		p.add("versionSkew", v1.PreflightWarn, "cannot read the version of Karmada apiserver: %v", err)
+		return
+	}
+	controlPlane, err := version.ParseGeneric(controlPlaneVersion.GitVersion)
+	if err != nil {
+		p.add("versionSkew", v1.PreflightWarn, "cannot parse the version %q of Karmada apiserver", controlPlaneVersion.GitVersion)
+		return
+	}
+	member, err := version.ParseGeneric(memberGitVersion)
+	if err != nil {
+		p.add("versionSkew", v1.PreflightWarn, "cannot parse the version %q of the member cluster", memberGitVersion)
+		return
+	}
+	skew := int(controlPlane.Minor()) - int(member.Minor())
+	if controlPlane.Major() != member.Major() || skew > maxMinorVersionSkew || skew < -maxMinorVersionSkew {
+		p.add("versionSkew", v1.PreflightWarn, "the member cluster runs Kubernetes %s, which is more than %d minor versions away from %s of Karmada apiserver",
+			memberGitVersion, maxMinorVersionSkew, controlPlaneVersion.GitVersion)
+		return
+	}
+	p.add("versionSkew", v1.PreflightPass, "the member cluster runs Kubernetes %s, Karmada apiserver runs %s",
+		memberGitVersion, controlPlaneVersion.GitVersion)
+}
+
+// checkAgent 检查 karmada-agent 的部署参数，镜像版本与 Karmada 控制面不一致或无法比较时给出警告
+func (p *preflight) checkAgent(ctx context.Context, controlPlaneClient kubeclient.Interface, opts *v1.AgentOptions, namespace string) {
+	agent, err := resolveAgentSpec(ctx, controlPlaneClient, opts, namespace)
+	if err != nil {
+		p.add("agent", v1.PreflightFail, "invalid karmada-agent options: %v", err)
+		return
+	}
+	controlPlane, err := controlPlaneVersion(ctx, controlPlaneClient)
+	if err != nil {
+		p.add("agent", v1.PreflightWarn, "karmada-agent will be deployed with image %s, but %v", agent.image, err)
+		return
+	}
+	if tag := imageTag(agent.image); tag != controlPlane {
+		p.add("agent", v1.PreflightWarn, "karmada-agent image %s does not match the Karmada version %s", agent.image, controlPlane)
+		return
+	}
+	p.add("agent", v1.PreflightPass, "karmada-agent will be deployed with image %s", agent.image)
+}
+
+// controlPlaneAttributes 返回接入集群时请求的用户在 Karmada 控制面中需要的权限
+func controlPlaneAttributes(syncMode v1alpha1.ClusterSyncMode) []authorizationv1.ResourceAttributes {
+	attributes := []authorizationv1.ResourceAttributes{
+		{Verb: "create", Group: v1alpha1.GroupName, Resource: "clusters"},
+		{Verb: "create", Resource: "namespaces"},
+		{Verb: "create", Resource: "secrets", Namespace: ClusterNamespace},
+	}
+	if syncMode == v1alpha1.Pull {
+		// 为 karmada-agent 创建 ServiceAccount 和 RBAC
+		attributes = append(attributes,
+			authorizationv1.ResourceAttributes{Verb: "create", Resource: "serviceaccounts", Namespace: ClusterNamespace},
+			authorizationv1.ResourceAttributes{Verb: "create", Group: rbacGroup, Resource: "clusterroles"},
+			authorizationv1.ResourceAttributes{Verb: "create", Group: rbacGroup, Resource: "clusterrolebindings"},
+			authorizationv1.ResourceAttributes{Verb: "create", Group: rbacGroup, Resource: "roles"},
+			authorizationv1.ResourceAttributes{Verb: "create", Group: rbacGroup, Resource: "rolebindings"},
+		)
+	}
+	return attributes
+}
+
+// memberAttributes 返回接入集群时成员集群 kubeconfig 的用户需要的权限
+func memberAttributes(syncMode v1alpha1.ClusterSyncMode, namespace string) []authorizationv1.ResourceAttributes {
+	attributes := []authorizationv1.ResourceAttributes{
+		{Verb: "get", Resource: "namespaces", Name: metav1.NamespaceSystem},
+		{Verb: "create", Resource: "namespaces"},
+		{Verb: "create", Resource: "serviceaccounts", Namespace: namespace},
+		{Verb: "create", Resource: "secrets", Namespace: namespace},
+		{Verb: "create", Group: rbacGroup, Resource: "clusterroles"},
+		{Verb: "create", Group: rbacGroup, Resource: "clusterrolebindings"},
+	}
+	if syncMode == v1alpha1.Pull {
+		attributes = append(attributes, authorizationv1.ResourceAttributes{Verb: "create", Group: "apps", Resource: "deployments", Namespace: namespace})
+	}
+	return attributes
+}
+
+// rbacGroup 是 RBAC 资源的 API 组
+const rbacGroup = "rbac.authorization.k8s.io"
+
+// checkControlPlanePermissions 检查请求的用户在 Karmada 控制面中的权限
+func (p *preflight) checkControlPlanePermissions(request *http.Request, syncMode v1alpha1.ClusterSyncMode) {
+	var denied []string
+	for _, attributes := range controlPlaneAttributes(syncMode) {
+		if err := client.Authorize(request, attributes); err != nil {
+			if !apierrors.IsForbidden(err) {
+				p.add("controlPlanePermissions", v1.PreflightFail, "cannot check the permissions in Karmada control plane: %v", err)
+				return
+			}
+			denied = append(denied, describeAttributes(attributes))
+		}
+	}
+	if len(denied) > 0 {
+		p.add("controlPlanePermissions", v1.PreflightFail, "missing permissions in Karmada control plane: %s", strings.Join(denied, ", "))
+		return
+	}
+	p.add("controlPlanePermissions", v1.PreflightPass, "the user has the permissions required in Karmada control plane")
+}
+
+// checkMemberPermissions 使用 SelfSubjectAccessReview 检查成员集群 kubeconfig 的用户的权限
+func (p *preflight) checkMemberPermissions(ctx context.Context, memberClient kubeclient.Interface, syncMode v1alpha1.ClusterSyncMode, namespace string) {
+	var denied []string
+	for _, attributes := range memberAttributes(syncMode, namespace) {
+		attributes := attributes
+		review, err := memberClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			p.add("memberPermissions", v1.PreflightFail, "cannot check the permissions in the member cluster: %v", err)
+			return
+		}
+		if !review.Status.Allowed {
+			denied = append(denied, describeAttributes(attributes))
+		}
+	}
+	if len(denied) > 0 {
+		p.add("memberPermissions", v1.PreflightFail, "missing permissions in the member cluster: %s", strings.Join(denied, ", "))
+		return
+	}
+	// 创建的 ClusterRole 包含用户自身未必拥有的权限，需要 escalate 权限或集群管理员权限
+	escalate := authorizationv1.ResourceAttributes{Verb: "escalate", Group: rbacGroup, Resource: "clusterroles"}
+	review, err := memberClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &escalate},
+	}, metav1.CreateOptions{})
+	if err != nil || !review.Status.Allowed {
+		p.add("memberPermissions", v1.PreflightWarn, "the user may not be able to grant the permissions of karmada in the member cluster, escalate on clusterroles is not allowed")
+		return
+	}
+	p.add("memberPermissions", v1.PreflightPass, "the kubeconfig has the permissions required in the member cluster")
+}
+
+// describeAttributes 返回权限的描述，例如 create secrets in karmada-cluster
+func describeAttributes(attributes authorizationv1.ResourceAttributes) string {
+	resource := attributes.Resource
+	if attributes.Group != "" {
+		resource = fmt.Sprintf("%s.%s", attributes.Resource, attributes.Group)
+	}
+	if attributes.Namespace != "" {
+		return fmt.Sprintf("%s %s in %s", attributes.Verb, resource, attributes.Namespace)
+	}
+	return fmt.Sprintf("%s %s", attributes.Verb, resource)
+}
+
+// checkExecutionNamespace 检查 Karmada 控制面中集群的执行命名空间是否残留自之前的接入
+func (p *preflight) checkExecutionNamespace(ctx context.Context, controlPlaneClient kubeclient.Interface, clusterName string) {
+	name := names.GenerateExecutionSpaceName(clusterName)
+	namespace, err := controlPlaneClient.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		p.add("executionNamespace", v1.PreflightPass, "the execution namespace %s does not exist yet", name)
+	case err != nil:
+		p.add("executionNamespace", v1.PreflightWarn, "cannot check the execution namespace %s: %v", name, err)
+	case namespace.Status.Phase == corev1.NamespaceTerminating:
+		p.add("executionNamespace", v1.PreflightFail, "the execution namespace %s of a previous cluster with the same name is still terminating", name)
+	default:
+		p.add("executionNamespace", v1.PreflightWarn, "the execution namespace %s is left over from a previous cluster with the same name", name)
+	}
+}
+
+// checkMemberNamespace 检查成员集群中部署 Karmada 组件的命名空间的状态
+func (p *preflight) checkMemberNamespace(ctx context.Context, memberClient kubeclient.Interface, syncMode v1alpha1.ClusterSyncMode, name string) {
+	namespace, err := memberClient.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		p.add("memberNamespace", v1.PreflightPass, "the namespace %s will be created in the member cluster", name)
+		return
+	case err != nil:
+		p.add("memberNamespace", v1.PreflightWarn, "cannot check the namespace %s in the member cluster: %v", name, err)
+		return
+	case namespace.Status.Phase == corev1.NamespaceTerminating:
+		p.add("memberNamespace", v1.PreflightFail, "the namespace %s in the member cluster is terminating", name)
+		return
+	}
+	if syncMode == v1alpha1.Pull {
+		_, err = memberClient.AppsV1().Deployments(name).Get(ctx, KarmadaAgentName, metav1.GetOptions{})
+		if err == nil {
+			p.add("memberNamespace", v1.PreflightFail, "%s is already deployed in the namespace %s of the member cluster", KarmadaAgentName, name)
+			return
+		}
+		if !apierrors.IsNotFound(err) {
+			klog.V(4).InfoS("Cannot check for an existing karmada-agent", "namespace", name, "err", err)
+		}
+	}
+	p.add("memberNamespace", v1.PreflightPass, "the namespace %s already exists in the member cluster and will be reused", name)
+}
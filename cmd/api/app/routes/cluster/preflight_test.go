@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+)
+
+func TestCheckVersionSkew(t *testing.T) {
+	tests := []struct {
+		name         string
+		controlPlane string
+		member       string
+		want         v1.PreflightStatus
+	}{
+		{name: "same version", controlPlane: "v1.31.3", member: "v1.31.0", want: v1.PreflightPass},
+		{name: "older member within skew", controlPlane: "v1.31.3", member: "v1.28.9", want: v1.PreflightPass},
+		{name: "newer member within skew", controlPlane: "v1.28.0", member: "v1.31.2-eks-1", want: v1.PreflightPass},
+		{name: "older member beyond skew", controlPlane: "v1.31.3", member: "v1.27.1", want: v1.PreflightWarn},
+		{name: "newer member beyond skew", controlPlane: "v1.27.0", member: "v1.31.0", want: v1.PreflightWarn},
+		{name: "different major version", controlPlane: "v1.31.0", member: "v2.31.0", want: v1.PreflightWarn},
+		{name: "unparsable member version", controlPlane: "v1.31.0", member: "unknown", want: v1.PreflightWarn},
+		{name: "unparsable control plane version", controlPlane: "", member: "v1.31.0", want: v1.PreflightWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := karmadafake.NewSimpleClientset()
+			client.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: tt.controlPlane}
+			p := &preflight{response: v1.PreflightResponse{Status: v1.PreflightPass}}
+			p.checkVersionSkew(client, tt.member)
+			if len(p.response.Checks) != 1 || p.response.Checks[0].Name != "versionSkew" {
+				t.Fatalf("checkVersionSkew() checks = %v", p.response.Checks)
+			}
+			if got := p.response.Checks[0].Status; got != tt.want {
+				t.Errorf("checkVersionSkew() status = %s, want %s: %s", got, tt.want, p.response.Checks[0].Message)
+			}
+		})
+	}
+}
+
+func TestCheckAgent(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects []string
+		opts    *v1.AgentOptions
+		want    v1.PreflightStatus
+	}{
+		{
+			name:    "version of the control plane",
+			objects: []string{"docker.io/karmada/karmada-controller-manager:v1.13.0"},
+			want:    v1.PreflightPass,
+		},
+		{
+			name:    "version differs from the control plane",
+			objects: []string{"docker.io/karmada/karmada-controller-manager:v1.13.0"},
+			opts:    &v1.AgentOptions{Version: "v1.12.0"},
+			want:    v1.PreflightWarn,
+		},
+		{
+			name: "version of the control plane is unknown",
+			opts: &v1.AgentOptions{Version: "v1.13.0"},
+			want: v1.PreflightWarn,
+		},
+		{
+			name: "no version to deploy",
+			want: v1.PreflightFail,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			for _, image := range tt.objects {
+				if err := client.Tracker().Add(controllerManager(image)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			p := &preflight{response: v1.PreflightResponse{Status: v1.PreflightPass}}
+			p.checkAgent(context.TODO(), client, tt.opts, "karmada-system")
+			if p.response.Status != tt.want {
+				t.Errorf("checkAgent() status = %s, want %s: %v", p.response.Status, tt.want, p.response.Checks)
+			}
+		})
+	}
+}
+
+func TestPreflightStatus(t *testing.T) {
+	p := &preflight{response: v1.PreflightResponse{Status: v1.PreflightPass}}
+	p.add("a", v1.PreflightPass, "pass")
+	if p.response.Status != v1.PreflightPass {
+		t.Errorf("status after a pass = %s", p.response.Status)
+	}
+	p.add("b", v1.PreflightWarn, "warn")
+	p.add("c", v1.PreflightFail, "fail")
+	p.add("d", v1.PreflightWarn, "warn")
+	if p.response.Status != v1.PreflightFail {
+		t.Errorf("status after a failure = %s, want %s", p.response.Status, v1.PreflightFail)
+	}
+	if len(p.response.Checks) != 4 {
+		t.Errorf("checks = %v", p.response.Checks)
+	}
+}
@@ -0,0 +1,525 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	clustervalidation "github.com/karmada-io/karmada/pkg/apis/cluster/validation"
+	"github.com/karmada-io/karmada/pkg/karmadactl/register"
+	tokenutil "github.com/karmada-io/karmada/pkg/karmadactl/util/bootstraptoken"
+	"github.com/karmada-io/karmada/pkg/util/lifted/pubkeypin"
+	"github.com/karmada-io/karmada/pkg/util/names"
+	batchv1 "k8s.io/api/batch/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
+	bootstraputil "k8s.io/cluster-bootstrap/token/util"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+)
+
+const (
+	// karmadactlName 是 karmadactl 的命令和镜像名称
+	karmadactlName = "karmadactl"
+	// registerName 是注册清单中 ServiceAccount、RBAC 和 Job 的名称
+	registerName = "karmada-register"
+	// registerTokenSecretName 是注册清单中保存引导令牌的 Secret 名称
+	registerTokenSecretName = "karmada-bootstrap-token"
+	// registerTokenEnv 是注册 Job 中引导令牌的环境变量
+	registerTokenEnv = "KARMADA_BOOTSTRAP_TOKEN"
+	// registerBackoffLimit 是注册 Job 的重试次数
+	registerBackoffLimit = int32(3)
+)
+
+// registration 是在成员集群中执行 karmadactl register 所需的参数
+type registration struct {
+	endpoint     string
+	caCertHashes []string
+	clusterName  string
+	namespace    string
+	provider     string
+	region       string
+	zones        []string
+	agent        *agentSpec
+	// karmadactlImage 是注册 Job 使用的 karmadactl 镜像
+	karmadactlImage string
+}
+
+// args 返回 karmadactl register 的参数
+func (r *registration) args(token string) []string {
+	args := []string{
+		"register",
+		strings.TrimPrefix(r.endpoint, "https://"),
+		fmt.Sprintf("--token=%s", token),
+		fmt.Sprintf("--discovery-token-ca-cert-hash=%s", strings.Join(r.caCertHashes, ",")),
+		fmt.Sprintf("--namespace=%s", r.namespace),
+		fmt.Sprintf("--karmada-agent-image=%s", r.agent.image),
+		fmt.Sprintf("--karmada-agent-replicas=%d", r.agent.replicas),
+	}
+	if r.clusterName != "" {
+		args = append(args, fmt.Sprintf("--cluster-name=%s", r.clusterName))
+	}
+	if r.provider != "" {
+		args = append(args, fmt.Sprintf("--cluster-provider=%s", r.provider))
+	}
+	if r.region != "" {
+		args = append(args, fmt.Sprintf("--cluster-region=%s", r.region))
+	}
+	if len(r.zones) > 0 {
+		args = append(args, fmt.Sprintf("--cluster-zones=%s", strings.Join(r.zones, ",")))
+	}
+	return args
+}
+
+// command 返回在成员集群中使用其 kubeconfig 执行的 karmadactl register 命令
+func (r *registration) command(token string) string {
+	return karmadactlName + " " + strings.Join(r.args(token), " ")
+}
+
+// manifests 返回在成员集群中运行 karmadactl register 的清单。Job 使用集群内的 ServiceAccount 访问成员集群，
+// 只授予 karmadactl register 创建 karmada-agent 所需的权限，注册完成后可以删除这些对象
+func (r *registration) manifests(token string) (string, error) {
+	labels := map[string]string{"app": registerName}
+	objs := []runtime.Object{
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: r.namespace},
+		},
+		&corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: registerTokenSecretName, Namespace: r.namespace, Labels: labels},
+			Type:       corev1.SecretTypeOpaque,
+			StringData: map[string]string{"token": token},
+		},
+		&corev1.ServiceAccount{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+			ObjectMeta: metav1.ObjectMeta{Name: registerName, Namespace: r.namespace, Labels: labels},
+		},
+		&rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+			ObjectMeta: metav1.ObjectMeta{Name: registerName, Labels: labels},
+			Rules:      registerClusterRules(),
+		},
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: registerName, Labels: labels},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: registerName},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: registerName, Namespace: r.namespace}},
+		},
+		&rbacv1.Role{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "Role"},
+			ObjectMeta: metav1.ObjectMeta{Name: registerName, Namespace: r.namespace, Labels: labels},
+			Rules:      registerNamespaceRules(),
+		},
+		&rbacv1.RoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "RoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: registerName, Namespace: r.namespace, Labels: labels},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: registerName},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: registerName, Namespace: r.namespace}},
+		},
+		r.job(labels),
+	}
+	var buf bytes.Buffer
+	for i, obj := range objs {
+		content, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(content)
+	}
+	return buf.String(), nil
+}
+
+// registerClusterRules 返回 karmadactl register 在成员集群中需要的集群级权限。karmadactl register 为 karmada-agent
+// 创建拥有全部权限的 ClusterRole 并绑定到其 ServiceAccount，因此需要对该 ClusterRole 的 escalate 和 bind 权限，
+// 但这些权限只限于 karmada-agent 的 ClusterRole 和 ClusterRoleBinding
+func registerClusterRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"namespaces"}, Verbs: []string{"get", "create"}},
+		{APIGroups: []string{rbacv1.GroupName}, Resources: []string{"clusterroles", "clusterrolebindings"}, Verbs: []string{"create"}},
+		{
+			APIGroups:     []string{rbacv1.GroupName},
+			Resources:     []string{"clusterroles"},
+			ResourceNames: []string{KarmadaAgentName},
+			Verbs:         []string{"get", "update", "escalate", "bind"},
+		},
+		{
+			APIGroups:     []string{rbacv1.GroupName},
+			Resources:     []string{"clusterrolebindings"},
+			ResourceNames: []string{KarmadaAgentName},
+			Verbs:         []string{"get", "update"},
+		},
+	}
+}
+
+// registerNamespaceRules 返回 karmadactl register 在 karmada-agent 所在命名空间中需要的权限，
+// 用于创建 karmada-agent 的 kubeconfig Secret、ServiceAccount 和 Deployment 并等待 Deployment 就绪
+func registerNamespaceRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"secrets", "serviceaccounts"}, Verbs: []string{"get", "create", "update"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "create", "update"}},
+	}
+}
+
+// job 返回运行 karmadactl register 的 Job，引导令牌通过环境变量从 Secret 中读取
+func (r *registration) job(labels map[string]string) *batchv1.Job {
+	backoffLimit := registerBackoffLimit
+	return &batchv1.Job{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+		ObjectMeta: metav1.ObjectMeta{Name: registerName, Namespace: r.namespace, Labels: labels},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: registerName,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    karmadactlName,
+						Image:   r.karmadactlImage,
+						Command: []string{"/bin/" + karmadactlName},
+						Args:    r.args(fmt.Sprintf("$(%s)", registerTokenEnv)),
+						Env: []corev1.EnvVar{{
+							Name: registerTokenEnv,
+							ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: registerTokenSecretName},
+								Key:                  "token",
+							}},
+						}},
+						// karmadactl register 会将 karmada-agent 的证书写入 /etc/karmada
+						VolumeMounts: []corev1.VolumeMount{{Name: "karmada-dir", MountPath: register.KarmadaDir}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name:         "karmada-dir",
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// registrationEndpoint 返回成员集群访问 Karmada apiserver 的地址和 CA 公钥的哈希。优先使用 kube-public/cluster-info，
+// karmadactl register 也通过它发现控制面；不存在时使用 dashboard 访问 Karmada apiserver 的配置
+func registrationEndpoint(ctx context.Context, request *http.Request, controlPlaneClient kubeclient.Interface) (string, []byte, error) {
+	clusterInfo, err := controlPlaneClient.CoreV1().ConfigMaps(metav1.NamespacePublic).Get(ctx, bootstrapapi.ConfigMapClusterInfo, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		config, err := clientcmd.Load([]byte(clusterInfo.Data[bootstrapapi.KubeConfigKey]))
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse %s/%s: %w", metav1.NamespacePublic, bootstrapapi.ConfigMapClusterInfo, err)
+		}
+		if cluster := tokenutil.GetClusterFromKubeConfig(config, ""); cluster != nil && len(cluster.CertificateAuthorityData) > 0 {
+			return cluster.Server, cluster.CertificateAuthorityData, nil
+		}
+	case !apierrors.IsNotFound(err):
+		return "", nil, err
+	}
+	klog.V(2).InfoS("No usable cluster-info on Karmada control plane, using the address of Karmada apiserver of the dashboard")
+	restConfig, err := client.GetKarmadaConfigFromRequest(request)
+	if err != nil {
+		return "", nil, err
+	}
+	tlsConfig := rest.CopyConfig(restConfig)
+	if err = rest.LoadTLSFiles(tlsConfig); err != nil {
+		return "", nil, err
+	}
+	if len(tlsConfig.TLSClientConfig.CAData) == 0 {
+		return "", nil, errors.NewInternal("cannot determine the CA of Karmada apiserver, create the kube-public/cluster-info ConfigMap on Karmada control plane")
+	}
+	return restConfig.Host, tlsConfig.TLSClientConfig.CAData, nil
+}
+
+// caCertHashes 返回 CA 证书公钥的哈希，格式与 kubeadm 和 karmadactl 的 --discovery-token-ca-cert-hash 相同
+func caCertHashes(caData []byte) ([]string, error) {
+	certs, err := certutil.ParseCertsPEM(caData)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, 0, len(certs))
+	for _, cert := range certs {
+		hashes = append(hashes, pubkeypin.Hash(cert))
+	}
+	return hashes, nil
+}
+
+// toBootstrapToken 将引导令牌转换为响应，不包含令牌的私密部分
+func toBootstrapToken(token *tokenutil.BootstrapToken) v1.BootstrapToken {
+	return v1.BootstrapToken{
+		ID:          token.Token.ID,
+		Description: token.Description,
+		Expires:     token.Expires,
+		Usages:      token.Usages,
+		Groups:      token.Groups,
+	}
+}
+
+// 创建引导令牌，并返回在成员集群中注册的命令和清单
+func handlePostBootstrapToken(c *gin.Context) {
+	tokenRequest := new(v1.CreateBootstrapTokenRequest)
+	if err := c.ShouldBind(tokenRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	ttl, err := bootstrapTokenTTL(tokenRequest.TTL)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if tokenRequest.ClusterName != "" {
+		if errs := clustervalidation.ValidateClusterName(tokenRequest.ClusterName); len(errs) > 0 {
+			common.Fail(c, errors.NewBadRequest(fmt.Sprintf("invalid cluster name %q: %s", tokenRequest.ClusterName, strings.Join(errs, ", "))))
+			return
+		}
+	}
+	controlPlaneClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	// 先准备注册参数，避免无法生成注册命令时留下无用的令牌
+	reg, err := newRegistration(c, controlPlaneClient, tokenRequest)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+
+	bootstrapToken, err := createBootstrapToken(controlPlaneClient, ttl, tokenRequest.Description)
+	if err != nil {
+		klog.ErrorS(err, "Create bootstrap token failed")
+		common.Fail(c, err)
+		return
+	}
+	tokenString := bootstrapToken.Token.ID + "." + bootstrapToken.Token.Secret
+	response := v1.CreateBootstrapTokenResponse{
+		BootstrapToken:  toBootstrapToken(bootstrapToken),
+		RegisterCommand: reg.command(tokenString),
+	}
+	response.Token = tokenString
+	if ttl > 0 {
+		response.Expires = &metav1.Time{Time: time.Now().Add(ttl)}
+	}
+	if tokenRequest.ClusterName != "" {
+		if response.Manifests, err = reg.manifests(tokenString); err != nil {
+			common.Fail(c, err)
+			return
+		}
+	}
+	common.Success(c, response)
+}
+
+// bootstrapTokenTTL 解析引导令牌的有效期，为空时使用 karmadactl token create 的默认有效期，0 表示永不过期
+func bootstrapTokenTTL(ttl string) (time.Duration, error) {
+	if ttl == "" {
+		return tokenutil.DefaultTokenDuration, nil
+	}
+	duration, err := time.ParseDuration(ttl)
+	if err != nil || duration < 0 {
+		return 0, errors.NewBadRequest(fmt.Sprintf("invalid ttl %q, expected a duration such as 24h", ttl))
+	}
+	return duration, nil
+}
+
+// createBootstrapToken 在 Karmada 控制面中创建引导令牌，令牌的用途和用户组与 karmadactl token create 相同，
+// 只能用于 karmadactl register 发现控制面和为 karmada-agent 申请证书
+func createBootstrapToken(controlPlaneClient kubeclient.Interface, ttl time.Duration, description string) (*tokenutil.BootstrapToken, error) {
+	bootstrapToken, err := tokenutil.GenerateRandomBootstrapToken(&metav1.Duration{Duration: ttl}, description,
+		tokenutil.DefaultGroups, tokenutil.DefaultUsages)
+	if err != nil {
+		return nil, err
+	}
+	if err = tokenutil.CreateNewToken(controlPlaneClient, bootstrapToken); err != nil {
+		return nil, err
+	}
+	return bootstrapToken, nil
+}
+
+// newRegistration 根据请求、Karmada 控制面的地址和 karmada-agent 的选项准备注册参数
+func newRegistration(c *gin.Context, controlPlaneClient kubeclient.Interface, tokenRequest *v1.CreateBootstrapTokenRequest) (*registration, error) {
+	endpoint, caData, err := registrationEndpoint(c.Request.Context(), c.Request, controlPlaneClient)
+	if err != nil {
+		return nil, err
+	}
+	if tokenRequest.APIServerEndpoint != "" {
+		endpoint = tokenRequest.APIServerEndpoint
+	}
+	hashes, err := caCertHashes(caData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the CA of Karmada apiserver: %w", err)
+	}
+	namespace := tokenRequest.MemberClusterNamespace
+	if namespace == "" {
+		namespace = names.NamespaceKarmadaSystem
+	}
+	opts := tokenRequest.Agent
+	if opts == nil {
+		opts = &v1.AgentOptions{}
+	}
+	agent, err := resolveAgentSpec(c.Request.Context(), controlPlaneClient, opts, namespace)
+	if err != nil {
+		return nil, err
+	}
+	registry, err := resolveAgentRegistry(opts)
+	if err != nil {
+		return nil, err
+	}
+	// karmadactl 与 karmada-agent 使用相同的版本，避免再次查询 Karmada 控制面的版本
+	karmadactlOpts := *opts
+	if karmadactlOpts.Version == "" {
+		karmadactlOpts.Version = imageTag(agent.image)
+	}
+	karmadactlImage, err := karmadaImage(c.Request.Context(), controlPlaneClient, &karmadactlOpts, registry, karmadactlName)
+	if err != nil {
+		return nil, err
+	}
+	return &registration{
+		endpoint:        endpoint,
+		caCertHashes:    hashes,
+		clusterName:     tokenRequest.ClusterName,
+		namespace:       namespace,
+		provider:        tokenRequest.ClusterProvider,
+		region:          tokenRequest.ClusterRegion,
+		zones:           tokenRequest.ClusterZones,
+		agent:           agent,
+		karmadactlImage: karmadactlImage,
+	}, nil
+}
+
+// 获取引导令牌列表
+func handleGetBootstrapTokens(c *gin.Context) {
+	controlPlaneClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	secrets, err := controlPlaneClient.CoreV1().Secrets(metav1.NamespaceSystem).List(c.Request.Context(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(bootstrapapi.SecretTypeBootstrapToken)).String(),
+	})
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result := v1.BootstrapTokenList{Tokens: make([]v1.BootstrapToken, 0, len(secrets.Items))}
+	for i := range secrets.Items {
+		token, err := tokenutil.GetBootstrapTokenFromSecret(&secrets.Items[i])
+		if err != nil {
+			klog.V(2).InfoS("Skipping invalid bootstrap token", "secret", secrets.Items[i].Name, "err", err)
+			continue
+		}
+		result.Tokens = append(result.Tokens, toBootstrapToken(token))
+	}
+	common.Success(c, result)
+}
+
+// 撤销引导令牌
+func handleDeleteBootstrapToken(c *gin.Context) {
+	id := c.Param("id")
+	if !bootstraputil.IsValidBootstrapTokenID(id) {
+		common.Fail(c, errors.NewBadRequest(fmt.Sprintf("invalid bootstrap token id %q", id)))
+		return
+	}
+	controlPlaneClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	err = controlPlaneClient.CoreV1().Secrets(metav1.NamespaceSystem).Delete(c.Request.Context(), bootstraputil.BootstrapTokenSecretName(id), metav1.DeleteOptions{})
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
+// 获取 karmada-agent 等待审批的证书签名请求
+func handleGetAgentCSRs(c *gin.Context) {
+	controlPlaneClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	csrs, err := controlPlaneClient.CertificatesV1().CertificateSigningRequests().List(c.Request.Context(), metav1.ListOptions{})
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result := v1.AgentCertificateSigningRequestList{Requests: []v1.AgentCertificateSigningRequest{}}
+	for i := range csrs.Items {
+		if request, ok := pendingAgentCSR(&csrs.Items[i]); ok {
+			result.Requests = append(result.Requests, request)
+		}
+	}
+	common.Success(c, result)
+}
+
+// pendingAgentCSR 判断证书签名请求是否是 karmadactl register 为 karmada-agent 提交且尚未审批的请求
+func pendingAgentCSR(csr *certificatesv1.CertificateSigningRequest) (v1.AgentCertificateSigningRequest, bool) {
+	if csr.Spec.SignerName != register.SignerName || len(csr.Status.Certificate) > 0 {
+		return v1.AgentCertificateSigningRequest{}, false
+	}
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certificatesv1.CertificateApproved || condition.Type == certificatesv1.CertificateDenied ||
+			condition.Type == certificatesv1.CertificateFailed {
+			return v1.AgentCertificateSigningRequest{}, false
+		}
+	}
+	block, _ := pem.Decode(csr.Spec.Request)
+	if block == nil {
+		return v1.AgentCertificateSigningRequest{}, false
+	}
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return v1.AgentCertificateSigningRequest{}, false
+	}
+	// karmada-agent 和 karmadactl register 生成 RBAC 时使用的证书的 CN 都以 system:karmada:agent: 开头
+	commonName := request.Subject.CommonName
+	if !strings.HasPrefix(commonName, register.ClusterPermissionPrefix) {
+		return v1.AgentCertificateSigningRequest{}, false
+	}
+	return v1.AgentCertificateSigningRequest{
+		Name:              csr.Name,
+		Username:          csr.Spec.Username,
+		CommonName:        commonName,
+		CreationTimestamp: csr.CreationTimestamp,
+	}, true
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/karmada-io/karmada/pkg/karmadactl/register"
+	tokenutil "github.com/karmada-io/karmada/pkg/karmadactl/util/bootstraptoken"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
+	bootstraputil "k8s.io/cluster-bootstrap/token/util"
+	"sigs.k8s.io/yaml"
+)
+
+// testRegistration 返回在 agents 命名空间中注册 member1 的参数
+func testRegistration() *registration {
+	return &registration{
+		endpoint:        "https://karmada-apiserver:5443",
+		caCertHashes:    []string{"sha256:aaa", "sha256:bbb"},
+		clusterName:     "member1",
+		namespace:       "agents",
+		region:          "east",
+		zones:           []string{"a", "b"},
+		agent:           &agentSpec{image: "docker.io/karmada/karmada-agent:v1.13.0", replicas: 2},
+		karmadactlImage: "docker.io/karmada/karmadactl:v1.13.0",
+	}
+}
+
+func TestBootstrapTokenTTL(t *testing.T) {
+	tests := []struct {
+		ttl     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{ttl: "", want: tokenutil.DefaultTokenDuration},
+		{ttl: "2h", want: 2 * time.Hour},
+		{ttl: "0", want: 0},
+		{ttl: "-1h", wantErr: true},
+		{ttl: "tomorrow", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := bootstrapTokenTTL(tt.ttl)
+		if tt.wantErr {
+			if !apierrors.IsBadRequest(err) {
+				t.Errorf("bootstrapTokenTTL(%q) error = %v, want BadRequest", tt.ttl, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("bootstrapTokenTTL(%q) = %v, %v, want %v", tt.ttl, got, err, tt.want)
+		}
+	}
+}
+
+func TestCreateBootstrapToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		ttl            time.Duration
+		wantExpiration bool
+	}{
+		{name: "default ttl", ttl: tokenutil.DefaultTokenDuration, wantExpiration: true},
+		{name: "never expires", ttl: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			token, err := createBootstrapToken(client, tt.ttl, "register member1")
+			if err != nil {
+				t.Fatalf("createBootstrapToken() error = %v", err)
+			}
+			secret, err := client.CoreV1().Secrets(metav1.NamespaceSystem).Get(context.TODO(),
+				bootstraputil.BootstrapTokenSecretName(token.Token.ID), metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("bootstrap token secret: %v", err)
+			}
+			if secret.Type != bootstrapapi.SecretTypeBootstrapToken {
+				t.Errorf("secret type = %s", secret.Type)
+			}
+			data := func(key string) string { return string(secret.Data[key]) }
+			for _, usage := range []string{"signing", "authentication"} {
+				if data(bootstrapapi.BootstrapTokenUsagePrefix+usage) != "true" {
+					t.Errorf("token usage %s is not enabled", usage)
+				}
+			}
+			if got := data(bootstrapapi.BootstrapTokenExtraGroupsKey); got != strings.Join(tokenutil.DefaultGroups, ",") {
+				t.Errorf("token groups = %q", got)
+			}
+			expiration := data(bootstrapapi.BootstrapTokenExpirationKey)
+			if (expiration != "") != tt.wantExpiration {
+				t.Fatalf("token expiration = %q, want expiration %t", expiration, tt.wantExpiration)
+			}
+			if tt.wantExpiration {
+				expires, err := time.Parse(time.RFC3339, expiration)
+				if err != nil || time.Until(expires) > tt.ttl || time.Until(expires) < tt.ttl-time.Minute {
+					t.Errorf("token expiration = %q, want in %v", expiration, tt.ttl)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistrationArgs(t *testing.T) {
+	want := []string{
+		"register",
+		"karmada-apiserver:5443",
+		"--token=abcdef.0123456789abcdef",
+		"--discovery-token-ca-cert-hash=sha256:aaa,sha256:bbb",
+		"--namespace=agents",
+		"--karmada-agent-image=docker.io/karmada/karmada-agent:v1.13.0",
+		"--karmada-agent-replicas=2",
+		"--cluster-name=member1",
+		"--cluster-region=east",
+		"--cluster-zones=a,b",
+	}
+	r := testRegistration()
+	if got := r.args("abcdef.0123456789abcdef"); !reflect.DeepEqual(got, want) {
+		t.Errorf("args() = %v, want %v", got, want)
+	}
+	if got := r.command("abcdef.0123456789abcdef"); got != "karmadactl "+strings.Join(want, " ") {
+		t.Errorf("command() = %q", got)
+	}
+}
+
+func TestRegistrationManifests(t *testing.T) {
+	const token = "abcdef.0123456789abcdef"
+	content, err := testRegistration().manifests(token)
+	if err != nil {
+		t.Fatalf("manifests() error = %v", err)
+	}
+	objects := map[string]*unstructured.Unstructured{}
+	var kinds []string
+	for _, doc := range strings.Split(content, "\n---\n") {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(doc), &obj.Object); err != nil {
+			t.Fatalf("invalid manifest %q: %v", doc, err)
+		}
+		kinds = append(kinds, obj.GetKind())
+		objects[obj.GetKind()] = obj
+	}
+	wantKinds := []string{"Namespace", "Secret", "ServiceAccount", "ClusterRole", "ClusterRoleBinding", "Role", "RoleBinding", "Job"}
+	if !reflect.DeepEqual(kinds, wantKinds) {
+		t.Fatalf("manifests() kinds = %v, want %v", kinds, wantKinds)
+	}
+	for _, kind := range wantKinds[1:] {
+		if obj := objects[kind]; obj.GetName() != registerName && obj.GetName() != registerTokenSecretName {
+			t.Errorf("%s name = %s", kind, obj.GetName())
+		}
+	}
+	if strings.Count(content, token) != 1 {
+		t.Errorf("the token should only be stored in the Secret:\n%s", content)
+	}
+
+	for _, kind := range []string{"ClusterRoleBinding", "RoleBinding"} {
+		binding := &rbacv1.RoleBinding{}
+		if err := yaml.Unmarshal([]byte(mustMarshal(t, objects[kind])), binding); err != nil {
+			t.Fatal(err)
+		}
+		if binding.RoleRef.Name != registerName {
+			t.Errorf("%s binds %s, want %s", kind, binding.RoleRef.Name, registerName)
+		}
+	}
+	clusterRole := &rbacv1.ClusterRole{}
+	if err := yaml.Unmarshal([]byte(mustMarshal(t, objects["ClusterRole"])), clusterRole); err != nil {
+		t.Fatal(err)
+	}
+	for _, rule := range clusterRole.Rules {
+		for _, verb := range rule.Verbs {
+			if verb == "*" || ((verb == "escalate" || verb == "bind") && !reflect.DeepEqual(rule.ResourceNames, []string{KarmadaAgentName})) {
+				t.Errorf("ClusterRole grants %s on %v %v", verb, rule.Resources, rule.ResourceNames)
+			}
+		}
+	}
+
+	containers, _, _ := unstructured.NestedSlice(objects["Job"].Object, "spec", "template", "spec", "containers")
+	if len(containers) != 1 {
+		t.Fatalf("job containers = %v", containers)
+	}
+	container := containers[0].(map[string]interface{})
+	args, _, _ := unstructured.NestedStringSlice(container, "args")
+	if len(args) < 3 || args[2] != "--token=$("+registerTokenEnv+")" {
+		t.Errorf("job args = %v, want the token from %s", args, registerTokenEnv)
+	}
+	if container["image"] != "docker.io/karmada/karmadactl:v1.13.0" {
+		t.Errorf("job image = %v", container["image"])
+	}
+}
+
+// mustMarshal 将对象序列化为 YAML
+func mustMarshal(t *testing.T, obj *unstructured.Unstructured) string {
+	content, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+// agentCSR 返回使用指定 CN 申请客户端证书的证书签名请求
+func agentCSR(t *testing.T, commonName string) *certificatesv1.CertificateSigningRequest {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: commonName}}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent-csr"},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			SignerName: register.SignerName,
+			Username:   "system:bootstrap:abcdef",
+			Request:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+		},
+	}
+}
+
+func TestPendingAgentCSR(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*certificatesv1.CertificateSigningRequest)
+		want   bool
+	}{
+		{name: "pending", want: true},
+		{
+			name: "approved",
+			mutate: func(csr *certificatesv1.CertificateSigningRequest) {
+				csr.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{{Type: certificatesv1.CertificateApproved}}
+			},
+		},
+		{
+			name: "denied",
+			mutate: func(csr *certificatesv1.CertificateSigningRequest) {
+				csr.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{{Type: certificatesv1.CertificateDenied}}
+			},
+		},
+		{
+			name:   "issued",
+			mutate: func(csr *certificatesv1.CertificateSigningRequest) { csr.Status.Certificate = []byte("cert") },
+		},
+		{
+			name:   "other signer",
+			mutate: func(csr *certificatesv1.CertificateSigningRequest) { csr.Spec.SignerName = "example.io/signer" },
+		},
+		{
+			name:   "invalid request",
+			mutate: func(csr *certificatesv1.CertificateSigningRequest) { csr.Spec.Request = []byte("invalid") },
+		},
+		{
+			name: "other common name",
+			mutate: func(csr *certificatesv1.CertificateSigningRequest) {
+				csr.Spec.Request = agentCSR(t, "system:node:member1").Spec.Request
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csr := agentCSR(t, register.ClusterPermissionPrefix+"member1")
+			if tt.mutate != nil {
+				tt.mutate(csr)
+			}
+			request, ok := pendingAgentCSR(csr)
+			if ok != tt.want {
+				t.Fatalf("pendingAgentCSR() = %t, want %t", ok, tt.want)
+			}
+			if ok && (request.CommonName != register.ClusterPermissionPrefix+"member1" || request.Username != "system:bootstrap:abcdef") {
+				t.Errorf("pendingAgentCSR() request = %+v", request)
+			}
+		})
+	}
+}
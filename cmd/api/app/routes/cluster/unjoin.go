@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	cmdutil "github.com/karmada-io/karmada/pkg/karmadactl/util"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	"github.com/karmada-io/karmada/pkg/util/names"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+)
+
+const (
+	// unjoinTimeout 是等待 Work 和 Cluster 对象被删除的超时时间
+	unjoinTimeout = 60 * time.Second
+	// karmadaImpersonatorName 是 Karmada 通过 Work 在推送模式的成员集群中创建的 impersonator ClusterRole 和 ClusterRoleBinding 的名称
+	karmadaImpersonatorName = "karmada-impersonator"
+)
+
+// unjoinOption 是移除集群的选项
+type unjoinOption struct {
+	karmadaClient      karmadaclientset.Interface
+	controlPlaneClient kubeclient.Interface
+	// memberClusterClient 访问成员集群，为空时不清理成员集群中的对象
+	memberClusterClient    kubeclient.Interface
+	clusterName            string
+	memberClusterNamespace string
+	force                  bool
+	response               v1.DeleteClusterResponse
+}
+
+// warnOrFail 强制移除时将错误记录为警告并继续，否则返回错误
+func (o *unjoinOption) warnOrFail(err error) error {
+	if err == nil || !o.force {
+		return err
+	}
+	klog.ErrorS(err, "Ignoring failure in forced cluster removal", "cluster", o.clusterName)
+	o.response.Warnings = append(o.response.Warnings, err.Error())
+	return nil
+}
+
+// unjoin 删除控制面中的 Cluster 对象，并清理接入集群时在控制面和成员集群中创建的对象
+func (o *unjoinOption) unjoin(ctx context.Context) error {
+	cluster, err := o.karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, o.clusterName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("no cluster object %s found in karmada control Plane", o.clusterName)
+	}
+	if err != nil {
+		return err
+	}
+	if o.memberClusterClient != nil {
+		if _, err = o.memberClusterClient.Discovery().ServerVersion(); err != nil {
+			if err = o.warnOrFail(fmt.Errorf("member cluster %s is unreachable, its objects are left behind: %w", o.clusterName, err)); err != nil {
+				return err
+			}
+			o.memberClusterClient = nil
+		}
+	}
+
+	if cluster.Spec.SyncMode == clusterv1alpha1.Pull {
+		// 删除执行命名空间会删除 karmada-agent 访问 Work 所需的 RBAC，因此需要先等待 Work 被删除
+		if err = o.warnOrFail(cmdutil.EnsureWorksDeleted(o.karmadaClient, names.GenerateExecutionSpaceName(o.clusterName), unjoinTimeout)); err != nil {
+			return err
+		}
+	}
+	// 强制移除时，超时后会移除 Work、执行命名空间和 Cluster 的 finalizer
+	if err = cmdutil.DeleteClusterObject(o.controlPlaneClient, o.karmadaClient, o.clusterName, unjoinTimeout, false, o.force); err != nil {
+		return err
+	}
+	o.response.Removed = append(o.response.Removed, fmt.Sprintf("Cluster %s", o.clusterName))
+
+	removed, err := deleteObjects(ctx, o.controlPlaneClient, controlPlaneObjects(cluster))
+	o.response.Removed = append(o.response.Removed, removed...)
+	if err = o.warnOrFail(err); err != nil {
+		return err
+	}
+	if o.memberClusterClient == nil {
+		return nil
+	}
+	removed, err = deleteObjects(ctx, o.memberClusterClient, memberObjects(cluster, o.memberClusterNamespace))
+	o.response.Removed = append(o.response.Removed, removed...)
+	return o.warnOrFail(err)
+}
+
+// controlPlaneObjects 返回接入集群时在控制面中创建的对象，推送模式的 Secret 通常已随 Cluster 对象被垃圾回收
+func controlPlaneObjects(cluster *clusterv1alpha1.Cluster) []objectRef {
+	if cluster.Spec.SyncMode == clusterv1alpha1.Pull {
+		return agentCredentialObjects(cluster.Name)
+	}
+	var refs []objectRef
+	for _, secretRef := range []*clusterv1alpha1.LocalSecretReference{cluster.Spec.SecretRef, cluster.Spec.ImpersonatorSecretRef} {
+		if secretRef != nil {
+			refs = append(refs, objectRef{kind: "Secret", namespace: secretRef.Namespace, name: secretRef.Name})
+		}
+	}
+	return refs
+}
+
+// memberObjects 返回接入集群时在成员集群中创建的对象
+func memberObjects(cluster *clusterv1alpha1.Cluster, agentNamespace string) []objectRef {
+	if cluster.Spec.SyncMode == clusterv1alpha1.Pull {
+		return []objectRef{
+			{kind: "Deployment", namespace: agentNamespace, name: KarmadaAgentName},
+			{kind: "Secret", namespace: agentNamespace, name: KarmadaKubeconfigName},
+			{kind: "Secret", namespace: agentNamespace, name: agentRegistrySecretName},
+			{kind: "ClusterRoleBinding", name: KarmadaAgentName},
+			{kind: "ClusterRole", name: KarmadaAgentName},
+			{kind: "ServiceAccount", namespace: agentNamespace, name: KarmadaAgentServiceAccountName},
+		}
+	}
+	refs := pushModeMemberObjects(cluster.Name)
+	return append(refs,
+		objectRef{kind: "Secret", namespace: ClusterNamespace, name: names.GenerateServiceAccountName(cluster.Name)},
+		objectRef{kind: "Secret", namespace: ClusterNamespace, name: names.GenerateServiceAccountName("impersonator")},
+		objectRef{kind: "ClusterRoleBinding", name: karmadaImpersonatorName},
+		objectRef{kind: "ClusterRole", name: karmadaImpersonatorName},
+		// 只删除带有 Karmada 标签的命名空间，成员集群中已有的同名命名空间可能保存着其他对象
+		objectRef{kind: "Namespace", name: ClusterNamespace, ownedBy: map[string]string{
+			karmadautil.KarmadaSystemLabel: karmadautil.KarmadaSystemLabelValue,
+		}},
+	)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// pushCluster 返回推送模式的 Cluster 对象
+func pushCluster(name string) *clusterv1alpha1.Cluster {
+	return &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: clusterv1alpha1.ClusterSpec{
+			SyncMode:              clusterv1alpha1.Push,
+			SecretRef:             &clusterv1alpha1.LocalSecretReference{Namespace: ClusterNamespace, Name: name},
+			ImpersonatorSecretRef: &clusterv1alpha1.LocalSecretReference{Namespace: ClusterNamespace, Name: name + "-impersonator"},
+		},
+	}
+}
+
+// memberNamespace 返回成员集群中带有指定标签的 karmada-cluster 命名空间
+func memberNamespace(labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ClusterNamespace, Labels: labels}}
+}
+
+func TestControlPlaneObjects(t *testing.T) {
+	push := pushCluster("member1")
+	if got, want := controlPlaneObjects(push), []objectRef{
+		{kind: "Secret", namespace: ClusterNamespace, name: "member1"},
+		{kind: "Secret", namespace: ClusterNamespace, name: "member1-impersonator"},
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("controlPlaneObjects(push) = %v, want %v", got, want)
+	}
+
+	push.Spec.ImpersonatorSecretRef = nil
+	if got := controlPlaneObjects(push); len(got) != 1 {
+		t.Errorf("controlPlaneObjects(push) without impersonator secret = %v", got)
+	}
+
+	pull := &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "member1"},
+		Spec:       clusterv1alpha1.ClusterSpec{SyncMode: clusterv1alpha1.Pull},
+	}
+	if got, want := controlPlaneObjects(pull), agentCredentialObjects("member1"); !reflect.DeepEqual(got, want) {
+		t.Errorf("controlPlaneObjects(pull) = %v, want %v", got, want)
+	}
+}
+
+func TestMemberObjects(t *testing.T) {
+	pull := &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "member1"},
+		Spec:       clusterv1alpha1.ClusterSpec{SyncMode: clusterv1alpha1.Pull},
+	}
+	for _, ref := range memberObjects(pull, "agents") {
+		if ref.kind == "Namespace" {
+			t.Errorf("memberObjects(pull) deletes namespace %s", ref.name)
+		}
+		if ref.namespace != "" && ref.namespace != "agents" {
+			t.Errorf("memberObjects(pull) = %v, want objects in namespace agents", ref)
+		}
+	}
+
+	var namespaces []objectRef
+	for _, ref := range memberObjects(pushCluster("member1"), "agents") {
+		if ref.kind == "Namespace" {
+			namespaces = append(namespaces, ref)
+		}
+	}
+	if len(namespaces) != 1 || namespaces[0].name != ClusterNamespace ||
+		namespaces[0].ownedBy[karmadautil.KarmadaSystemLabel] != karmadautil.KarmadaSystemLabelValue {
+		t.Errorf("memberObjects(push) namespaces = %v, want only %s owned by Karmada", namespaces, ClusterNamespace)
+	}
+}
+
+func TestDeleteObjectsOwnedBy(t *testing.T) {
+	owned := map[string]string{karmadautil.KarmadaSystemLabel: karmadautil.KarmadaSystemLabelValue}
+	tests := []struct {
+		name      string
+		namespace *corev1.Namespace
+		want      []string
+		wantKept  bool
+	}{
+		{
+			name:      "namespace created by Karmada",
+			namespace: memberNamespace(owned),
+			want:      []string{"Namespace karmada-cluster"},
+		},
+		{
+			name:      "namespace of the user",
+			namespace: memberNamespace(map[string]string{"team": "a"}),
+			wantKept:  true,
+		},
+		{
+			name: "missing namespace",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objects []runtime.Object
+			if tt.namespace != nil {
+				objects = append(objects, tt.namespace)
+			}
+			client := fake.NewSimpleClientset(objects...)
+			removed, err := deleteObjects(context.TODO(), client, []objectRef{{kind: "Namespace", name: ClusterNamespace, ownedBy: owned}})
+			if err != nil {
+				t.Fatalf("deleteObjects() error = %v", err)
+			}
+			if !reflect.DeepEqual(removed, tt.want) {
+				t.Errorf("deleteObjects() removed = %v, want %v", removed, tt.want)
+			}
+			_, err = client.CoreV1().Namespaces().Get(context.TODO(), ClusterNamespace, metav1.GetOptions{})
+			if kept := err == nil; kept != tt.wantKept {
+				t.Errorf("namespace kept = %t, want %t", kept, tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestUnjoin(t *testing.T) {
+	unreachable := func(client *fake.Clientset) {
+		client.PrependReactor("get", "version", func(clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, fmt.Errorf("connection refused")
+		})
+	}
+	tests := []struct {
+		name         string
+		force        bool
+		member       func(*fake.Clientset)
+		wantErr      bool
+		wantWarnings int
+		wantMember   bool
+	}{
+		{
+			name:       "reachable member cluster",
+			wantMember: true,
+		},
+		{
+			name:    "unreachable member cluster",
+			member:  unreachable,
+			wantErr: true,
+		},
+		{
+			name:         "forced removal of an unreachable member cluster",
+			force:        true,
+			member:       unreachable,
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := pushCluster("member1")
+			karmadaClient := karmadafake.NewSimpleClientset(cluster)
+			controlPlaneClient := fake.NewSimpleClientset(
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ClusterNamespace, Name: "member1"}},
+			)
+			memberClient := fake.NewSimpleClientset(
+				memberNamespace(map[string]string{karmadautil.KarmadaSystemLabel: karmadautil.KarmadaSystemLabelValue}),
+			)
+			if tt.member != nil {
+				tt.member(memberClient)
+			}
+			o := &unjoinOption{
+				karmadaClient:       karmadaClient,
+				controlPlaneClient:  controlPlaneClient,
+				memberClusterClient: memberClient,
+				clusterName:         "member1",
+				force:               tt.force,
+			}
+			err := o.unjoin(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unjoin() error = %v, want error %t", err, tt.wantErr)
+			}
+			if len(o.response.Warnings) != tt.wantWarnings {
+				t.Errorf("unjoin() warnings = %v, want %d", o.response.Warnings, tt.wantWarnings)
+			}
+			_, err = karmadaClient.ClusterV1alpha1().Clusters().Get(context.TODO(), "member1", metav1.GetOptions{})
+			if removed := apierrors.IsNotFound(err); removed == tt.wantErr {
+				t.Errorf("cluster removed = %t, want %t", removed, !tt.wantErr)
+			}
+			_, err = memberClient.CoreV1().Namespaces().Get(context.TODO(), ClusterNamespace, metav1.GetOptions{})
+			if cleaned := apierrors.IsNotFound(err); cleaned != tt.wantMember {
+				t.Errorf("member namespace removed = %t, want %t", cleaned, tt.wantMember)
+			}
+		})
+	}
+}
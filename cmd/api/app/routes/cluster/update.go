@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	clustervalidation "github.com/karmada-io/karmada/pkg/apis/cluster/validation"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+)
+
+// validatePutClusterRequest 校验更新集群的请求中 apiserver 之外无法给出明确错误的字段
+func validatePutClusterRequest(clusterRequest *v1.PutClusterRequest) error {
+	if clusterRequest.ProxyURL == nil || *clusterRequest.ProxyURL == "" {
+		return nil
+	}
+	if errs := clustervalidation.ValidateClusterProxyURL(field.NewPath("proxyURL"), *clusterRequest.ProxyURL); len(errs) > 0 {
+		return errors.NewBadRequest(errs.ToAggregate().Error())
+	}
+	return nil
+}
+
+// applyClusterUpdate 将请求中提供的字段写入集群，未提供的字段保持不变
+func applyClusterUpdate(cluster *clusterv1alpha1.Cluster, clusterRequest *v1.PutClusterRequest) {
+	// 假设前端可以获取整个标签和污点
+	if clusterRequest.Labels != nil {
+		labels := make(map[string]string)
+		for _, labelItem := range *clusterRequest.Labels {
+			labels[labelItem.Key] = labelItem.Value
+		}
+		cluster.Labels = labels
+	}
+	if clusterRequest.Taints != nil {
+		taints := make([]corev1.Taint, 0)
+		for _, taintItem := range *clusterRequest.Taints {
+			taints = append(taints, corev1.Taint{
+				Key:    taintItem.Key,
+				Value:  taintItem.Value,
+				Effect: taintItem.Effect,
+			})
+		}
+		cluster.Spec.Taints = taints
+	}
+	// 注解只合并请求中的键，避免删除 Karmada 等组件写入的注解
+	for key, value := range clusterRequest.Annotations {
+		if value == nil {
+			delete(cluster.Annotations, key)
+			continue
+		}
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		cluster.Annotations[key] = *value
+	}
+	if clusterRequest.Provider != nil {
+		cluster.Spec.Provider = *clusterRequest.Provider
+	}
+	if clusterRequest.Region != nil {
+		cluster.Spec.Region = *clusterRequest.Region
+	}
+	if clusterRequest.Zones != nil {
+		cluster.Spec.Zones = *clusterRequest.Zones
+	}
+	if clusterRequest.ProxyURL != nil {
+		cluster.Spec.ProxyURL = *clusterRequest.ProxyURL
+	}
+	if clusterRequest.InsecureSkipTLSVerification != nil {
+		cluster.Spec.InsecureSkipTLSVerification = *clusterRequest.InsecureSkipTLSVerification
+	}
+	if clusterRequest.ResourceModels != nil {
+		cluster.Spec.ResourceModels = *clusterRequest.ResourceModels
+	}
+}
+
+// patchCluster 以 mutate 修改集群并以合并补丁更新。补丁携带读取时的 resourceVersion，集群在此期间被修改时重新读取并重试
+func patchCluster(ctx context.Context, karmadaClient karmadaclientset.Interface, name string, mutate func(cluster *clusterv1alpha1.Cluster)) (*clusterv1alpha1.Cluster, error) {
+	var updated *clusterv1alpha1.Cluster
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		modified := current.DeepCopy()
+		mutate(modified)
+
+		origData, err := json.Marshal(current)
+		if err != nil {
+			return fmt.Errorf("failed to marshal original data: %+v", err)
+		}
+		modifiedData, err := json.Marshal(modified)
+		if err != nil {
+			return fmt.Errorf("failed to marshal modified data: %+v", err)
+		}
+		patchBytes, err := jsonmergepatch.CreateThreeWayJSONMergePatch(origData, modifiedData, origData)
+		if err != nil {
+			return fmt.Errorf("failed creating merge patch: %+v", err)
+		}
+		if patchBytes, err = withResourceVersion(patchBytes, current.ResourceVersion); err != nil {
+			return err
+		}
+		klog.V(3).InfoS("patching cluster", "name", name, "patch", string(patchBytes))
+		updated, err = karmadaClient.ClusterV1alpha1().Clusters().Patch(ctx, name, k8stypes.MergePatchType, patchBytes, metav1.PatchOptions{})
+		return err
+	})
+	return updated, err
+}
+
+// withResourceVersion 在合并补丁中加入 resourceVersion，使 apiserver 在对象已被修改时返回冲突
+func withResourceVersion(patchBytes []byte, resourceVersion string) ([]byte, error) {
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(patchBytes, &patch); err != nil {
+		return nil, err
+	}
+	metadata, _ := patch["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadata["resourceVersion"] = resourceVersion
+	patch["metadata"] = metadata
+	return json.Marshal(patch)
+}
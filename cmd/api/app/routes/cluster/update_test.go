@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+)
+
+// annotatedCluster 返回带有 Karmada 和用户注解的集群
+func annotatedCluster() *clusterv1alpha1.Cluster {
+	cluster := pushCluster("member1")
+	cluster.Labels = map[string]string{"env": "dev"}
+	cluster.Annotations = map[string]string{
+		"karmada.io/cluster-id":         "1234",
+		"cluster.karmada.io/owner":      "karmada",
+		"example.io/description":        "old",
+		"example.io/obsolete":           "true",
+		"kubectl.kubernetes.io/comment": "kept",
+	}
+	cluster.Spec.Region = "east"
+	cluster.Spec.Taints = []corev1.Taint{{Key: "dedicated", Effect: corev1.TaintEffectNoSchedule}}
+	return cluster
+}
+
+func TestApplyClusterUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		request string
+		want    func(*clusterv1alpha1.Cluster)
+	}{
+		{
+			name:    "empty request",
+			request: `{}`,
+			want:    func(*clusterv1alpha1.Cluster) {},
+		},
+		{
+			name:    "merge annotations",
+			request: `{"annotations": {"example.io/description": "new", "example.io/added": "1", "example.io/obsolete": null}}`,
+			want: func(cluster *clusterv1alpha1.Cluster) {
+				cluster.Annotations["example.io/description"] = "new"
+				cluster.Annotations["example.io/added"] = "1"
+				delete(cluster.Annotations, "example.io/obsolete")
+			},
+		},
+		{
+			name:    "remove a missing annotation",
+			request: `{"annotations": {"example.io/missing": null}}`,
+			want:    func(*clusterv1alpha1.Cluster) {},
+		},
+		{
+			name:    "replace labels and taints",
+			request: `{"labels": [{"key": "env", "value": "prod"}], "taints": []}`,
+			want: func(cluster *clusterv1alpha1.Cluster) {
+				cluster.Labels = map[string]string{"env": "prod"}
+				cluster.Spec.Taints = []corev1.Taint{}
+			},
+		},
+		{
+			name:    "placement and access settings",
+			request: `{"region": "", "zones": ["a"], "proxyURL": "socks5://proxy:1080", "insecureSkipTLSVerification": true}`,
+			want: func(cluster *clusterv1alpha1.Cluster) {
+				cluster.Spec.Region = ""
+				cluster.Spec.Zones = []string{"a"}
+				cluster.Spec.ProxyURL = "socks5://proxy:1080"
+				cluster.Spec.InsecureSkipTLSVerification = true
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterRequest := &v1.PutClusterRequest{}
+			if err := json.Unmarshal([]byte(tt.request), clusterRequest); err != nil {
+				t.Fatal(err)
+			}
+			got := annotatedCluster()
+			applyClusterUpdate(got, clusterRequest)
+			want := annotatedCluster()
+			tt.want(want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("applyClusterUpdate() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyClusterUpdateWithoutAnnotations(t *testing.T) {
+	cluster := pushCluster("member1")
+	applyClusterUpdate(cluster, &v1.PutClusterRequest{Annotations: map[string]*string{
+		"example.io/added":   ptr.To("1"),
+		"example.io/removed": nil,
+	}})
+	if want := map[string]string{"example.io/added": "1"}; !reflect.DeepEqual(cluster.Annotations, want) {
+		t.Errorf("annotations = %v, want %v", cluster.Annotations, want)
+	}
+}
+
+func TestPatchClusterKeepsAnnotations(t *testing.T) {
+	karmadaClient := karmadafake.NewSimpleClientset(annotatedCluster())
+	clusterRequest := &v1.PutClusterRequest{Annotations: map[string]*string{"example.io/description": ptr.To("new")}}
+	updated, err := patchCluster(context.TODO(), karmadaClient, "member1", func(cluster *clusterv1alpha1.Cluster) {
+		applyClusterUpdate(cluster, clusterRequest)
+	})
+	if err != nil {
+		t.Fatalf("patchCluster() error = %v", err)
+	}
+	want := annotatedCluster().Annotations
+	want["example.io/description"] = "new"
+	if !reflect.DeepEqual(updated.Annotations, want) {
+		t.Errorf("annotations = %v, want %v", updated.Annotations, want)
+	}
+}
+
+func TestWithResourceVersion(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{
+			name:  "empty patch",
+			patch: `{}`,
+			want:  `{"metadata":{"resourceVersion":"42"}}`,
+		},
+		{
+			name:  "patch of the spec",
+			patch: `{"spec":{"region":"west"}}`,
+			want:  `{"metadata":{"resourceVersion":"42"},"spec":{"region":"west"}}`,
+		},
+		{
+			name:  "patch of the metadata",
+			patch: `{"metadata":{"labels":{"env":"prod"}}}`,
+			want:  `{"metadata":{"labels":{"env":"prod"},"resourceVersion":"42"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := withResourceVersion([]byte(tt.patch), "42")
+			if err != nil {
+				t.Fatalf("withResourceVersion() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("withResourceVersion() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if _, err := withResourceVersion([]byte(`[]`), "42"); err == nil {
+		t.Errorf("withResourceVersion() of a non-object patch should fail")
+	}
+}
@@ -23,20 +23,27 @@ import (
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
-	"sigs.k8s.io/yaml"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
 	"github.com/karmada-io/dashboard/pkg/resource/clusteroverridepolicy"
+	"github.com/karmada-io/dashboard/pkg/resource/overridepolicy"
 )
 
 // 获取集群覆盖策略列表
 func handleGetClusterOverridePolicyList(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
+	listers, err := informer.ListersForList(c.Request, "", types.ResourceKindClusterOverridePolicy)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
-	clusterOverrideList, err := clusteroverridepolicy.GetClusterOverridePolicyList(karmadaClient, dataSelect)
+	clusterOverrideList, err := clusteroverridepolicy.GetClusterOverridePolicyList(listers.ClusterOverridePolicies, dataSelect)
 	if err != nil {
 		klog.ErrorS(err, "Failed to GetClusterOverridePolicyList")
 		common.Fail(c, err)
@@ -47,9 +54,13 @@ func handleGetClusterOverridePolicyList(c *gin.Context) {
 
 // 获取集群覆盖策略详情
 func handleGetClusterOverridePolicyDetail(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
 	name := c.Param("clusterOverridePolicyName")
-	result, err := clusteroverridepolicy.GetClusterOverridePolicyDetail(karmadaClient, name)
+	listers, err := informer.ListersForGet(c.Request, types.ResourceKindClusterOverridePolicy, "", name)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := clusteroverridepolicy.GetClusterOverridePolicyDetail(listers.ClusterOverridePolicies, name)
 	if err != nil {
 		klog.ErrorS(err, "GetClusterOverridePolicyDetail failed")
 		common.Fail(c, err)
@@ -58,33 +69,44 @@ func handleGetClusterOverridePolicyDetail(c *gin.Context) {
 	common.Success(c, result)
 }
 
-// 创建集群覆盖策略
+// 创建集群覆盖策略，请求可以是 YAML 或结构化的 spec
 func handlePostClusterOverridePolicy(c *gin.Context) {
 	ctx := context.Context(c)
 	overridepolicyRequest := new(v1.PostOverridePolicyRequest)
 	if err := c.ShouldBind(&overridepolicyRequest); err != nil {
-		common.Fail(c, err)
+		common.Fail(c, errors.NewBindingError(err))
 		return
 	}
+	data := &overridepolicy.PolicyData{
+		YAML:        overridepolicyRequest.OverrideData,
+		Name:        overridepolicyRequest.Name,
+		Namespace:   overridepolicyRequest.Namespace,
+		Labels:      overridepolicyRequest.Labels,
+		Annotations: overridepolicyRequest.Annotations,
+		Spec:        overridepolicyRequest.Spec,
+	}
 
-	var err error
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	if overridepolicyRequest.IsClusterScope {
-		clusterOverridePolicy := v1alpha1.ClusterOverridePolicy{}
-		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &clusterOverridePolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal ClusterOverridePolicy")
+		var clusterOverridePolicy *v1alpha1.ClusterOverridePolicy
+		if clusterOverridePolicy, err = data.ClusterOverridePolicy(); err != nil {
+			klog.ErrorS(err, "Invalid ClusterOverridePolicy")
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Create(ctx, &clusterOverridePolicy, metav1.CreateOptions{})
+		_, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Create(ctx, clusterOverridePolicy, metav1.CreateOptions{})
 	} else {
-		overridePolicy := v1alpha1.OverridePolicy{}
-		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &overridePolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal OverridePolicy")
+		var overridePolicy *v1alpha1.OverridePolicy
+		if overridePolicy, err = data.OverridePolicy(); err != nil {
+			klog.ErrorS(err, "Invalid OverridePolicy")
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace).Create(ctx, &overridePolicy, metav1.CreateOptions{})
+		_, err = karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace).Create(ctx, overridePolicy, metav1.CreateOptions{})
 	}
 	if err != nil {
 		klog.ErrorS(err, "Failed to create OverridePolicy")
@@ -94,6 +116,64 @@ func handlePostClusterOverridePolicy(c *gin.Context) {
 	common.Success(c, "ok")
 }
 
+// 更新集群覆盖策略，请求可以是 YAML 或结构化的 spec，指定 resourceVersion 时策略已被修改则返回冲突
+func handlePutClusterOverridePolicy(c *gin.Context) {
+	ctx := context.Context(c)
+	name := c.Param("clusterOverridePolicyName")
+	overridepolicyRequest := new(v1.PutClusterOverridePolicyRequest)
+	if err := c.ShouldBind(&overridepolicyRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	data := &overridepolicy.PolicyData{
+		YAML:            overridepolicyRequest.OverrideData,
+		Labels:          overridepolicyRequest.Labels,
+		Annotations:     overridepolicyRequest.Annotations,
+		Spec:            overridepolicyRequest.Spec,
+		ResourceVersion: overridepolicyRequest.ResourceVersion,
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	oldClusterOverridePolicy, err := karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	clusterOverridePolicy, err := data.UpdateClusterOverridePolicy(oldClusterOverridePolicy)
+	if err != nil {
+		klog.ErrorS(err, "Invalid ClusterOverridePolicy")
+		common.Fail(c, err)
+		return
+	}
+	_, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Update(ctx, clusterOverridePolicy, metav1.UpdateOptions{})
+	if err != nil {
+		klog.ErrorS(err, "Failed to update ClusterOverridePolicy")
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
+// 删除集群覆盖策略，指定 resourceVersion 时策略已被修改则返回冲突
+func handleDeleteClusterOverridePolicy(c *gin.Context) {
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	err = overridepolicy.DeleteClusterOverridePolicy(c.Request.Context(), karmadaClient,
+		c.Param("clusterOverridePolicyName"), c.Query("resourceVersion"))
+	if err != nil {
+		klog.ErrorS(err, "Failed to delete ClusterOverridePolicy")
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
 // 初始化路由
 func init() {
 	r := router.V1()
@@ -103,4 +183,8 @@ func init() {
 	r.GET("/clusteroverridepolicy/:clusterOverridePolicyName", handleGetClusterOverridePolicyDetail)
 	// 创建集群覆盖策略
 	r.POST("/clusteroverridepolicy", handlePostClusterOverridePolicy)
+	// 更新集群覆盖策略
+	r.PUT("/clusteroverridepolicy/:clusterOverridePolicyName", handlePutClusterOverridePolicy)
+	// 删除集群覆盖策略
+	r.DELETE("/clusteroverridepolicy/:clusterOverridePolicyName", handleDeleteClusterOverridePolicy)
 }
@@ -23,20 +23,27 @@ import (
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
-	"sigs.k8s.io/yaml"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
 	"github.com/karmada-io/dashboard/pkg/resource/clusterpropagationpolicy"
+	"github.com/karmada-io/dashboard/pkg/resource/propagationpolicy"
 )
 
 // 获取集群传播策略列表
 func handleGetClusterPropagationPolicyList(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
+	listers, err := informer.ListersForList(c.Request, "", types.ResourceKindClusterPropagationPolicy)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
-	clusterPropagationList, err := clusterpropagationpolicy.GetClusterPropagationPolicyList(karmadaClient, dataSelect)
+	clusterPropagationList, err := clusterpropagationpolicy.GetClusterPropagationPolicyList(listers.ClusterPropagationPolicies, dataSelect)
 	if err != nil {
 		klog.ErrorS(err, "Failed to GetClusterPropagationPolicyList")
 		common.Fail(c, err)
@@ -47,9 +54,13 @@ func handleGetClusterPropagationPolicyList(c *gin.Context) {
 
 // 获取集群传播策略详情
 func handleGetClusterPropagationPolicyDetail(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
 	name := c.Param("clusterPropagationPolicyName")
-	result, err := clusterpropagationpolicy.GetClusterPropagationPolicyDetail(karmadaClient, name)
+	listers, err := informer.ListersForGet(c.Request, types.ResourceKindClusterPropagationPolicy, "", name)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := clusterpropagationpolicy.GetClusterPropagationPolicyDetail(listers.ClusterPropagationPolicies, name)
 	if err != nil {
 		klog.ErrorS(err, "GetClusterPropagationPolicyDetail failed")
 		common.Fail(c, err)
@@ -58,33 +69,44 @@ func handleGetClusterPropagationPolicyDetail(c *gin.Context) {
 	common.Success(c, result)
 }
 
-// 创建集群传播策略
+// 创建集群传播策略，请求可以是 YAML 或结构化的 spec
 func handlePostClusterPropagationPolicy(c *gin.Context) {
 	ctx := context.Context(c)
 	propagationpolicyRequest := new(v1.PostPropagationPolicyRequest)
 	if err := c.ShouldBind(&propagationpolicyRequest); err != nil {
-		common.Fail(c, err)
+		common.Fail(c, errors.NewBindingError(err))
 		return
 	}
+	data := &propagationpolicy.PolicyData{
+		YAML:        propagationpolicyRequest.PropagationData,
+		Name:        propagationpolicyRequest.Name,
+		Namespace:   propagationpolicyRequest.Namespace,
+		Labels:      propagationpolicyRequest.Labels,
+		Annotations: propagationpolicyRequest.Annotations,
+		Spec:        propagationpolicyRequest.Spec,
+	}
 
-	var err error
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	if propagationpolicyRequest.IsClusterScope {
-		clusterPropagationPolicy := v1alpha1.ClusterPropagationPolicy{}
-		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &clusterPropagationPolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal ClusterPropagationPolicy")
+		var clusterPropagationPolicy *v1alpha1.ClusterPropagationPolicy
+		if clusterPropagationPolicy, err = data.ClusterPropagationPolicy(); err != nil {
+			klog.ErrorS(err, "Invalid ClusterPropagationPolicy")
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Create(ctx, &clusterPropagationPolicy, metav1.CreateOptions{})
+		_, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Create(ctx, clusterPropagationPolicy, metav1.CreateOptions{})
 	} else {
-		propagationPolicy := v1alpha1.PropagationPolicy{}
-		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &propagationPolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal PropagationPolicy")
+		var propagationPolicy *v1alpha1.PropagationPolicy
+		if propagationPolicy, err = data.PropagationPolicy(); err != nil {
+			klog.ErrorS(err, "Invalid PropagationPolicy")
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Create(ctx, &propagationPolicy, metav1.CreateOptions{})
+		_, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Create(ctx, propagationPolicy, metav1.CreateOptions{})
 	}
 	if err != nil {
 		klog.ErrorS(err, "Failed to create PropagationPolicy")
@@ -94,6 +116,64 @@ func handlePostClusterPropagationPolicy(c *gin.Context) {
 	common.Success(c, "ok")
 }
 
+// 更新集群传播策略，请求可以是 YAML 或结构化的 spec，指定 resourceVersion 时策略已被修改则返回冲突
+func handlePutClusterPropagationPolicy(c *gin.Context) {
+	ctx := context.Context(c)
+	name := c.Param("clusterPropagationPolicyName")
+	propagationpolicyRequest := new(v1.PutClusterPropagationPolicyRequest)
+	if err := c.ShouldBind(&propagationpolicyRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	data := &propagationpolicy.PolicyData{
+		YAML:            propagationpolicyRequest.PropagationData,
+		Labels:          propagationpolicyRequest.Labels,
+		Annotations:     propagationpolicyRequest.Annotations,
+		Spec:            propagationpolicyRequest.Spec,
+		ResourceVersion: propagationpolicyRequest.ResourceVersion,
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	oldClusterPropagationPolicy, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	clusterPropagationPolicy, err := data.UpdateClusterPropagationPolicy(oldClusterPropagationPolicy)
+	if err != nil {
+		klog.ErrorS(err, "Invalid ClusterPropagationPolicy")
+		common.Fail(c, err)
+		return
+	}
+	_, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Update(ctx, clusterPropagationPolicy, metav1.UpdateOptions{})
+	if err != nil {
+		klog.ErrorS(err, "Failed to update ClusterPropagationPolicy")
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
+// 删除集群传播策略，指定 resourceVersion 时策略已被修改则返回冲突，请求参数 wait=true 时等待绑定释放
+func handleDeleteClusterPropagationPolicy(c *gin.Context) {
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := propagationpolicy.DeleteClusterPropagationPolicy(c.Request.Context(), karmadaClient,
+		c.Param("clusterPropagationPolicyName"), c.Query("resourceVersion"), c.Query("wait") == "true")
+	if err != nil {
+		klog.ErrorS(err, "Failed to delete ClusterPropagationPolicy")
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
 // 初始化路由
 func init() {
 	r := router.V1()
@@ -103,4 +183,8 @@ func init() {
 	r.GET("/clusterpropagationpolicy/:clusterPropagationPolicyName", handleGetClusterPropagationPolicyDetail)
 	// 创建集群传播策略
 	r.POST("/clusterpropagationpolicy", handlePostClusterPropagationPolicy)
+	// 更新集群传播策略
+	r.PUT("/clusterpropagationpolicy/:clusterPropagationPolicyName", handlePutClusterPropagationPolicy)
+	// 删除集群传播策略
+	r.DELETE("/clusterpropagationpolicy/:clusterPropagationPolicyName", handleDeleteClusterPropagationPolicy)
 }
@@ -24,6 +24,7 @@ import (
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
 	"github.com/karmada-io/dashboard/pkg/config"
 )
 
@@ -40,7 +41,7 @@ func SetDashboardConfig(c *gin.Context) {
 	setDashboardConfigRequest := new(v1.SetDashboardConfigRequest)
 	if err := c.ShouldBind(setDashboardConfigRequest); err != nil {
 		klog.ErrorS(err, "Could not read SetDashboardConfigRequest")
-		common.Fail(c, err)
+		common.Fail(c, errors.NewBindingError(err))
 		return
 	}
 
@@ -54,8 +55,19 @@ func SetDashboardConfig(c *gin.Context) {
 	if len(setDashboardConfigRequest.MenuConfigs) > 0 {
 		dashboardConfig.MenuConfigs = setDashboardConfigRequest.MenuConfigs
 	}
+	// 配置保存在宿主集群中，使用 dashboard 自身身份写入，因此先在 Karmada API 服务器上校验调用者的权限
+	userClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if err = config.AuthorizeDashboardConfigUpdate(userClient); err != nil {
+		klog.ErrorS(err, "User is not allowed to update dashboard config")
+		common.Fail(c, err)
+		return
+	}
 	k8sClient := client.InClusterClient()
-	err := config.UpdateDashboardConfig(k8sClient, dashboardConfig)
+	err = config.UpdateDashboardConfig(k8sClient, dashboardConfig)
 	if err != nil {
 		klog.ErrorS(err, "Error updating dashboard config")
 		common.Fail(c, err)
@@ -20,14 +20,22 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	resourcecommon "github.com/karmada-io/dashboard/pkg/resource/common"
 	"github.com/karmada-io/dashboard/pkg/resource/configmap"
 )
 
 // 获取配置map列表
 func handleGetConfigMap(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	nsQuery := common.ParseNamespacePathParameter(c)
 	result, err := configmap.GetConfigMapList(k8sClient, nsQuery, dataSelect)
@@ -40,7 +48,11 @@ func handleGetConfigMap(c *gin.Context) {
 
 // 获取配置map详情
 func handleGetConfigMapDetail(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := c.Param("namespace")
 	name := c.Param("name")
 	result, err := configmap.GetConfigMapDetail(k8sClient, namespace, name)
@@ -51,6 +63,60 @@ func handleGetConfigMapDetail(c *gin.Context) {
 	common.Success(c, result)
 }
 
+// 创建configmap
+func handleCreateConfigMap(c *gin.Context) {
+	createRequest := new(v1.CreateResourceRequest)
+	if err := c.ShouldBind(createRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := resourcecommon.CreateResource(dynamicClient, types.ResourceKindConfigMap, createRequest.Namespace, createRequest.Content)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// 更新configmap
+func handleUpdateConfigMap(c *gin.Context) {
+	updateRequest := new(v1.UpdateResourceRequest)
+	if err := c.ShouldBind(updateRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := resourcecommon.UpdateResource(dynamicClient, types.ResourceKindConfigMap, c.Param("namespace"), c.Param("name"), updateRequest.Content)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// 删除configmap
+func handleDeleteConfigMap(c *gin.Context) {
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if err = resourcecommon.DeleteResource(dynamicClient, types.ResourceKindConfigMap, c.Param("namespace"), c.Param("name")); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
 // 初始化路由
 func init() {
 	r := router.V1()
@@ -59,4 +125,10 @@ func init() {
 	// 获取配置map详情
 	r.GET("/configmap/:namespace", handleGetConfigMap)
 	r.GET("/configmap/:namespace/:name", handleGetConfigMapDetail)
+	// 创建configmap
+	r.POST("/configmap", handleCreateConfigMap)
+	// 更新configmap
+	r.PUT("/configmap/:namespace/:name", handleUpdateConfigMap)
+	// 删除configmap
+	r.DELETE("/configmap/:namespace/:name", handleDeleteConfigMap)
 }
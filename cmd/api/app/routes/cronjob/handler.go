@@ -20,8 +20,12 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	resourcecommon "github.com/karmada-io/dashboard/pkg/resource/common"
 	"github.com/karmada-io/dashboard/pkg/resource/cronjob"
 	"github.com/karmada-io/dashboard/pkg/resource/event"
 )
@@ -30,7 +34,11 @@ import (
 func handleGetCronJob(c *gin.Context) {
 	namespace := common.ParseNamespacePathParameter(c)
 	dataSelect := common.ParseDataSelectPathParameter(c)
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	result, err := cronjob.GetCronJobList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		common.Fail(c, err)
@@ -43,7 +51,11 @@ func handleGetCronJob(c *gin.Context) {
 func handleGetCronJobDetail(c *gin.Context) {
 	namespace := c.Param("namespace")
 	name := c.Param("statefulset")
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	result, err := cronjob.GetCronJobDetail(k8sClient, namespace, name)
 	if err != nil {
 		common.Fail(c, err)
@@ -56,7 +68,11 @@ func handleGetCronJobDetail(c *gin.Context) {
 func handleGetCronJobEvents(c *gin.Context) {
 	namespace := c.Param("namespace")
 	name := c.Param("statefulset")
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
@@ -66,6 +82,60 @@ func handleGetCronJobEvents(c *gin.Context) {
 	common.Success(c, result)
 }
 
+// 创建cronjob
+func handleCreateCronJob(c *gin.Context) {
+	createRequest := new(v1.CreateResourceRequest)
+	if err := c.ShouldBind(createRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := resourcecommon.CreateResource(dynamicClient, types.ResourceKindCronJob, createRequest.Namespace, createRequest.Content)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// 更新cronjob
+func handleUpdateCronJob(c *gin.Context) {
+	updateRequest := new(v1.UpdateResourceRequest)
+	if err := c.ShouldBind(updateRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := resourcecommon.UpdateResource(dynamicClient, types.ResourceKindCronJob, c.Param("namespace"), c.Param("name"), updateRequest.Content)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// 删除cronjob
+func handleDeleteCronJob(c *gin.Context) {
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if err = resourcecommon.DeleteResource(dynamicClient, types.ResourceKindCronJob, c.Param("namespace"), c.Param("name")); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
 // 初始化路由
 func init() {
 	r := router.V1()
@@ -76,4 +146,10 @@ func init() {
 	r.GET("/cronjob/:namespace/:statefulset", handleGetCronJobDetail)
 	// 获取cronjob事件
 	r.GET("/cronjob/:namespace/:statefulset/event", handleGetCronJobEvents)
+	// 创建cronjob
+	r.POST("/cronjob", handleCreateCronJob)
+	// 更新cronjob
+	r.PUT("/cronjob/:namespace/:name", handleUpdateCronJob)
+	// 删除cronjob
+	r.DELETE("/cronjob/:namespace/:name", handleDeleteCronJob)
 }
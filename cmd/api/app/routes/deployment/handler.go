@@ -22,13 +22,13 @@ import (
 	"github.com/gin-gonic/gin"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/yaml"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
 	"github.com/karmada-io/dashboard/pkg/resource/deployment"
 	"github.com/karmada-io/dashboard/pkg/resource/event"
 )
@@ -38,19 +38,14 @@ func handlerCreateDeployment(c *gin.Context) {
 	ctx := context.Context(c)
 	createDeploymentRequest := new(v1.CreateDeploymentRequest)
 	if err := c.ShouldBind(&createDeploymentRequest); err != nil {
-		common.Fail(c, err)
+		common.Fail(c, errors.NewBindingError(err))
 		return
 	}
 	if createDeploymentRequest.Namespace == "" {
 		createDeploymentRequest.Namespace = "default"
 	}
 
-	restConfig, _, err := client.GetKarmadaConfig()
-	if err != nil {
-		common.Fail(c, err)
-		return
-	}
-	clientset, err := kubernetes.NewForConfig(restConfig)
+	clientset, err := client.GetKubeClientFromRequest(c.Request)
 	if err != nil {
 		common.Fail(c, err)
 		return
@@ -72,7 +67,11 @@ func handlerCreateDeployment(c *gin.Context) {
 func handleGetDeployments(c *gin.Context) {
 	namespace := common.ParseNamespacePathParameter(c)
 	dataSelect := common.ParseDataSelectPathParameter(c)
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	result, err := deployment.GetDeploymentList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		common.Fail(c, err)
@@ -85,7 +84,11 @@ func handleGetDeployments(c *gin.Context) {
 func handleGetDeploymentDetail(c *gin.Context) {
 	namespace := c.Param("namespace")
 	name := c.Param("deployment")
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	result, err := deployment.GetDeploymentDetail(k8sClient, namespace, name)
 	if err != nil {
 		common.Fail(c, err)
@@ -98,7 +101,11 @@ func handleGetDeploymentDetail(c *gin.Context) {
 func handleGetDeploymentEvents(c *gin.Context) {
 	namespace := c.Param("namespace")
 	name := c.Param("deployment")
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
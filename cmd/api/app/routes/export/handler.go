@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/export"
+	"github.com/karmada-io/dashboard/pkg/informer"
+)
+
+// 将选择的资源及其策略导出为可重新应用的资源清单
+func handleExport(c *gin.Context) {
+	exportRequest := new(v1.ExportRequest)
+	if err := c.ShouldBind(exportRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	format, err := export.ParseFormat(exportRequest.Format)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	mapper, err := client.GetRESTMapperFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	var policies *export.Policies
+	if exportRequest.IncludePolicies {
+		if policies, err = policiesForRequest(c.Request, exportRequest.Namespace); err != nil {
+			common.Fail(c, err)
+			return
+		}
+	}
+	objs, err := export.Collect(c.Request.Context(), dynamicClient, mapper, policies, export.Options{
+		Namespace:       exportRequest.Namespace,
+		Kinds:           splitKinds(exportRequest.Kinds),
+		LabelSelector:   exportRequest.LabelSelector,
+		IncludePolicies: exportRequest.IncludePolicies,
+	})
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	// 先写入缓冲区，以便出错时仍能返回 JSON 格式的错误
+	buf := new(bytes.Buffer)
+	if err = export.Write(buf, format, objs); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	fileName := fmt.Sprintf("karmada-export-%s.%s", time.Now().Format("20060102150405"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Data(http.StatusOK, format.ContentType(), buf.Bytes())
+}
+
+// policiesForRequest 检查请求的用户是否有权限列出策略，并从 informer 缓存中读取策略
+func policiesForRequest(request *http.Request, namespace string) (*export.Policies, error) {
+	listers, err := informer.ListersForList(request, namespace,
+		types.ResourceKindPropagationPolicy, types.ResourceKindClusterPropagationPolicy,
+		types.ResourceKindOverridePolicy, types.ResourceKindClusterOverridePolicy)
+	if err != nil {
+		return nil, err
+	}
+	return export.PoliciesFromListers(listers, namespace)
+}
+
+// splitKinds 拆分以逗号分隔的资源类型，忽略空项
+func splitKinds(kinds string) []string {
+	var result []string
+	for _, kind := range strings.Split(kinds, ",") {
+		if kind = strings.TrimSpace(kind); kind != "" {
+			result = append(result, kind)
+		}
+	}
+	return result
+}
+
+// 初始化路由
+func init() {
+	r := router.V1()
+	// 导出资源及其策略
+	r.GET("/export", handleExport)
+}
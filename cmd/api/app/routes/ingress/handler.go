@@ -20,14 +20,22 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	resourcecommon "github.com/karmada-io/dashboard/pkg/resource/common"
 	"github.com/karmada-io/dashboard/pkg/resource/ingress"
 )
 
 // 获取ingress列表
 func handleGetIngress(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	nsQuery := common.ParseNamespacePathParameter(c)
 	result, err := ingress.GetIngressList(k8sClient, nsQuery, dataSelect)
@@ -40,7 +48,11 @@ func handleGetIngress(c *gin.Context) {
 
 // 获取ingress详情
 func handleGetIngressDetail(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := c.Param("namespace")
 	name := c.Param("service")
 	result, err := ingress.GetIngressDetail(k8sClient, namespace, name)
@@ -51,6 +63,60 @@ func handleGetIngressDetail(c *gin.Context) {
 	common.Success(c, result)
 }
 
+// 创建ingress
+func handleCreateIngress(c *gin.Context) {
+	createRequest := new(v1.CreateResourceRequest)
+	if err := c.ShouldBind(createRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := resourcecommon.CreateResource(dynamicClient, types.ResourceKindIngress, createRequest.Namespace, createRequest.Content)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// 更新ingress
+func handleUpdateIngress(c *gin.Context) {
+	updateRequest := new(v1.UpdateResourceRequest)
+	if err := c.ShouldBind(updateRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := resourcecommon.UpdateResource(dynamicClient, types.ResourceKindIngress, c.Param("namespace"), c.Param("name"), updateRequest.Content)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// 删除ingress
+func handleDeleteIngress(c *gin.Context) {
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if err = resourcecommon.DeleteResource(dynamicClient, types.ResourceKindIngress, c.Param("namespace"), c.Param("name")); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
 // 初始化路由
 func init() {
 	r := router.V1()
@@ -60,4 +126,10 @@ func init() {
 	r.GET("/ingress/:namespace", handleGetIngress)
 	// 获取ingress详情
 	r.GET("/ingress/:namespace/:service", handleGetIngressDetail)
+	// 创建ingress
+	r.POST("/ingress", handleCreateIngress)
+	// 更新ingress
+	r.PUT("/ingress/:namespace/:name", handleUpdateIngress)
+	// 删除ingress
+	r.DELETE("/ingress/:namespace/:name", handleDeleteIngress)
 }
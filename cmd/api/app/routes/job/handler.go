@@ -20,8 +20,12 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	resourcecommon "github.com/karmada-io/dashboard/pkg/resource/common"
 	"github.com/karmada-io/dashboard/pkg/resource/event"
 	"github.com/karmada-io/dashboard/pkg/resource/job"
 )
@@ -30,7 +34,11 @@ import (
 func handleGetJob(c *gin.Context) {
 	namespace := common.ParseNamespacePathParameter(c)
 	dataSelect := common.ParseDataSelectPathParameter(c)
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	result, err := job.GetJobList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		common.Fail(c, err)
@@ -43,7 +51,11 @@ func handleGetJob(c *gin.Context) {
 func handleGetJobDetail(c *gin.Context) {
 	namespace := c.Param("namespace")
 	name := c.Param("statefulset")
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	result, err := job.GetJobDetail(k8sClient, namespace, name)
 	if err != nil {
 		common.Fail(c, err)
@@ -56,7 +68,11 @@ func handleGetJobDetail(c *gin.Context) {
 func handleGetJobEvents(c *gin.Context) {
 	namespace := c.Param("namespace")
 	name := c.Param("statefulset")
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
 	if err != nil {
@@ -66,6 +82,60 @@ func handleGetJobEvents(c *gin.Context) {
 	common.Success(c, result)
 }
 
+// 创建job
+func handleCreateJob(c *gin.Context) {
+	createRequest := new(v1.CreateResourceRequest)
+	if err := c.ShouldBind(createRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := resourcecommon.CreateResource(dynamicClient, types.ResourceKindJob, createRequest.Namespace, createRequest.Content)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// 更新job
+func handleUpdateJob(c *gin.Context) {
+	updateRequest := new(v1.UpdateResourceRequest)
+	if err := c.ShouldBind(updateRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := resourcecommon.UpdateResource(dynamicClient, types.ResourceKindJob, c.Param("namespace"), c.Param("name"), updateRequest.Content)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// 删除job
+func handleDeleteJob(c *gin.Context) {
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	if err = resourcecommon.DeleteResource(dynamicClient, types.ResourceKindJob, c.Param("namespace"), c.Param("name")); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, "ok")
+}
+
 // 初始化路由
 func init() {
 	r := router.V1()
@@ -77,4 +147,10 @@ func init() {
 	r.GET("/job/:namespace/:statefulset", handleGetJobDetail)
 	// 获取job事件
 	r.GET("/job/:namespace/:statefulset/event", handleGetJobEvents)
+	// 创建job
+	r.POST("/job", handleCreateJob)
+	// 更新job
+	r.PUT("/job/:namespace/:name", handleUpdateJob)
+	// 删除job
+	r.DELETE("/job/:namespace/:name", handleDeleteJob)
 }
@@ -28,7 +28,11 @@ import (
 
 // 获取成员集群的deployment列表
 func handleGetMemberDeployments(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := common.ParseNamespacePathParameter(c)
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := deployment.GetDeploymentList(memberClient, namespace, dataSelect)
@@ -41,7 +45,11 @@ func handleGetMemberDeployments(c *gin.Context) {
 
 // 获取成员集群的deployment详情
 func handleGetMemberDeploymentDetail(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := c.Param("namespace")
 	name := c.Param("deployment")
 	result, err := deployment.GetDeploymentDetail(memberClient, namespace, name)
@@ -54,7 +62,11 @@ func handleGetMemberDeploymentDetail(c *gin.Context) {
 
 // 获取成员集群的deployment事件
 func handleGetMemberDeploymentEvents(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := c.Param("namespace")
 	name := c.Param("deployment")
 	dataSelect := common.ParseDataSelectPathParameter(c)
@@ -28,7 +28,11 @@ import (
 
 // 获取成员集群的namespace列表
 func handleGetMemberNamespace(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := ns.GetNamespaceList(memberClient, dataSelect)
@@ -41,7 +45,11 @@ func handleGetMemberNamespace(c *gin.Context) {
 
 // 获取成员集群的namespace详情
 func handleGetMemberNamespaceDetail(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 
 	name := c.Param("name")
 	result, err := ns.GetNamespaceDetail(memberClient, name)
@@ -54,7 +62,11 @@ func handleGetMemberNamespaceDetail(c *gin.Context) {
 
 // 获取成员集群的namespace事件
 func handleGetMemberNamespaceEvents(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 
 	name := c.Param("name")
 	dataSelect := common.ParseDataSelectPathParameter(c)
@@ -18,17 +18,29 @@ package node
 
 import (
 	"github.com/gin-gonic/gin"
+	authorizationv1 "k8s.io/api/authorization/v1"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+	"github.com/karmada-io/dashboard/pkg/informer"
 	"github.com/karmada-io/dashboard/pkg/resource/node"
 )
 
 // 获取成员集群的node列表
 func handleGetClusterNode(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	clusterName := c.Param("clustername")
 	dataSelect := common.ParseDataSelectPathParameter(c)
+	if memberCache, ok := informer.Member(clusterName); ok {
+		handleGetCachedClusterNode(c, memberCache, dataSelect)
+		return
+	}
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, clusterName)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	result, err := node.GetNodeList(memberClient, dataSelect)
 	if err != nil {
 		common.Fail(c, err)
@@ -37,6 +49,29 @@ func handleGetClusterNode(c *gin.Context) {
 	common.Success(c, result)
 }
 
+// 从成员集群的缓存获取node列表，缓存使用 dashboard 自身的凭证，因此先检查用户在成员集群中的权限
+func handleGetCachedClusterNode(c *gin.Context, memberCache *informer.MemberCache, dataSelect *dataselect.DataSelectQuery) {
+	err := client.AuthorizeMember(c.Request, c.Param("clustername"), authorizationv1.ResourceAttributes{
+		Verb:     "list",
+		Resource: "nodes",
+	})
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	lister, err := memberCache.Nodes(c.Request.Context())
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := node.GetNodeListFromLister(lister, dataSelect)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
 // 初始化路由
 func init() {
 	r := router.MemberV1()
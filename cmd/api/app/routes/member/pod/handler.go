@@ -28,7 +28,11 @@ import (
 // return a pods list
 // 获取成员集群的pod列表
 func handleGetMemberPod(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	nsQuery := common.ParseNamespacePathParameter(c)
 	result, err := pod.GetPodList(memberClient, nsQuery, dataSelect)
@@ -42,7 +46,11 @@ func handleGetMemberPod(c *gin.Context) {
 // return a pod detail
 // 获取成员集群的pod详情
 func handleGetMemberPodDetail(c *gin.Context) {
-	memberClient := client.InClusterClientForMemberCluster(c.Param("clustername"))
+	memberClient, err := client.GetMemberClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	namespace := c.Param("namespace")
 	name := c.Param("name")
 	result, err := pod.GetPodDetail(memberClient, namespace, name)
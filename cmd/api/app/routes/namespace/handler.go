@@ -23,16 +23,21 @@ import (
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
 	"github.com/karmada-io/dashboard/pkg/resource/event"
 	ns "github.com/karmada-io/dashboard/pkg/resource/namespace"
 )
 
 // 创建namespace
 func handleCreateNamespace(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	createNamespaceRequest := new(v1.CreateNamesapceRequest)
 	if err := c.ShouldBind(&createNamespaceRequest); err != nil {
-		common.Fail(c, err)
+		common.Fail(c, errors.NewBindingError(err))
 		return
 	}
 	spec := &ns.NamespaceSpec{
@@ -48,7 +53,11 @@ func handleCreateNamespace(c *gin.Context) {
 
 // 获取namespace列表
 func handleGetNamespaces(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := ns.GetNamespaceList(k8sClient, dataSelect)
 	if err != nil {
@@ -60,7 +69,11 @@ func handleGetNamespaces(c *gin.Context) {
 
 // 获取namespace详情
 func handleGetNamespaceDetail(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	name := c.Param("name")
 	result, err := ns.GetNamespaceDetail(k8sClient, name)
 	if err != nil {
@@ -72,7 +85,11 @@ func handleGetNamespaceDetail(c *gin.Context) {
 
 // 获取namespace事件
 func handleGetNamespaceEvents(c *gin.Context) {
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
+	k8sClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	name := c.Param("name")
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	result, err := event.GetNamespaceEvents(k8sClient, dataSelect, name)
@@ -24,23 +24,27 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
-	"sigs.k8s.io/yaml"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
 	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
 	"github.com/karmada-io/dashboard/pkg/resource/overridepolicy"
 )
 
 // 获取覆盖策略列表
 func handleGetOverridePolicyList(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	namespace := common.ParseNamespacePathParameter(c)
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
-	overrideList, err := overridepolicy.GetOverridePolicyList(karmadaClient, k8sClient, namespace, dataSelect)
+	listers, err := informer.ListersForList(c.Request, namespace.ToRequestParam(), types.ResourceKindOverridePolicy)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	overrideList, err := overridepolicy.GetOverridePolicyList(listers.OverridePolicies, namespace, dataSelect)
 	if err != nil {
 		klog.ErrorS(err, "Failed to GetOverridePolicyList")
 		common.Fail(c, err)
@@ -51,10 +55,14 @@ func handleGetOverridePolicyList(c *gin.Context) {
 
 // 获取覆盖策略详情
 func handleGetOverridePolicyDetail(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
 	namespace := c.Param("namespace")
 	name := c.Param("overridePolicyName")
-	result, err := overridepolicy.GetOverridePolicyDetail(karmadaClient, namespace, name)
+	listers, err := informer.ListersForGet(c.Request, types.ResourceKindOverridePolicy, namespace, name)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := overridepolicy.GetOverridePolicyDetail(listers.OverridePolicies, namespace, name)
 	if err != nil {
 		klog.ErrorS(err, "GetOverridePolicyDetail failed")
 		common.Fail(c, err)
@@ -63,37 +71,48 @@ func handleGetOverridePolicyDetail(c *gin.Context) {
 	common.Success(c, result)
 }
 
-// 创建覆盖策略
+// 创建覆盖策略，请求可以是 YAML 或结构化的 spec
 func handlePostOverridePolicy(c *gin.Context) {
 	// todo precheck existence of namespace, now we tested it under scope of default, it's ok till now.
 	ctx := context.Context(c)
 	overridepolicyRequest := new(v1.PostOverridePolicyRequest)
 	if err := c.ShouldBind(&overridepolicyRequest); err != nil {
-		common.Fail(c, err)
+		common.Fail(c, errors.NewBindingError(err))
 		return
 	}
 	if overridepolicyRequest.Namespace == "" {
 		overridepolicyRequest.Namespace = "default"
 	}
+	data := &overridepolicy.PolicyData{
+		YAML:        overridepolicyRequest.OverrideData,
+		Name:        overridepolicyRequest.Name,
+		Namespace:   overridepolicyRequest.Namespace,
+		Labels:      overridepolicyRequest.Labels,
+		Annotations: overridepolicyRequest.Annotations,
+		Spec:        overridepolicyRequest.Spec,
+	}
 
-	var err error
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	if overridepolicyRequest.IsClusterScope {
-		clusteroverridePolicy := v1alpha1.ClusterOverridePolicy{}
-		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &clusteroverridePolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal ClusterOverridePolicy")
+		var clusteroverridePolicy *v1alpha1.ClusterOverridePolicy
+		if clusteroverridePolicy, err = data.ClusterOverridePolicy(); err != nil {
+			klog.ErrorS(err, "Invalid ClusterOverridePolicy")
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Create(ctx, &clusteroverridePolicy, metav1.CreateOptions{})
+		_, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Create(ctx, clusteroverridePolicy, metav1.CreateOptions{})
 	} else {
-		overridePolicy := v1alpha1.OverridePolicy{}
-		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &overridePolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal OverridePolicy")
+		var overridePolicy *v1alpha1.OverridePolicy
+		if overridePolicy, err = data.OverridePolicy(); err != nil {
+			klog.ErrorS(err, "Invalid OverridePolicy")
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace).Create(ctx, &overridePolicy, metav1.CreateOptions{})
+		_, err = karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace).Create(ctx, overridePolicy, metav1.CreateOptions{})
 	}
 	if err != nil {
 		klog.ErrorS(err, "Failed to create OverridePolicies")
@@ -103,39 +122,42 @@ func handlePostOverridePolicy(c *gin.Context) {
 	common.Success(c, "ok")
 }
 
-// 更新覆盖策略
+// 更新覆盖策略，请求可以是 YAML 或结构化的 spec
 func handlePutOverridePolicy(c *gin.Context) {
 	ctx := context.Context(c)
 	overridepolicyRequest := new(v1.PutOverridePolicyRequest)
 	if err := c.ShouldBind(&overridepolicyRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	data := &overridepolicy.PolicyData{
+		YAML:        overridepolicyRequest.OverrideData,
+		Labels:      overridepolicyRequest.Labels,
+		Annotations: overridepolicyRequest.Annotations,
+		Spec:        overridepolicyRequest.Spec,
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
 		common.Fail(c, err)
 		return
 	}
-	var err error
-	karmadaClient := client.InClusterKarmadaClient()
-	// todo check pp exist
 	if overridepolicyRequest.IsClusterScope {
-		clusteroverridePolicy := v1alpha1.ClusterOverridePolicy{}
-		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &clusteroverridePolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal ClusterOverridePolicy")
-			common.Fail(c, err)
-			return
+		var oldClusterOverridePolicy, clusteroverridePolicy *v1alpha1.ClusterOverridePolicy
+		oldClusterOverridePolicy, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Get(ctx, overridepolicyRequest.Name, metav1.GetOptions{})
+		if err == nil {
+			clusteroverridePolicy, err = data.UpdateClusterOverridePolicy(oldClusterOverridePolicy)
 		}
-		_, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Update(ctx, &clusteroverridePolicy, metav1.UpdateOptions{})
-	} else {
-		overridePolicy := v1alpha1.OverridePolicy{}
-		if err = yaml.Unmarshal([]byte(overridepolicyRequest.OverrideData), &overridePolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal OverridePolicy")
-			common.Fail(c, err)
-			return
+		if err == nil {
+			_, err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Update(ctx, clusteroverridePolicy, metav1.UpdateOptions{})
 		}
-		var oldOverridePolicy *v1alpha1.OverridePolicy
+	} else {
+		var oldOverridePolicy, overridePolicy *v1alpha1.OverridePolicy
 		oldOverridePolicy, err = karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace).Get(ctx, overridepolicyRequest.Name, metav1.GetOptions{})
 		if err == nil {
-			// only spec can be updated
-			overridePolicy.TypeMeta = oldOverridePolicy.TypeMeta
-			overridePolicy.ObjectMeta = oldOverridePolicy.ObjectMeta
-			_, err = karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace).Update(ctx, &overridePolicy, metav1.UpdateOptions{})
+			overridePolicy, err = data.UpdateOverridePolicy(oldOverridePolicy)
+		}
+		if err == nil {
+			_, err = karmadaClient.PolicyV1alpha1().OverridePolicies(overridepolicyRequest.Namespace).Update(ctx, overridePolicy, metav1.UpdateOptions{})
 		}
 	}
 	if err != nil {
@@ -151,13 +173,16 @@ func handleDeleteOverridePolicy(c *gin.Context) {
 	ctx := context.Context(c)
 	overridepolicyRequest := new(v1.DeleteOverridePolicyRequest)
 	if err := c.ShouldBind(&overridepolicyRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
 		common.Fail(c, err)
 		return
 	}
-	var err error
-	karmadaClient := client.InClusterKarmadaClient()
 	if overridepolicyRequest.IsClusterScope {
-		err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Delete(ctx, overridepolicyRequest.Name, metav1.DeleteOptions{})
+		err = overridepolicy.DeleteClusterOverridePolicy(ctx, karmadaClient, overridepolicyRequest.Name, "")
 		if err != nil {
 			klog.ErrorS(err, "Failed to delete ClusterOverridePolicy")
 			common.Fail(c, err)
@@ -199,4 +224,6 @@ func init() {
 	r.PUT("/overridepolicy", handlePutOverridePolicy)
 	// 删除覆盖策略
 	r.DELETE("/overridepolicy", handleDeleteOverridePolicy)
+	// 预览覆盖策略在各集群渲染的资源
+	r.POST("/overridepolicy/preview", handlePostOverridePolicyPreview)
 }
@@ -0,0 +1,262 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
+	"github.com/karmada-io/dashboard/pkg/resource/cluster"
+	"github.com/karmada-io/dashboard/pkg/resource/overridepolicy"
+)
+
+// 预览覆盖策略为每个目标集群渲染的资源及其差异
+func handlePostOverridePolicyPreview(c *gin.Context) {
+	previewRequest := new(v1.PreviewOverridePolicyRequest)
+	if err := c.ShouldBind(previewRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	resource, err := previewResource(c, previewRequest)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	policies, err := previewPolicies(c.Request, previewRequest, resource.GetNamespace())
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	clusters, err := previewClusters(c.Request, previewRequest, resource)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	previews, err := overridepolicy.Preview(resource, clusters, policies)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, previews)
+}
+
+// previewResource 解析请求中的资源模板，或从控制面获取请求指定的资源
+func previewResource(c *gin.Context, previewRequest *v1.PreviewOverridePolicyRequest) (*unstructured.Unstructured, error) {
+	if previewRequest.Resource != "" {
+		resource := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(previewRequest.Resource), &resource.Object); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid resource: %v", err))
+		}
+		if resource.GetAPIVersion() == "" || resource.GetKind() == "" || resource.GetName() == "" {
+			return nil, errors.NewBadRequest("resource must have apiVersion, kind and metadata.name")
+		}
+		return resource, nil
+	}
+
+	if previewRequest.APIVersion == "" || previewRequest.Kind == "" || previewRequest.Name == "" {
+		return nil, errors.NewBadRequest("either resource or apiVersion, kind and name are required")
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := client.GetRESTMapperFromRequest(c.Request)
+	if err != nil {
+		return nil, err
+	}
+	gvk := schema.FromAPIVersionAndKind(previewRequest.APIVersion, previewRequest.Kind)
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	resourceClient := dynamic.ResourceInterface(dynamicClient.Resource(mapping.Resource))
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		resourceClient = dynamicClient.Resource(mapping.Resource).Namespace(previewRequest.Namespace)
+	}
+	return resourceClient.Get(c, previewRequest.Name, metav1.GetOptions{})
+}
+
+// previewPolicies 返回参与预览的覆盖策略，未保存的策略替换同名的已保存策略
+func previewPolicies(request *http.Request, previewRequest *v1.PreviewOverridePolicyRequest, namespace string) (*overridepolicy.Policies, error) {
+	ops := map[string]*v1alpha1.OverridePolicy{}
+	cops := map[string]*v1alpha1.ClusterOverridePolicy{}
+	if previewRequest.IncludeExisting {
+		if err := existingOverridePolicies(request, namespace, ops, cops); err != nil {
+			return nil, err
+		}
+	}
+	for _, reference := range previewRequest.Policies {
+		if err := referencedOverridePolicy(request, reference, ops, cops); err != nil {
+			return nil, err
+		}
+	}
+	for _, data := range previewRequest.OverrideData {
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal([]byte(data), &typeMeta); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid override policy: %v", err))
+		}
+		if typeMeta.Kind == v1alpha1.ResourceKindClusterOverridePolicy {
+			policy := &v1alpha1.ClusterOverridePolicy{}
+			if err := yaml.Unmarshal([]byte(data), policy); err != nil {
+				return nil, errors.NewBadRequest(fmt.Sprintf("invalid ClusterOverridePolicy: %v", err))
+			}
+			cops[policy.Name] = policy
+			continue
+		}
+		policy := &v1alpha1.OverridePolicy{}
+		if err := yaml.Unmarshal([]byte(data), policy); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid OverridePolicy: %v", err))
+		}
+		if policy.Namespace == "" {
+			policy.Namespace = namespace
+		}
+		ops[policy.Namespace+"/"+policy.Name] = policy
+	}
+
+	policies := &overridepolicy.Policies{}
+	for _, policy := range ops {
+		policies.OverridePolicies = append(policies.OverridePolicies, policy)
+	}
+	for _, policy := range cops {
+		policies.ClusterOverridePolicies = append(policies.ClusterOverridePolicies, policy)
+	}
+	return policies, nil
+}
+
+// existingOverridePolicies 将命名空间中已保存的 OverridePolicy 和所有 ClusterOverridePolicy 加入 ops 和 cops
+func existingOverridePolicies(request *http.Request, namespace string, ops map[string]*v1alpha1.OverridePolicy,
+	cops map[string]*v1alpha1.ClusterOverridePolicy) error {
+	listers, err := informer.ListersForList(request, "", types.ResourceKindClusterOverridePolicy)
+	if err != nil {
+		return err
+	}
+	clusterPolicies, err := listers.ClusterOverridePolicies.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, policy := range clusterPolicies {
+		cops[policy.Name] = policy
+	}
+	if namespace == "" {
+		return nil
+	}
+	if listers, err = informer.ListersForList(request, namespace, types.ResourceKindOverridePolicy); err != nil {
+		return err
+	}
+	namespacedPolicies, err := listers.OverridePolicies.OverridePolicies(namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, policy := range namespacedPolicies {
+		ops[policy.Namespace+"/"+policy.Name] = policy
+	}
+	return nil
+}
+
+// referencedOverridePolicy 将请求引用的已保存策略加入 ops 或 cops
+func referencedOverridePolicy(request *http.Request, reference v1.OverridePolicyReference, ops map[string]*v1alpha1.OverridePolicy,
+	cops map[string]*v1alpha1.ClusterOverridePolicy) error {
+	if reference.IsClusterScope {
+		listers, err := informer.ListersForGet(request, types.ResourceKindClusterOverridePolicy, "", reference.Name)
+		if err != nil {
+			return err
+		}
+		policy, err := listers.ClusterOverridePolicies.Get(reference.Name)
+		if err != nil {
+			return err
+		}
+		cops[policy.Name] = policy
+		return nil
+	}
+	listers, err := informer.ListersForGet(request, types.ResourceKindOverridePolicy, reference.Namespace, reference.Name)
+	if err != nil {
+		return err
+	}
+	policy, err := listers.OverridePolicies.OverridePolicies(reference.Namespace).Get(reference.Name)
+	if err != nil {
+		return err
+	}
+	ops[policy.Namespace+"/"+policy.Name] = policy
+	return nil
+}
+
+// previewClusters 返回预览的目标集群：请求指定的集群、资源绑定调度到的集群或所有集群
+func previewClusters(request *http.Request, previewRequest *v1.PreviewOverridePolicyRequest, resource *unstructured.Unstructured) ([]*clusterv1alpha1.Cluster, error) {
+	listers, err := informer.ListersForList(request, "", types.ResourceKindCluster)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := listers.Clusters.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	targets := previewRequest.Clusters
+	if len(targets) == 0 {
+		if targets, err = scheduledClusters(request, listers, resource); err != nil {
+			return nil, err
+		}
+	}
+	if len(targets) == 0 {
+		return clusters, nil
+	}
+	selected := make([]*clusterv1alpha1.Cluster, 0, len(targets))
+	for _, target := range targets {
+		found := false
+		for _, cluster := range clusters {
+			if cluster.Name == target {
+				selected = append(selected, cluster)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.NewBadRequest(fmt.Sprintf("cluster %q not found", target))
+		}
+	}
+	return selected, nil
+}
+
+// scheduledClusters 返回资源的绑定调度到的集群，资源未被分发时返回空
+func scheduledClusters(request *http.Request, listers *informer.KarmadaListers, resource *unstructured.Unstructured) ([]string, error) {
+	bindingKind, bindingName := cluster.BindingOf(resource.GetKind(), resource.GetNamespace(), resource.GetName())
+	if _, err := informer.ListersForGet(request, bindingKind, resource.GetNamespace(), bindingName); err != nil {
+		return nil, err
+	}
+	targets, err := cluster.ScheduledClusters(listers.ResourceBindings, listers.ClusterResourceBindings,
+		resource.GetKind(), resource.GetNamespace(), resource.GetName())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	return targets, err
+}
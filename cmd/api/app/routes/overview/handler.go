@@ -22,23 +22,39 @@ import (
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
 )
 
 // 获取仪表盘概览
 func handleGetOverview(c *gin.Context) {
 	dataSelect := common.ParseDataSelectPathParameter(c)
+	listers, err := informer.ListersForList(c.Request, "", types.ResourceKindCluster,
+		types.ResourceKindPropagationPolicy, types.ResourceKindClusterPropagationPolicy,
+		types.ResourceKindOverridePolicy, types.ResourceKindClusterOverridePolicy)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	kubeClient, err := client.GetKubeClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	// karmada-controller-manager 运行在宿主集群中，仍使用 dashboard 自身的凭证获取版本信息
 	karmadaInfo, err := GetControllerManagerInfo()
 	if err != nil {
 		common.Fail(c, err)
 		return
 	}
-	memberClusterStatus, err := GetMemberClusterInfo(dataSelect)
+	memberClusterStatus, err := GetMemberClusterInfo(listers.Clusters, dataSelect)
 	if err != nil {
 		common.Fail(c, err)
 		return
 	}
 
-	clusterResourceStatus, err := GetClusterResourceStatus()
+	clusterResourceStatus, err := GetClusterResourceStatus(listers, kubeClient)
 	if err != nil {
 		common.Fail(c, err)
 		return
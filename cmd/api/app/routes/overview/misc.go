@@ -24,16 +24,19 @@ import (
 	"math/big"
 	"strings"
 
+	clusterlisters "github.com/karmada-io/karmada/pkg/generated/listers/cluster/v1alpha1"
 	"github.com/karmada-io/karmada/pkg/version"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
 
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/pkg/client"
 	"github.com/karmada-io/dashboard/pkg/dataselect"
+	"github.com/karmada-io/dashboard/pkg/informer"
 	"github.com/karmada-io/dashboard/pkg/resource/cluster"
 )
 
@@ -165,9 +168,8 @@ func GetControllerManagerInfo() (*v1.KarmadaInfo, error) {
 
 // GetMemberClusterInfo returns the status of member clusters.
 // 获取成员集群的状态
-func GetMemberClusterInfo(ds *dataselect.DataSelectQuery) (*v1.MemberClusterStatus, error) {
-	karmadaClient := client.InClusterKarmadaClient()
-	result, err := cluster.GetClusterList(karmadaClient, ds)
+func GetMemberClusterInfo(lister clusterlisters.ClusterLister, ds *dataselect.DataSelectQuery) (*v1.MemberClusterStatus, error) {
+	result, err := cluster.GetClusterList(lister, ds)
 	if err != nil {
 		return nil, err
 	}
@@ -199,39 +201,37 @@ func GetMemberClusterInfo(ds *dataselect.DataSelectQuery) (*v1.MemberClusterStat
 
 // GetClusterResourceStatus returns the status of cluster resources.
 // 获取集群资源的状态
-func GetClusterResourceStatus() (*v1.ClusterResourceStatus, error) {
+func GetClusterResourceStatus(listers *informer.KarmadaListers, kubeClient kubeclient.Interface) (*v1.ClusterResourceStatus, error) {
 	clusterResourceStatus := &v1.ClusterResourceStatus{}
 	ctx := context.TODO()
-	karmadaClient := client.InClusterKarmadaClient()
 	// handle pp num
-	clusterPPRet, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().List(ctx, metav1.ListOptions{})
+	clusterPPRet, err := listers.ClusterPropagationPolicies.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
-	clusterResourceStatus.PropagationPolicyNum += len(clusterPPRet.Items)
+	clusterResourceStatus.PropagationPolicyNum += len(clusterPPRet)
 
-	ppRet, err := karmadaClient.PolicyV1alpha1().PropagationPolicies("").List(ctx, metav1.ListOptions{})
+	ppRet, err := listers.PropagationPolicies.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
-	clusterResourceStatus.PropagationPolicyNum += len(ppRet.Items)
+	clusterResourceStatus.PropagationPolicyNum += len(ppRet)
 
 	// handle op num
-	clusterOPRet, err := karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().List(ctx, metav1.ListOptions{})
+	clusterOPRet, err := listers.ClusterOverridePolicies.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
-	clusterResourceStatus.OverridePolicyNum += len(clusterOPRet.Items)
+	clusterResourceStatus.OverridePolicyNum += len(clusterOPRet)
 
-	opRet, err := karmadaClient.PolicyV1alpha1().OverridePolicies("").List(ctx, metav1.ListOptions{})
+	opRet, err := listers.OverridePolicies.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
-	clusterResourceStatus.OverridePolicyNum += len(opRet.Items)
+	clusterResourceStatus.OverridePolicyNum += len(opRet)
 
 	// handle cluster resources
 	// handler namespace num
-	kubeClient := client.InClusterClientForKarmadaAPIServer()
 	nsRet, err := kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
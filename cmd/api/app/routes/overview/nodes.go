@@ -20,23 +20,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"sync"
 
 	"github.com/gin-gonic/gin"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
 	apiV1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/types"
 	"github.com/karmada-io/dashboard/pkg/dataselect"
+	"github.com/karmada-io/dashboard/pkg/informer"
 )
 
 // GetNodeSummary 获取节点汇总信息
-func GetNodeSummary(dataSelect *dataselect.DataSelectQuery) (*apiV1.NodesResponse, error) {
+func GetNodeSummary(request *http.Request, dataSelect *dataselect.DataSelectQuery) (*apiV1.NodesResponse, error) {
 	// 初始化汇总结构
 	response := &apiV1.NodesResponse{
 		Items: []apiV1.NodeItem{},
@@ -46,11 +50,14 @@ func GetNodeSummary(dataSelect *dataselect.DataSelectQuery) (*apiV1.NodesRespons
 		},
 	}
 
-	// 获取Karmada客户端
-	karmadaClient := client.InClusterKarmadaClient()
+	// 获取缓存的 Lister
+	listers, err := informer.ListersForList(request, "", types.ResourceKindCluster)
+	if err != nil {
+		return nil, err
+	}
 
 	// 获取集群列表
-	clusterList, err := karmadaClient.ClusterV1alpha1().Clusters().List(context.TODO(), metav1.ListOptions{})
+	clusters, err := listers.Clusters.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get cluster list")
 		return nil, err
@@ -62,15 +69,15 @@ func GetNodeSummary(dataSelect *dataselect.DataSelectQuery) (*apiV1.NodesRespons
 	var mu sync.Mutex
 
 	// 遍历所有集群
-	for _, cluster := range clusterList.Items {
+	for _, cluster := range clusters {
 		wg.Add(1)
 		go func(clusterName string) {
 			defer wg.Done()
 
 			// 获取成员集群的客户端
-			memberClient := client.InClusterClientForMemberCluster(clusterName)
-			if memberClient == nil {
-				klog.Warningf("Failed to get client for cluster %s", clusterName)
+			memberClient, err := client.GetMemberClientFromRequest(request, clusterName)
+			if err != nil {
+				klog.ErrorS(err, "Failed to get client for cluster", "cluster", clusterName)
 				return
 			}
 
@@ -320,7 +327,7 @@ func getNodeStatus(node v1.Node) string {
 // HandleGetNodeSummary 处理获取节点汇总信息的请求
 func HandleGetNodeSummary(c *gin.Context) {
 	dataSelect := common.ParseDataSelectPathParameter(c)
-	summary, err := GetNodeSummary(dataSelect)
+	summary, err := GetNodeSummary(c.Request, dataSelect)
 	if err != nil {
 		klog.ErrorS(err, "Failed to get node summary")
 		common.Fail(c, err)
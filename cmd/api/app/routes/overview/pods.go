@@ -18,22 +18,26 @@ package overview
 
 import (
 	"context"
+	"net/http"
 	"sync"
 
 	"github.com/gin-gonic/gin"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
 	apiV1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/types"
 	"github.com/karmada-io/dashboard/pkg/dataselect"
+	"github.com/karmada-io/dashboard/pkg/informer"
 )
 
 // GetPodSummary 获取Pod汇总信息
-func GetPodSummary(dataSelect *dataselect.DataSelectQuery) (*apiV1.PodsResponse, error) {
+func GetPodSummary(request *http.Request, dataSelect *dataselect.DataSelectQuery) (*apiV1.PodsResponse, error) {
 	// 初始化汇总结构
 	response := &apiV1.PodsResponse{
 		Items:          []apiV1.PodItem{},
@@ -46,11 +50,14 @@ func GetPodSummary(dataSelect *dataselect.DataSelectQuery) (*apiV1.PodsResponse,
 	namespaceMap := make(map[string]int)
 	clusterMap := make(map[string]int)
 
-	// 获取Karmada客户端
-	karmadaClient := client.InClusterKarmadaClient()
+	// 获取缓存的 Lister
+	listers, err := informer.ListersForList(request, "", types.ResourceKindCluster)
+	if err != nil {
+		return nil, err
+	}
 
 	// 获取集群列表
-	clusterList, err := karmadaClient.ClusterV1alpha1().Clusters().List(context.TODO(), metav1.ListOptions{})
+	clusters, err := listers.Clusters.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get cluster list")
 		return nil, err
@@ -62,15 +69,15 @@ func GetPodSummary(dataSelect *dataselect.DataSelectQuery) (*apiV1.PodsResponse,
 	var mu sync.Mutex
 
 	// 遍历所有集群
-	for _, cluster := range clusterList.Items {
+	for _, cluster := range clusters {
 		wg.Add(1)
 		go func(clusterName string) {
 			defer wg.Done()
 
 			// 获取成员集群的客户端
-			memberClient := client.InClusterClientForMemberCluster(clusterName)
-			if memberClient == nil {
-				klog.Warningf("Failed to get client for cluster %s", clusterName)
+			memberClient, err := client.GetMemberClientFromRequest(request, clusterName)
+			if err != nil {
+				klog.ErrorS(err, "Failed to get client for cluster", "cluster", clusterName)
 				return
 			}
 
@@ -253,7 +260,7 @@ func getPodRestartCount(pod v1.Pod) int32 {
 // HandleGetPodSummary 处理获取Pod汇总信息的请求
 func HandleGetPodSummary(c *gin.Context) {
 	dataSelect := common.ParseDataSelectPathParameter(c)
-	summary, err := GetPodSummary(dataSelect)
+	summary, err := GetPodSummary(c.Request, dataSelect)
 	if err != nil {
 		klog.ErrorS(err, "Failed to get pod summary")
 		common.Fail(c, err)
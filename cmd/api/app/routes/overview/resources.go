@@ -17,34 +17,31 @@ limitations under the License.
 package overview
 
 import (
-	"context"
-
 	"github.com/gin-gonic/gin"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterlisters "github.com/karmada-io/karmada/pkg/generated/listers/cluster/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
 
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
-	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
 )
 
 // GetClusterResourcesSummary 获取所有集群的资源汇总信息
-func GetClusterResourcesSummary() (*v1.ResourcesSummary, error) {
+func GetClusterResourcesSummary(lister clusterlisters.ClusterLister) (*v1.ResourcesSummary, error) {
 	// 初始化汇总结构
 	summary := &v1.ResourcesSummary{}
 
-	// 获取Karmada客户端
-	karmadaClient := client.InClusterKarmadaClient()
-
-	// 直接获取集群列表，避免使用dataselect包
-	clusterList, err := karmadaClient.ClusterV1alpha1().Clusters().List(context.TODO(), metav1.ListOptions{})
+	// 直接从缓存获取集群列表，避免使用dataselect包
+	clusters, err := lister.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get cluster list")
 		return nil, err
 	}
 
 	// 遍历所有集群，累加资源数据
-	for _, cluster := range clusterList.Items {
+	for _, cluster := range clusters {
 		// 节点状态统计
 		if cluster.Status.NodeSummary != nil {
 			// 计算节点总数和就绪节点数
@@ -91,7 +88,12 @@ func GetClusterResourcesSummary() (*v1.ResourcesSummary, error) {
 
 // HandleGetResourcesSummary 处理获取资源汇总信息的请求
 func HandleGetResourcesSummary(c *gin.Context) {
-	summary, err := GetClusterResourcesSummary()
+	listers, err := informer.ListersForList(c.Request, "", types.ResourceKindCluster)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	summary, err := GetClusterResourcesSummary(listers.Clusters)
 	if err != nil {
 		klog.ErrorS(err, "Failed to get cluster resources summary")
 		common.Fail(c, err)
@@ -19,6 +19,7 @@ package overview
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sort"
 	"strconv"
 	"strings"
@@ -27,9 +28,8 @@ import (
 	"github.com/gin-gonic/gin"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 
 	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
@@ -37,8 +37,9 @@ import (
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
 	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
-	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
 )
 
 // 资源类型分组映射
@@ -139,9 +140,9 @@ func getResourceGroup(kind string) string {
 }
 
 // findPropagationPolicyForResource 查找与资源匹配的传播策略
-func findPropagationPolicyForResource(ctx context.Context, karmadaClient karmadaclientset.Interface, namespace, name, kind string) (string, map[string]int32, error) {
+func findPropagationPolicyForResource(listers *informer.KarmadaListers, namespace, name, kind string) (string, map[string]int32, error) {
 	// 获取所有PropagationPolicy
-	policyList, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	policyList, err := listers.PropagationPolicies.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get propagation policies")
 		return "", nil, err
@@ -151,7 +152,7 @@ func findPropagationPolicyForResource(ctx context.Context, karmadaClient karmada
 	clusterWeights := make(map[string]int32)
 
 	// 检查每个策略是否匹配该资源
-	for _, policy := range policyList.Items {
+	for _, policy := range policyList {
 		if policy.Namespace != namespace && namespace != "" {
 			continue
 		}
@@ -178,13 +179,13 @@ func findPropagationPolicyForResource(ctx context.Context, karmadaClient karmada
 	}
 
 	// 查找ClusterPropagationPolicy
-	clusterPolicyList, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().List(ctx, metav1.ListOptions{})
+	clusterPolicyList, err := listers.ClusterPropagationPolicies.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get cluster propagation policies")
 		return "", nil, err
 	}
 
-	for _, policy := range clusterPolicyList.Items {
+	for _, policy := range clusterPolicyList {
 		for _, rs := range policy.Spec.ResourceSelectors {
 			if rs.Kind == kind && (rs.Name == name || rs.Name == "") {
 				// 找到匹配的策略，获取集群权重
@@ -228,9 +229,14 @@ type ResourceSchedulingInfo struct {
 }
 
 // GetClusterSchedulePreview 获取集群调度预览信息
-func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
-	// 获取Karmada客户端
-	karmadaClient := client.InClusterKarmadaClient()
+func GetClusterSchedulePreview(request *http.Request) (*v1.SchedulePreviewResponse, error) {
+	// 获取缓存的 Lister
+	listers, err := informer.ListersForList(request, "", types.ResourceKindCluster,
+		types.ResourceKindResourceBinding, types.ResourceKindClusterResourceBinding,
+		types.ResourceKindPropagationPolicy, types.ResourceKindClusterPropagationPolicy)
+	if err != nil {
+		return nil, err
+	}
 	ctx := context.TODO()
 
 	// 初始化响应结构
@@ -247,14 +253,14 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 	}
 
 	// 获取所有集群
-	clusterList, err := karmadaClient.ClusterV1alpha1().Clusters().List(ctx, metav1.ListOptions{})
+	clusters, err := listers.Clusters.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get cluster list")
 		return nil, err
 	}
 
 	// 为每个集群创建节点
-	for _, cluster := range clusterList.Items {
+	for _, cluster := range clusters {
 		// 收集集群的调度参数
 		schedulingParams := &v1.SchedulingParams{
 			Labels: make(map[string]string),
@@ -311,13 +317,13 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 	}
 
 	// 获取资源绑定信息
-	resourceBindings, err := karmadaClient.WorkV1alpha2().ResourceBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	resourceBindings, err := listers.ResourceBindings.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get resource bindings")
 		return nil, err
 	}
 
-	clusterResourceBindings, err := karmadaClient.WorkV1alpha2().ClusterResourceBindings().List(ctx, metav1.ListOptions{})
+	clusterResourceBindings, err := listers.ClusterResourceBindings.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get cluster resource bindings")
 		return nil, err
@@ -334,7 +340,7 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 	resourceSchedulingMap := make(map[string]map[string]*ResourceSchedulingInfo)
 
 	// 收集各资源类型对应的资源名称
-	for _, binding := range resourceBindings.Items {
+	for _, binding := range resourceBindings {
 		resourceType := binding.Spec.Resource.Kind
 		resourceName := binding.Spec.Resource.Name
 		if resourceName != "" {
@@ -357,7 +363,7 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 	}
 
 	// 处理集群级资源
-	for _, binding := range clusterResourceBindings.Items {
+	for _, binding := range clusterResourceBindings {
 		resourceType := binding.Spec.Resource.Kind
 		resourceName := binding.Spec.Resource.Name
 		if resourceName != "" {
@@ -380,7 +386,7 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 	}
 
 	// 处理资源绑定 - 获取调度信息
-	for _, binding := range resourceBindings.Items {
+	for _, binding := range resourceBindings {
 		resourceKind := binding.Spec.Resource.Kind
 		resourceName := binding.Spec.Resource.Name
 		resourceNamespace := binding.Spec.Resource.Namespace
@@ -395,7 +401,7 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 		}
 
 		// 查找匹配的传播策略
-		policyName, clusterWeights, _ := findPropagationPolicyForResource(ctx, karmadaClient, resourceNamespace, resourceName, resourceKind)
+		policyName, clusterWeights, _ := findPropagationPolicyForResource(listers, resourceNamespace, resourceName, resourceKind)
 
 		// 资源唯一标识符
 		resourceKey := fmt.Sprintf("%s/%s/%s", resourceNamespace, resourceKind, resourceName)
@@ -461,7 +467,7 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 	}
 
 	// 处理集群资源绑定
-	for _, binding := range clusterResourceBindings.Items {
+	for _, binding := range clusterResourceBindings {
 		resourceKind := binding.Spec.Resource.Kind
 		resourceName := binding.Spec.Resource.Name
 
@@ -475,7 +481,7 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 		}
 
 		// 查找匹配的传播策略
-		policyName, clusterWeights, _ := findPropagationPolicyForResource(ctx, karmadaClient, "", resourceName, resourceKind)
+		policyName, clusterWeights, _ := findPropagationPolicyForResource(listers, "", resourceName, resourceKind)
 
 		// 资源唯一标识符 (集群级资源无命名空间)
 		resourceKey := fmt.Sprintf("/%s/%s", resourceKind, resourceName)
@@ -545,40 +551,15 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 	var wg sync.WaitGroup
 	var mu sync.Mutex // 保护map的并发访问
 
-	for i := range clusterList.Items {
-		cluster := &clusterList.Items[i]
+	for i := range clusters {
+		cluster := clusters[i]
 		wg.Add(1)
 
 		go func(c *clusterv1alpha1.Cluster) {
 			defer wg.Done()
 
-			// 使用现有的客户端函数获取成员集群客户端
-			kubeClient := client.InClusterClientForMemberCluster(c.Name)
-			if kubeClient == nil {
-				klog.ErrorS(fmt.Errorf("failed to get client"), "Could not get client for cluster", "cluster", c.Name)
-				return
-			}
-
-			// 创建动态客户端 - 通过设置相同的配置
-			config, err := client.GetMemberConfig()
-			if err != nil {
-				klog.ErrorS(err, "Failed to get member config", "cluster", c.Name)
-				return
-			}
-
-			// 修改配置以指向特定集群
-			restConfig := rest.CopyConfig(config)
-			// 获取karmada配置
-			karmadaConfig, _, err := client.GetKarmadaConfig()
-			if err != nil {
-				klog.ErrorS(err, "Failed to get karmada config", "cluster", c.Name)
-				return
-			}
-			// 使用固定的代理URL格式 - client包中定义的proxyURL常量为非导出
-			proxyURL := "/apis/cluster.karmada.io/v1alpha1/clusters/%s/proxy/"
-			restConfig.Host = karmadaConfig.Host + fmt.Sprintf(proxyURL, c.Name)
-
-			dynamicClient, err := dynamic.NewForConfig(restConfig)
+			// 使用请求中的令牌通过集群代理创建成员集群动态客户端
+			dynamicClient, err := client.GetMemberDynamicClientFromRequest(request, c.Name)
 			if err != nil {
 				klog.ErrorS(err, "Failed to create dynamic client", "cluster", c.Name)
 				return
@@ -657,7 +638,7 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 							// 额外验证：检查该资源是否在ResourceBinding或ClusterResourceBinding中存在
 							// 检查ResourceBinding
 							foundInResourceBindings := false
-							for _, binding := range resourceBindings.Items {
+							for _, binding := range resourceBindings {
 								if binding.Spec.Resource.Kind == "Deployment" &&
 									binding.Spec.Resource.Name == deployName &&
 									(binding.Namespace == deployNamespace || binding.Spec.Resource.Namespace == deployNamespace) {
@@ -668,7 +649,7 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 
 							// 检查ClusterResourceBinding
 							if !foundInResourceBindings {
-								for _, binding := range clusterResourceBindings.Items {
+								for _, binding := range clusterResourceBindings {
 									if binding.Spec.Resource.Kind == "Deployment" &&
 										binding.Spec.Resource.Name == deployName {
 										foundInResourceBindings = true
@@ -859,7 +840,7 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 							foundInResourceBindings := false
 
 							// 检查ResourceBinding
-							for _, binding := range resourceBindings.Items {
+							for _, binding := range resourceBindings {
 								if binding.Spec.Resource.Kind == resourceKind &&
 									binding.Spec.Resource.Name == resourceName &&
 									(binding.Namespace == resourceNamespace || binding.Spec.Resource.Namespace == resourceNamespace) {
@@ -870,7 +851,7 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 
 							// 检查ClusterResourceBinding
 							if !foundInResourceBindings {
-								for _, binding := range clusterResourceBindings.Items {
+								for _, binding := range clusterResourceBindings {
 									if binding.Spec.Resource.Kind == resourceKind &&
 										binding.Spec.Resource.Name == resourceName {
 										foundInResourceBindings = true
@@ -1122,13 +1103,13 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 	response.Nodes = append(response.Nodes, resourceNodes...)
 
 	// 获取传播策略
-	propagationPolicies, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	propagationPolicies, err := listers.PropagationPolicies.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get propagation policies")
 		return nil, err
 	}
 
-	clusterPropagationPolicies, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().List(ctx, metav1.ListOptions{})
+	clusterPropagationPolicies, err := listers.ClusterPropagationPolicies.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get cluster propagation policies")
 		return nil, err
@@ -1136,9 +1117,9 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 
 	// 将策略信息添加到响应中
 	response.Summary = v1.ScheduleSummary{
-		TotalClusters:          len(clusterList.Items),
-		TotalPropagationPolicy: len(propagationPolicies.Items) + len(clusterPropagationPolicies.Items),
-		TotalResourceBinding:   len(resourceBindings.Items) + len(clusterResourceBindings.Items),
+		TotalClusters:          len(clusters),
+		TotalPropagationPolicy: len(propagationPolicies) + len(clusterPropagationPolicies),
+		TotalResourceBinding:   len(resourceBindings) + len(clusterResourceBindings),
 	}
 
 	return response, nil
@@ -1146,7 +1127,7 @@ func GetClusterSchedulePreview() (*v1.SchedulePreviewResponse, error) {
 
 // HandleGetSchedulePreview 处理获取集群调度预览的请求
 func HandleGetSchedulePreview(c *gin.Context) {
-	preview, err := GetClusterSchedulePreview()
+	preview, err := GetClusterSchedulePreview(c.Request)
 	if err != nil {
 		klog.ErrorS(err, "Failed to get cluster schedule preview")
 		common.Fail(c, err)
@@ -1157,9 +1138,14 @@ func HandleGetSchedulePreview(c *gin.Context) {
 }
 
 // GetAllClusterResourcesPreview 获取所有集群资源预览信息，不局限于Karmada调度的资源
-func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
-	// 获取Karmada客户端
-	karmadaClient := client.InClusterKarmadaClient()
+func GetAllClusterResourcesPreview(request *http.Request) (*v1.SchedulePreviewResponse, error) {
+	// 获取缓存的 Lister
+	listers, err := informer.ListersForList(request, "", types.ResourceKindCluster,
+		types.ResourceKindResourceBinding, types.ResourceKindClusterResourceBinding,
+		types.ResourceKindPropagationPolicy, types.ResourceKindClusterPropagationPolicy)
+	if err != nil {
+		return nil, err
+	}
 	ctx := context.TODO()
 
 	// 初始化响应结构
@@ -1176,14 +1162,14 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 	}
 
 	// 获取所有集群
-	clusterList, err := karmadaClient.ClusterV1alpha1().Clusters().List(ctx, metav1.ListOptions{})
+	clusters, err := listers.Clusters.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get cluster list")
 		return nil, err
 	}
 
 	// 为每个集群创建节点
-	for _, cluster := range clusterList.Items {
+	for _, cluster := range clusters {
 		// 收集集群的调度参数
 		schedulingParams := &v1.SchedulingParams{
 			Labels: make(map[string]string),
@@ -1240,13 +1226,13 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 	}
 
 	// 获取资源绑定信息
-	resourceBindings, err := karmadaClient.WorkV1alpha2().ResourceBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	resourceBindings, err := listers.ResourceBindings.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get resource bindings")
 		return nil, err
 	}
 
-	clusterResourceBindings, err := karmadaClient.WorkV1alpha2().ClusterResourceBindings().List(ctx, metav1.ListOptions{})
+	clusterResourceBindings, err := listers.ClusterResourceBindings.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get cluster resource bindings")
 		return nil, err
@@ -1264,7 +1250,7 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 	resourceTypeToNameMap := make(map[string][]string)
 
 	// 收集各资源类型对应的资源名称
-	for _, binding := range resourceBindings.Items {
+	for _, binding := range resourceBindings {
 		resourceType := binding.Spec.Resource.Kind
 		resourceName := binding.Spec.Resource.Name
 		if resourceName != "" {
@@ -1287,7 +1273,7 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 	}
 
 	// 处理集群级资源
-	for _, binding := range clusterResourceBindings.Items {
+	for _, binding := range clusterResourceBindings {
 		resourceType := binding.Spec.Resource.Kind
 		resourceName := binding.Spec.Resource.Name
 		if resourceName != "" {
@@ -1310,7 +1296,7 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 	}
 
 	// 处理资源绑定 - 获取调度信息
-	for _, binding := range resourceBindings.Items {
+	for _, binding := range resourceBindings {
 		resourceKind := binding.Spec.Resource.Kind
 
 		// 将资源添加到类型统计
@@ -1336,7 +1322,7 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 	}
 
 	// 处理集群资源绑定 - 获取调度信息
-	for _, binding := range clusterResourceBindings.Items {
+	for _, binding := range clusterResourceBindings {
 		resourceKind := binding.Spec.Resource.Kind
 
 		// 将资源添加到类型统计
@@ -1366,40 +1352,15 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 	var wg sync.WaitGroup
 	var mu sync.Mutex // 保护map的并发访问
 
-	for i := range clusterList.Items {
-		cluster := &clusterList.Items[i]
+	for i := range clusters {
+		cluster := clusters[i]
 		wg.Add(1)
 
 		go func(c *clusterv1alpha1.Cluster) {
 			defer wg.Done()
 
-			// 使用现有的客户端函数获取成员集群客户端
-			kubeClient := client.InClusterClientForMemberCluster(c.Name)
-			if kubeClient == nil {
-				klog.ErrorS(fmt.Errorf("failed to get client"), "Could not get client for cluster", "cluster", c.Name)
-				return
-			}
-
-			// 创建动态客户端 - 通过设置相同的配置
-			config, err := client.GetMemberConfig()
-			if err != nil {
-				klog.ErrorS(err, "Failed to get member config", "cluster", c.Name)
-				return
-			}
-
-			// 修改配置以指向特定集群
-			restConfig := rest.CopyConfig(config)
-			// 获取karmada配置
-			karmadaConfig, _, err := client.GetKarmadaConfig()
-			if err != nil {
-				klog.ErrorS(err, "Failed to get karmada config", "cluster", c.Name)
-				return
-			}
-			// 使用固定的代理URL格式 - client包中定义的proxyURL常量为非导出
-			proxyURL := "/apis/cluster.karmada.io/v1alpha1/clusters/%s/proxy/"
-			restConfig.Host = karmadaConfig.Host + fmt.Sprintf(proxyURL, c.Name)
-
-			dynamicClient, err := dynamic.NewForConfig(restConfig)
+			// 使用请求中的令牌通过集群代理创建成员集群动态客户端
+			dynamicClient, err := client.GetMemberDynamicClientFromRequest(request, c.Name)
 			if err != nil {
 				klog.ErrorS(err, "Failed to create dynamic client", "cluster", c.Name)
 				return
@@ -1478,7 +1439,7 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 							// 额外验证：检查该资源是否在ResourceBinding或ClusterResourceBinding中存在
 							// 检查ResourceBinding
 							foundInResourceBindings := false
-							for _, binding := range resourceBindings.Items {
+							for _, binding := range resourceBindings {
 								if binding.Spec.Resource.Kind == "Deployment" &&
 									binding.Spec.Resource.Name == deployName &&
 									(binding.Namespace == deployNamespace || binding.Spec.Resource.Namespace == deployNamespace) {
@@ -1489,7 +1450,7 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 
 							// 检查ClusterResourceBinding
 							if !foundInResourceBindings {
-								for _, binding := range clusterResourceBindings.Items {
+								for _, binding := range clusterResourceBindings {
 									if binding.Spec.Resource.Kind == "Deployment" &&
 										binding.Spec.Resource.Name == deployName {
 										foundInResourceBindings = true
@@ -1680,7 +1641,7 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 							foundInResourceBindings := false
 
 							// 检查ResourceBinding
-							for _, binding := range resourceBindings.Items {
+							for _, binding := range resourceBindings {
 								if binding.Spec.Resource.Kind == resourceKind &&
 									binding.Spec.Resource.Name == resourceName &&
 									(binding.Namespace == resourceNamespace || binding.Spec.Resource.Namespace == resourceNamespace) {
@@ -1691,7 +1652,7 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 
 							// 检查ClusterResourceBinding
 							if !foundInResourceBindings {
-								for _, binding := range clusterResourceBindings.Items {
+								for _, binding := range clusterResourceBindings {
 									if binding.Spec.Resource.Kind == resourceKind &&
 										binding.Spec.Resource.Name == resourceName {
 										foundInResourceBindings = true
@@ -1894,13 +1855,13 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 	response.ActualResourceDist = actualResourceDist
 
 	// 获取传播策略
-	propagationPolicies, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	propagationPolicies, err := listers.PropagationPolicies.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get propagation policies")
 		return nil, err
 	}
 
-	clusterPropagationPolicies, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().List(ctx, metav1.ListOptions{})
+	clusterPropagationPolicies, err := listers.ClusterPropagationPolicies.List(labels.Everything())
 	if err != nil {
 		klog.ErrorS(err, "Failed to get cluster propagation policies")
 		return nil, err
@@ -1908,9 +1869,9 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 
 	// 将策略信息添加到响应中
 	response.Summary = v1.ScheduleSummary{
-		TotalClusters:          len(clusterList.Items),
-		TotalPropagationPolicy: len(propagationPolicies.Items) + len(clusterPropagationPolicies.Items),
-		TotalResourceBinding:   len(resourceBindings.Items) + len(clusterResourceBindings.Items),
+		TotalClusters:          len(clusters),
+		TotalPropagationPolicy: len(propagationPolicies) + len(clusterPropagationPolicies),
+		TotalResourceBinding:   len(resourceBindings) + len(clusterResourceBindings),
 	}
 
 	return response, nil
@@ -1918,7 +1879,7 @@ func GetAllClusterResourcesPreview() (*v1.SchedulePreviewResponse, error) {
 
 // HandleGetAllClusterResourcesPreview 处理获取所有集群资源预览的请求
 func HandleGetAllClusterResourcesPreview(c *gin.Context) {
-	preview, err := GetAllClusterResourcesPreview()
+	preview, err := GetAllClusterResourcesPreview(c.Request)
 	if err != nil {
 		klog.ErrorS(err, "Failed to get all cluster resources preview")
 		common.Fail(c, err)
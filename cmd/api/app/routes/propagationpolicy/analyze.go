@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
+	"github.com/karmada-io/dashboard/pkg/resource/overridepolicy"
+	"github.com/karmada-io/dashboard/pkg/resource/propagationpolicy"
+)
+
+// 分析所有传播策略的冲突、未选中资源的策略以及覆盖相同字段的覆盖策略
+func handleGetPropagationPolicyAnalysis(c *gin.Context) {
+	listers, err := informer.ListersForList(c.Request, "",
+		types.ResourceKindPropagationPolicy, types.ResourceKindClusterPropagationPolicy,
+		types.ResourceKindOverridePolicy, types.ResourceKindClusterOverridePolicy, types.ResourceKindCluster)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	policies, err := existingPolicies(listers, "")
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	overridePolicies, err := existingOverridePolicies(listers)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	clusters, err := listers.Clusters.List(labels.Everything())
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	mapper, err := client.GetRESTMapperFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := propagationpolicy.Analyze(c.Request.Context(), dynamicClient, mapper, policies, overridePolicies, clusters)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// existingOverridePolicies 返回所有 OverridePolicy 和 ClusterOverridePolicy
+func existingOverridePolicies(listers *informer.KarmadaListers) (*overridepolicy.Policies, error) {
+	ops, err := listers.OverridePolicies.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	cops, err := listers.ClusterOverridePolicies.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return &overridepolicy.Policies{OverridePolicies: ops, ClusterOverridePolicies: cops}, nil
+}
@@ -22,25 +22,33 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
-	"sigs.k8s.io/yaml"
 
 	"github.com/karmada-io/dashboard/cmd/api/app/router"
 	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
 	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
 	"github.com/karmada-io/dashboard/pkg/client"
 	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
 	"github.com/karmada-io/dashboard/pkg/resource/propagationpolicy"
 )
 
 // 获取传播策略列表
 func handleGetPropagationPolicyList(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
 	dataSelect := common.ParseDataSelectPathParameter(c)
 	namespace := common.ParseNamespacePathParameter(c)
-	k8sClient := client.InClusterClientForKarmadaAPIServer()
-	propagationList, err := propagationpolicy.GetPropagationPolicyList(karmadaClient, k8sClient, namespace, dataSelect)
+	listers, err := informer.ListersForList(c.Request, namespace.ToRequestParam(), types.ResourceKindPropagationPolicy)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	verber, err := client.VerberClient(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	propagationList, err := propagationpolicy.GetPropagationPolicyList(listers.PropagationPolicies, verber, namespace, dataSelect)
 	if err != nil {
 		klog.ErrorS(err, "Failed to GetPropagationPolicyList")
 		common.Fail(c, err)
@@ -51,10 +59,14 @@ func handleGetPropagationPolicyList(c *gin.Context) {
 
 // 获取传播策略详情
 func handleGetPropagationPolicyDetail(c *gin.Context) {
-	karmadaClient := client.InClusterKarmadaClient()
 	namespace := c.Param("namespace")
 	name := c.Param("propagationPolicyName")
-	result, err := propagationpolicy.GetPropagationPolicyDetail(karmadaClient, namespace, name)
+	listers, err := informer.ListersForGet(c.Request, types.ResourceKindPropagationPolicy, namespace, name)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := propagationpolicy.GetPropagationPolicyDetail(listers.PropagationPolicies, namespace, name)
 	if err != nil {
 		klog.ErrorS(err, "GetPropagationPolicyDetail failed")
 		common.Fail(c, err)
@@ -63,37 +75,48 @@ func handleGetPropagationPolicyDetail(c *gin.Context) {
 	common.Success(c, result)
 }
 
-// 创建传播策略
+// 创建传播策略，请求可以是 YAML 或结构化的 spec
 func handlePostPropagationPolicy(c *gin.Context) {
 	// todo precheck existence of namespace, now we tested it under scope of default, it's ok till now.
 	ctx := context.Context(c)
 	propagationpolicyRequest := new(v1.PostPropagationPolicyRequest)
 	if err := c.ShouldBind(&propagationpolicyRequest); err != nil {
-		common.Fail(c, err)
+		common.Fail(c, errors.NewBindingError(err))
 		return
 	}
 	if propagationpolicyRequest.Namespace == "" {
 		propagationpolicyRequest.Namespace = "default"
 	}
+	data := &propagationpolicy.PolicyData{
+		YAML:        propagationpolicyRequest.PropagationData,
+		Name:        propagationpolicyRequest.Name,
+		Namespace:   propagationpolicyRequest.Namespace,
+		Labels:      propagationpolicyRequest.Labels,
+		Annotations: propagationpolicyRequest.Annotations,
+		Spec:        propagationpolicyRequest.Spec,
+	}
 
-	var err error
-	karmadaClient := client.InClusterKarmadaClient()
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
 	if propagationpolicyRequest.IsClusterScope {
-		clusterpropagationPolicy := v1alpha1.ClusterPropagationPolicy{}
-		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &clusterpropagationPolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal ClusterPropagationPolicy")
+		var clusterpropagationPolicy *v1alpha1.ClusterPropagationPolicy
+		if clusterpropagationPolicy, err = data.ClusterPropagationPolicy(); err != nil {
+			klog.ErrorS(err, "Invalid ClusterPropagationPolicy")
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Create(ctx, &clusterpropagationPolicy, metav1.CreateOptions{})
+		_, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Create(ctx, clusterpropagationPolicy, metav1.CreateOptions{})
 	} else {
-		propagationPolicy := v1alpha1.PropagationPolicy{}
-		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &propagationPolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal PropagationPolicy")
+		var propagationPolicy *v1alpha1.PropagationPolicy
+		if propagationPolicy, err = data.PropagationPolicy(); err != nil {
+			klog.ErrorS(err, "Invalid PropagationPolicy")
 			common.Fail(c, err)
 			return
 		}
-		_, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Create(ctx, &propagationPolicy, metav1.CreateOptions{})
+		_, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Create(ctx, propagationPolicy, metav1.CreateOptions{})
 	}
 	if err != nil {
 		klog.ErrorS(err, "Failed to create PropagationPolicy")
@@ -103,39 +126,42 @@ func handlePostPropagationPolicy(c *gin.Context) {
 	common.Success(c, "ok")
 }
 
-// 更新传播策略
+// 更新传播策略，请求可以是 YAML 或结构化的 spec
 func handlePutPropagationPolicy(c *gin.Context) {
 	ctx := context.Context(c)
 	propagationpolicyRequest := new(v1.PutPropagationPolicyRequest)
 	if err := c.ShouldBind(&propagationpolicyRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	data := &propagationpolicy.PolicyData{
+		YAML:        propagationpolicyRequest.PropagationData,
+		Labels:      propagationpolicyRequest.Labels,
+		Annotations: propagationpolicyRequest.Annotations,
+		Spec:        propagationpolicyRequest.Spec,
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
 		common.Fail(c, err)
 		return
 	}
-	var err error
-	karmadaClient := client.InClusterKarmadaClient()
-	// todo check pp exist
 	if propagationpolicyRequest.IsClusterScope {
-		clusterpropagationPolicy := v1alpha1.ClusterPropagationPolicy{}
-		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &clusterpropagationPolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal ClusterPropagationPolicy")
-			common.Fail(c, err)
-			return
+		var oldClusterPropagationPolicy, clusterpropagationPolicy *v1alpha1.ClusterPropagationPolicy
+		oldClusterPropagationPolicy, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Get(ctx, propagationpolicyRequest.Name, metav1.GetOptions{})
+		if err == nil {
+			clusterpropagationPolicy, err = data.UpdateClusterPropagationPolicy(oldClusterPropagationPolicy)
 		}
-		_, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Update(ctx, &clusterpropagationPolicy, metav1.UpdateOptions{})
-	} else {
-		propagationPolicy := v1alpha1.PropagationPolicy{}
-		if err = yaml.Unmarshal([]byte(propagationpolicyRequest.PropagationData), &propagationPolicy); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal PropagationPolicy")
-			common.Fail(c, err)
-			return
+		if err == nil {
+			_, err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Update(ctx, clusterpropagationPolicy, metav1.UpdateOptions{})
 		}
-		var oldPropagationPolicy *v1alpha1.PropagationPolicy
+	} else {
+		var oldPropagationPolicy, propagationPolicy *v1alpha1.PropagationPolicy
 		oldPropagationPolicy, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Get(ctx, propagationpolicyRequest.Name, metav1.GetOptions{})
 		if err == nil {
-			// only spec can be updated
-			propagationPolicy.TypeMeta = oldPropagationPolicy.TypeMeta
-			propagationPolicy.ObjectMeta = oldPropagationPolicy.ObjectMeta
-			_, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Update(ctx, &propagationPolicy, metav1.UpdateOptions{})
+			propagationPolicy, err = data.UpdatePropagationPolicy(oldPropagationPolicy)
+		}
+		if err == nil {
+			_, err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Update(ctx, propagationPolicy, metav1.UpdateOptions{})
 		}
 	}
 	if err != nil {
@@ -146,42 +172,32 @@ func handlePutPropagationPolicy(c *gin.Context) {
 	common.Success(c, "ok")
 }
 
-// 删除传播策略
+// 删除传播策略，请求参数 wait=true 时等待绑定释放
 func handleDeletePropagationPolicy(c *gin.Context) {
 	ctx := context.Context(c)
 	propagationpolicyRequest := new(v1.DeletePropagationPolicyRequest)
 	if err := c.ShouldBind(&propagationpolicyRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	karmadaClient, err := client.GetKarmadaClientFromRequest(c.Request)
+	if err != nil {
 		common.Fail(c, err)
 		return
 	}
-	var err error
-	karmadaClient := client.InClusterKarmadaClient()
+	wait := c.Query("wait") == "true"
+	var result *propagationpolicy.DeleteResult
 	if propagationpolicyRequest.IsClusterScope {
-		err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Delete(ctx, propagationpolicyRequest.Name, metav1.DeleteOptions{})
-		if err != nil {
-			klog.ErrorS(err, "Failed to delete PropagationPolicy")
-			common.Fail(c, err)
-			return
-		}
+		result, err = propagationpolicy.DeleteClusterPropagationPolicy(ctx, karmadaClient, propagationpolicyRequest.Name, "", wait)
 	} else {
-		err = karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Delete(ctx, propagationpolicyRequest.Name, metav1.DeleteOptions{})
-		if err != nil {
-			klog.ErrorS(err, "Failed to delete PropagationPolicy")
-			common.Fail(c, err)
-			return
-		}
-		_ = retry.OnError(
-			retry.DefaultRetry,
-			func(err error) bool {
-				return errors.IsNotFound(err)
-			},
-			func() error {
-				_, getErr := karmadaClient.PolicyV1alpha1().PropagationPolicies(propagationpolicyRequest.Namespace).Get(ctx, propagationpolicyRequest.Name, metav1.GetOptions{})
-				return getErr
-			})
+		result, err = propagationpolicy.DeletePropagationPolicy(ctx, karmadaClient, propagationpolicyRequest.Namespace, propagationpolicyRequest.Name, "", wait)
 	}
-
-	common.Success(c, "ok")
+	if err != nil {
+		klog.ErrorS(err, "Failed to delete PropagationPolicy")
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
 }
 
 // 初始化路由
@@ -193,6 +209,10 @@ func init() {
 	r.GET("/propagationpolicy/namespace/:namespace/:propagationPolicyName", handleGetPropagationPolicyDetail)
 	// 创建传播策略
 	r.POST("/propagationpolicy", handlePostPropagationPolicy)
+	// 在保存前模拟传播策略
+	r.POST("/propagationpolicy/simulate", handlePostPropagationPolicySimulate)
+	// 分析传播策略和覆盖策略的冲突与重叠
+	r.GET("/propagationpolicy/analysis", handleGetPropagationPolicyAnalysis)
 	// 更新传播策略
 	r.PUT("/propagationpolicy", handlePutPropagationPolicy)
 	// 删除传播策略
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/karmada-io/dashboard/cmd/api/app/types/api/v1"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/informer"
+	"github.com/karmada-io/dashboard/pkg/resource/propagationpolicy"
+)
+
+// 在保存前模拟传播策略选中的资源和调度策略选择的集群
+func handlePostPropagationPolicySimulate(c *gin.Context) {
+	simulateRequest := new(v1.SimulatePropagationPolicyRequest)
+	if err := c.ShouldBind(simulateRequest); err != nil {
+		common.Fail(c, errors.NewBindingError(err))
+		return
+	}
+	policy, err := parseSimulatedPolicy(simulateRequest)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	// ClusterPropagationPolicy 的 namespace 为空，可能与所有命名空间中的 PropagationPolicy 竞争
+	namespace := policy.Namespace
+	if _, err = informer.ListersForList(c.Request, namespace, types.ResourceKindPropagationPolicy); err != nil {
+		common.Fail(c, err)
+		return
+	}
+	listers, err := informer.ListersForList(c.Request, "", types.ResourceKindClusterPropagationPolicy, types.ResourceKindCluster)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	policies, err := existingPolicies(listers, namespace)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	clusters, err := listers.Clusters.List(labels.Everything())
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	mapper, err := client.GetRESTMapperFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	result, err := propagationpolicy.Simulate(c.Request.Context(), dynamicClient, mapper, policy, policies, clusters)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	common.Success(c, result)
+}
+
+// parseSimulatedPolicy 解析请求中的传播策略
+func parseSimulatedPolicy(simulateRequest *v1.SimulatePropagationPolicyRequest) (*propagationpolicy.Policy, error) {
+	if simulateRequest.IsClusterScope {
+		policy := &v1alpha1.ClusterPropagationPolicy{}
+		if err := yaml.Unmarshal([]byte(simulateRequest.PropagationData), policy); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid ClusterPropagationPolicy: %v", err))
+		}
+		return propagationpolicy.FromClusterPropagationPolicy(policy), nil
+	}
+	policy := &v1alpha1.PropagationPolicy{}
+	if err := yaml.Unmarshal([]byte(simulateRequest.PropagationData), policy); err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid PropagationPolicy: %v", err))
+	}
+	if simulateRequest.Namespace != "" {
+		policy.Namespace = simulateRequest.Namespace
+	}
+	if policy.Namespace == "" {
+		policy.Namespace = "default"
+	}
+	return propagationpolicy.FromPropagationPolicy(policy), nil
+}
+
+// existingPolicies 返回命名空间中的 PropagationPolicy 和所有 ClusterPropagationPolicy，namespace 为空时返回所有
+// PropagationPolicy
+func existingPolicies(listers *informer.KarmadaListers, namespace string) ([]*propagationpolicy.Policy, error) {
+	pps, err := listers.PropagationPolicies.PropagationPolicies(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	cpps, err := listers.ClusterPropagationPolicies.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]*propagationpolicy.Policy, 0, len(pps)+len(cpps))
+	for _, pp := range pps {
+		policies = append(policies, propagationpolicy.FromPropagationPolicy(pp))
+	}
+	for _, cpp := range cpps {
+		policies = append(policies, propagationpolicy.FromClusterPropagationPolicy(cpp))
+	}
+	return policies, nil
+}
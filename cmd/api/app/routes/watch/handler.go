@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/cmd/api/app/router"
+	"github.com/karmada-io/dashboard/cmd/api/app/types/common"
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	resourcecommon "github.com/karmada-io/dashboard/pkg/resource/common"
+	"github.com/karmada-io/dashboard/pkg/watch"
+)
+
+const (
+	// minHeartbeatInterval 是允许的最小心跳间隔
+	minHeartbeatInterval = time.Second
+	// writeTimeout 是写入单个事件的超时时间
+	writeTimeout = 10 * time.Second
+)
+
+// upgrader 将 HTTP 请求升级为 WebSocket 连接
+var upgrader = websocket.Upgrader{
+	Subprotocols: []string{router.WatchSubprotocol},
+	// 认证使用请求携带的令牌而非 Cookie，因此允许跨域连接，与 CorsMiddleware 保持一致
+	CheckOrigin: func(_ *http.Request) bool { return true },
+}
+
+// 监听 Karmada 控制面中的资源
+func handleWatch(c *gin.Context) {
+	dynamicClient, err := client.GetDynamicClientFromRequest(c.Request)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	serveWatch(c, dynamicClient)
+}
+
+// 通过 Karmada 集群代理监听成员集群中的资源
+func handleMemberWatch(c *gin.Context) {
+	dynamicClient, err := client.GetMemberDynamicClientFromRequest(c.Request, c.Param("clustername"))
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	serveWatch(c, dynamicClient)
+}
+
+// serveWatch 解析监听参数，并根据请求使用 WebSocket 或 SSE 发送事件
+func serveWatch(c *gin.Context, dynamicClient dynamic.Interface) {
+	kind := types.ResourceKind(c.Param("kind"))
+	gvr, ok := kind.GroupVersionResource()
+	if !ok {
+		common.Fail(c, errors.NewBadRequest(fmt.Sprintf("unsupported resource kind %q", kind)))
+		return
+	}
+	opts, err := parseOptions(c, kind)
+	if err != nil {
+		common.Fail(c, err)
+		return
+	}
+	resource := dynamicClient.Resource(gvr)
+	if websocket.IsWebSocketUpgrade(c.Request) {
+		serveWebSocket(c, resource, opts)
+		return
+	}
+	serveSSE(c, resource, opts)
+}
+
+// parseOptions 解析监听选项，集群级别的资源忽略命名空间参数
+func parseOptions(c *gin.Context, kind types.ResourceKind) (watch.Options, error) {
+	opts := watch.Options{
+		Namespace:       resourcecommon.NewNamespaceQuery(nil),
+		DataSelect:      common.ParseDataSelectPathParameter(c),
+		LabelSelector:   c.Query("labelSelector"),
+		ResourceVersion: c.Query("resourceVersion"),
+	}
+	if kind.Namespaced() {
+		opts.Namespace = common.ParseNamespacePathParameter(c)
+	}
+	// EventSource 断线重连时通过 Last-Event-ID 请求头携带最后收到的事件 ID，即 resourceVersion
+	if opts.ResourceVersion == "" {
+		opts.ResourceVersion = c.GetHeader("Last-Event-ID")
+	}
+	if heartbeat := c.Query("heartbeat"); heartbeat != "" {
+		interval, err := time.ParseDuration(heartbeat)
+		if err != nil {
+			return opts, errors.NewBadRequest(fmt.Sprintf("invalid heartbeat %q: %v", heartbeat, err))
+		}
+		if interval < minHeartbeatInterval {
+			interval = minHeartbeatInterval
+		}
+		opts.Heartbeat = interval
+	}
+	return opts, nil
+}
+
+// serveSSE 以 Server-Sent Events 的形式发送事件，事件 ID 为 resourceVersion
+func serveSSE(c *gin.Context, resource dynamic.NamespaceableResourceInterface, opts watch.Options) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// 禁止反向代理缓冲事件
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	err := watch.Run(ctx, resource, opts, func(event *watch.Event) error {
+		if err := sse.Encode(c.Writer, sse.Event{Id: event.ResourceVersion, Data: event}); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return ctx.Err()
+	})
+	if err != nil && ctx.Err() == nil {
+		klog.V(4).InfoS("Watch stream ended", "err", err)
+	}
+}
+
+// serveWebSocket 以 WebSocket 文本消息的形式发送事件
+func serveWebSocket(c *gin.Context, resource dynamic.NamespaceableResourceInterface, opts watch.Options) {
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade 已经向客户端返回了错误
+		klog.V(4).InfoS("Failed to upgrade watch request", "err", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	// 读取客户端消息以处理控制帧，连接关闭时结束监听
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	err = watch.Run(ctx, resource, opts, func(event *watch.Event) error {
+		if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+			return err
+		}
+		return conn.WriteJSON(event)
+	})
+	closeCode, closeText := websocket.CloseNormalClosure, ""
+	if err != nil {
+		// 错误详情已经通过 ERROR 事件发送，关闭帧只携带简短的原因
+		closeCode, closeText = websocket.CloseInternalServerErr, "watch failed"
+		klog.V(4).InfoS("Watch stream ended", "err", err)
+	}
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, closeText), time.Now().Add(writeTimeout))
+}
+
+// 初始化路由
+func init() {
+	r := router.V1()
+	r.GET("/watch/:kind", handleWatch)
+	r.GET("/watch/:kind/:namespace", handleWatch)
+
+	mr := router.MemberV1()
+	mr.GET("/watch/:kind", handleMemberWatch)
+	mr.GET("/watch/:kind/:namespace", handleMemberWatch)
+}
@@ -19,6 +19,9 @@ package v1
 import (
 	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/karmada-io/dashboard/pkg/history"
 )
 
 // PostClusterRequest is the request body for creating a cluster.
@@ -40,6 +43,36 @@ type PostClusterRequest struct {
 	ClusterRegion           string                   `json:"clusterRegion"`
 	// ClusterZones 是集群区域
 	ClusterZones            []string                 `json:"clusterZones"`
+	// Agent 是拉取模式下 karmada-agent 的部署选项
+	Agent                   *AgentOptions            `json:"agent"`
+}
+
+// AgentOptions defines how karmada-agent is deployed to a member cluster that joins in pull mode. Unset fields are
+// defaulted from the dashboard configuration and the Karmada control plane.
+// AgentOptions 是拉取模式下 karmada-agent 的部署选项，未设置的字段使用 dashboard 配置和 Karmada 控制面的默认值
+type AgentOptions struct {
+	// Image 是完整的镜像地址，设置后忽略 Registry、RegistryName 和 Version
+	Image string `json:"image"`
+	// Registry 是镜像仓库地址，例如 registry.example.com/karmada
+	Registry string `json:"registry"`
+	// RegistryName 是 dashboard 配置中 Docker 镜像仓库的名称，仓库配置了用户名和密码时会在成员集群中创建拉取镜像的 Secret
+	RegistryName string `json:"registryName"`
+	// Version 是镜像的版本，默认与 Karmada 控制面的版本一致
+	Version string `json:"version"`
+	// ImagePullSecrets 是成员集群中已存在的拉取镜像的 Secret
+	ImagePullSecrets []string `json:"imagePullSecrets"`
+	// Replicas 是副本数，默认为 2
+	Replicas *int32 `json:"replicas"`
+	// Resources 是容器的资源请求和限制
+	Resources corev1.ResourceRequirements `json:"resources"`
+	// Tolerations 是 Pod 的容忍度，默认容忍控制面节点的污点
+	Tolerations []corev1.Toleration `json:"tolerations"`
+	// NodeSelector 是 Pod 的节点选择器
+	NodeSelector map[string]string `json:"nodeSelector"`
+	// FeatureGates 是 karmada-agent 的特性开关
+	FeatureGates map[string]bool `json:"featureGates"`
+	// ExtraArgs 是追加到 karmada-agent 命令行的参数，例如 --v=4
+	ExtraArgs []string `json:"extraArgs"`
 }
 
 // PostClusterResponse is the response body for creating a cluster.
@@ -74,6 +107,20 @@ type PutClusterRequest struct {
 	Labels *[]LabelRequest `json:"labels"`
 	// Taints 是污点
 	Taints *[]TaintRequest `json:"taints"`
+	// Annotations 是要修改的注解，合并到集群现有的注解中，值为 null 的注解会被删除
+	Annotations map[string]*string `json:"annotations"`
+	// Provider 是集群的云厂商
+	Provider *string `json:"provider"`
+	// Region 是集群所在的地域
+	Region *string `json:"region"`
+	// Zones 是集群所在的可用区
+	Zones *[]string `json:"zones"`
+	// ProxyURL 是访问成员集群使用的代理地址，支持 http、https 和 socks5，为空字符串时不使用代理
+	ProxyURL *string `json:"proxyURL"`
+	// InsecureSkipTLSVerification 为 true 时访问成员集群不校验服务端证书
+	InsecureSkipTLSVerification *bool `json:"insecureSkipTLSVerification"`
+	// ResourceModels 是集群的资源模型，用于按资源档位统计可分配的资源
+	ResourceModels *[]v1alpha1.ResourceModel `json:"resourceModels"`
 }
 
 // PutClusterResponse is the response body for updating a cluster.
@@ -85,9 +132,117 @@ type PutClusterResponse struct{}
 type DeleteClusterRequest struct {
 	// MemberClusterName 是成员集群的名称
 	MemberClusterName string `uri:"name" binding:"required"`
+	// MemberClusterKubeConfig 是成员集群的 kubeconfig，提供时同时删除接入时在成员集群中创建的对象
+	MemberClusterKubeConfig string `json:"memberClusterKubeconfig"`
+	// MemberClusterNamespace 是拉取模式下 karmada-agent 所在的命名空间，默认为 karmada-system
+	MemberClusterNamespace string `json:"memberClusterNamespace"`
+	// Force 为 true 时，成员集群无法访问或清理失败也继续移除集群，失败的清理作为警告返回
+	Force bool `json:"force"`
 }
 
 // DeleteClusterResponse is the response body for deleting a cluster.
 // DeleteClusterResponse 是删除集群的响应
 type DeleteClusterResponse struct {
+	// Removed 是被删除的对象，例如 Secret karmada-cluster/member1
+	Removed []string `json:"removed"`
+	// Warnings 是强制移除时被跳过或失败的清理
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// PreflightStatus is the result of one preflight check of a cluster join.
+// PreflightStatus 是集群接入预检的单项检查结果
+type PreflightStatus string
+
+const (
+	// PreflightPass means the check passed.
+	PreflightPass PreflightStatus = "pass"
+	// PreflightWarn means the join may work but needs attention.
+	PreflightWarn PreflightStatus = "warn"
+	// PreflightFail means the join would fail.
+	PreflightFail PreflightStatus = "fail"
+)
+
+// PreflightCheck is one item of the preflight checklist.
+// PreflightCheck 是预检清单中的一项检查
+type PreflightCheck struct {
+	// Name 是检查项的名称，例如 kubeconfig、reachability
+	Name string `json:"name"`
+	// Status 是检查结果
+	Status PreflightStatus `json:"status"`
+	// Message 是检查结果的说明
+	Message string `json:"message"`
+}
+
+// PreflightResponse is the response body of a cluster join preflight.
+// PreflightResponse 是集群接入预检的响应
+type PreflightResponse struct {
+	// Status 是所有检查项中最差的结果
+	Status PreflightStatus `json:"status"`
+	// Checks 是按执行顺序排列的检查项
+	Checks []PreflightCheck `json:"checks"`
+}
+
+// CredentialRotationMode is how the new credentials of a push mode cluster are obtained.
+// CredentialRotationMode 是推送模式集群获取新凭据的方式
+type CredentialRotationMode string
+
+const (
+	// CredentialRotationKubeconfig uses the token of the given kubeconfig as the credentials of Karmada.
+	CredentialRotationKubeconfig CredentialRotationMode = "kubeconfig"
+	// CredentialRotationRemint mints new tokens for the ServiceAccounts that Karmada created in the member cluster.
+	CredentialRotationRemint CredentialRotationMode = "remint"
+)
+
+// RotateClusterCredentialsRequest is the request body for rotating the credentials of a push mode cluster.
+// RotateClusterCredentialsRequest 是轮换推送模式集群凭据的请求
+type RotateClusterCredentialsRequest struct {
+	// Mode 是获取新凭据的方式
+	Mode CredentialRotationMode `json:"mode" binding:"required"`
+	// MemberClusterKubeConfig 是成员集群的 kubeconfig，kubeconfig 模式下必填；remint 模式下为空时使用当前保存的凭据访问成员集群
+	MemberClusterKubeConfig string `json:"memberClusterKubeconfig"`
+	// ExpirationSeconds 是新签发的 ServiceAccount 令牌的有效期，默认为一年
+	ExpirationSeconds *int64 `json:"expirationSeconds"`
+}
+
+// ClusterCredential describes a token that Karmada uses to access a push mode cluster.
+// ClusterCredential 描述 Karmada 访问推送模式集群使用的令牌
+type ClusterCredential struct {
+	// Secret 是控制面中保存令牌的 Secret，例如 karmada-cluster/member1
+	Secret string `json:"secret"`
+	// ServiceAccount 是令牌所属的成员集群 ServiceAccount，令牌不是 ServiceAccount 令牌时为空
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Expires 是令牌的过期时间，令牌不会过期或无法解析时为空
+	Expires *metav1.Time `json:"expires,omitempty"`
+}
+
+// ClusterCredentials is the response body for getting the credentials of a push mode cluster.
+// ClusterCredentials 是获取推送模式集群凭据的响应
+type ClusterCredentials struct {
+	// Credentials 是 Cluster.Spec.SecretRef 引用的凭据
+	Credentials *ClusterCredential `json:"credentials"`
+	// Impersonator 是 Cluster.Spec.ImpersonatorSecretRef 引用的凭据
+	Impersonator *ClusterCredential `json:"impersonator"`
+}
+
+// ClusterHistory is the response body for getting the condition and health history of a cluster.
+// ClusterHistory 是获取集群状态历史的响应
+type ClusterHistory struct {
+	// Timeline 是时间窗口内集群条件、Kubernetes 版本和资源概况的变化，按时间排序
+	Timeline []history.Event `json:"timeline"`
+	// Availability 是时间窗口内集群的可用性
+	Availability *history.Availability `json:"availability"`
+}
+
+// CheckAPIEnablementRequest is the query for checking whether the target clusters of a propagation policy or of a
+// propagated resource serve the propagated kinds.
+// CheckAPIEnablementRequest 是检查传播策略或已分发资源的目标集群是否提供被分发资源类型的请求
+type CheckAPIEnablementRequest struct {
+	// PolicyKind 是 PropagationPolicy 或 ClusterPropagationPolicy，为空时检查 APIVersion 和 Kind 指定的资源
+	PolicyKind string `form:"policyKind"`
+	// APIVersion 和 Kind 是被检查资源的类型，检查传播策略时忽略
+	APIVersion string `form:"apiVersion"`
+	Kind       string `form:"kind"`
+	// Namespace 和 Name 是被检查的传播策略或资源，集群范围的对象 Namespace 为空
+	Namespace string `form:"namespace"`
+	Name      string `form:"name" binding:"required"`
 }
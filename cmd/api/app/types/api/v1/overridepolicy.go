@@ -16,15 +16,26 @@ limitations under the License.
 
 package v1
 
-// PostOverridePolicyRequest is the request body for creating an override policy.
-// PostOverridePolicyRequest 是创建覆盖策略的请求
+import "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+
+// PostOverridePolicyRequest is the request body for creating an override policy, either from the YAML in
+// OverrideData or from the typed Name, Labels, Annotations and Spec.
+// PostOverridePolicyRequest 是创建覆盖策略的请求，使用 OverrideData 中的 YAML 或结构化的 Name、Labels、Annotations 和 Spec
 type PostOverridePolicyRequest struct {
-	// OverrideData 是覆盖策略的数据
-	OverrideData   string `json:"overrideData" binding:"required"`
+	// OverrideData 是覆盖策略的 YAML，与 Spec 二选一
+	OverrideData   string `json:"overrideData"`
 	// IsClusterScope 是是否集群范围
 	IsClusterScope bool   `json:"isClusterScope"`
 	// Namespace 是命名空间
 	Namespace      string `json:"namespace"`
+	// Name 是使用 Spec 创建时的策略名称
+	Name string `json:"name"`
+	// Labels 是使用 Spec 创建时的策略标签
+	Labels map[string]string `json:"labels"`
+	// Annotations 是使用 Spec 创建时的策略注解
+	Annotations map[string]string `json:"annotations"`
+	// Spec 是结构化的覆盖策略，包括资源选择器和覆盖规则
+	Spec *v1alpha1.OverrideSpec `json:"spec"`
 }
 
 // PostOverridePolicyResponse is the response body for creating an override policy.
@@ -32,17 +43,24 @@ type PostOverridePolicyRequest struct {
 type PostOverridePolicyResponse struct {
 }
 
-// PutOverridePolicyRequest is the request body for updating an override policy.
-// PutOverridePolicyRequest 是更新覆盖策略的请求
+// PutOverridePolicyRequest is the request body for updating an override policy, either from the YAML in
+// OverrideData or from the typed Labels, Annotations and Spec.
+// PutOverridePolicyRequest 是更新覆盖策略的请求，使用 OverrideData 中的 YAML 或结构化的 Labels、Annotations 和 Spec
 type PutOverridePolicyRequest struct {
-	// OverrideData 是覆盖策略的数据
-	OverrideData   string `json:"overrideData" binding:"required"`
+	// OverrideData 是覆盖策略的 YAML，与 Spec 二选一
+	OverrideData   string `json:"overrideData"`
 	// IsClusterScope 是是否集群范围
 	IsClusterScope bool   `json:"isClusterScope"`
 	// Namespace 是命名空间
 	Namespace      string `json:"namespace"`
 	// Name 是名称
 	Name           string `json:"name" binding:"required"`
+	// Labels 是使用 Spec 更新时的策略标签，为空时保持不变
+	Labels map[string]string `json:"labels"`
+	// Annotations 是使用 Spec 更新时的策略注解，为空时保持不变
+	Annotations map[string]string `json:"annotations"`
+	// Spec 是结构化的覆盖策略，替换策略原有的 spec
+	Spec *v1alpha1.OverrideSpec `json:"spec"`
 }
 
 // PutOverridePolicyResponse is the response body for updating an override policy.
@@ -50,6 +68,22 @@ type PutOverridePolicyRequest struct {
 type PutOverridePolicyResponse struct {
 }
 
+// PutClusterOverridePolicyRequest is the request body for updating a cluster override policy, either from the YAML
+// in OverrideData or from the typed Labels, Annotations and Spec.
+// PutClusterOverridePolicyRequest 是更新集群覆盖策略的请求，使用 OverrideData 中的 YAML 或结构化的 Labels、Annotations 和 Spec
+type PutClusterOverridePolicyRequest struct {
+	// OverrideData 是集群覆盖策略的 YAML，与 Spec 二选一
+	OverrideData string `json:"overrideData"`
+	// Labels 是使用 Spec 更新时的策略标签，为空时保持不变
+	Labels map[string]string `json:"labels"`
+	// Annotations 是使用 Spec 更新时的策略注解，为空时保持不变
+	Annotations map[string]string `json:"annotations"`
+	// Spec 是结构化的覆盖策略，替换策略原有的 spec
+	Spec *v1alpha1.OverrideSpec `json:"spec"`
+	// ResourceVersion 是读取策略时的版本，策略已被修改时更新失败；为空时使用 YAML 中的 resourceVersion
+	ResourceVersion string `json:"resourceVersion"`
+}
+
 // DeleteOverridePolicyRequest is the request body for deleting an override policy.
 // DeleteOverridePolicyRequest 是删除覆盖策略的请求
 type DeleteOverridePolicyRequest struct {
@@ -65,3 +99,33 @@ type DeleteOverridePolicyRequest struct {
 // DeleteOverridePolicyResponse 是删除覆盖策略的响应
 type DeleteOverridePolicyResponse struct {
 }
+
+// OverridePolicyReference identifies a saved OverridePolicy or ClusterOverridePolicy.
+// OverridePolicyReference 指定一个已保存的 OverridePolicy 或 ClusterOverridePolicy
+type OverridePolicyReference struct {
+	// IsClusterScope 为 true 时是 ClusterOverridePolicy
+	IsClusterScope bool `json:"isClusterScope"`
+	// Namespace 是 OverridePolicy 的命名空间
+	Namespace string `json:"namespace"`
+	// Name 是名称
+	Name string `json:"name" binding:"required"`
+}
+
+// PreviewOverridePolicyRequest is the request body for previewing a resource rendered by override policies.
+// PreviewOverridePolicyRequest 是预览覆盖策略渲染结果的请求
+type PreviewOverridePolicyRequest struct {
+	// Resource 是资源模板的 YAML，为空时预览 APIVersion、Kind、Namespace 和 Name 指定的控制面资源
+	Resource   string `json:"resource"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	// OverrideData 是未保存的覆盖策略的 YAML，每项是一个 OverridePolicy 或 ClusterOverridePolicy，与已保存的策略同名时替换已保存的策略
+	OverrideData []string `json:"overrideData"`
+	// Policies 是参与预览的已保存策略
+	Policies []OverridePolicyReference `json:"policies"`
+	// IncludeExisting 为 true 时所有已保存的覆盖策略都参与预览，与 Karmada 实际应用的策略一致
+	IncludeExisting bool `json:"includeExisting"`
+	// Clusters 是预览的目标集群，为空时使用资源绑定的调度结果，资源未被分发时使用所有集群
+	Clusters []string `json:"clusters"`
+}
@@ -16,16 +16,26 @@ limitations under the License.
 
 package v1
 
-// PostPropagationPolicyRequest defines the request structure for creating a propagation policy.
-// todo this is only a simple version of pp request, just for POC
-// PostPropagationPolicyRequest 是创建传播策略的请求
+import "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+
+// PostPropagationPolicyRequest defines the request structure for creating a propagation policy, either from the
+// YAML in PropagationData or from the typed Name, Labels, Annotations and Spec.
+// PostPropagationPolicyRequest 是创建传播策略的请求，使用 PropagationData 中的 YAML 或结构化的 Name、Labels、Annotations 和 Spec
 type PostPropagationPolicyRequest struct {
-	// PropagationData 是传播策略的数据
-	PropagationData string `json:"propagationData" binding:"required"`
+	// PropagationData 是传播策略的 YAML，与 Spec 二选一
+	PropagationData string `json:"propagationData"`
 	// IsClusterScope 是是否集群范围
 	IsClusterScope  bool   `json:"isClusterScope"`
 	// Namespace 是命名空间
 	Namespace       string `json:"namespace"`
+	// Name 是使用 Spec 创建时的策略名称
+	Name string `json:"name"`
+	// Labels 是使用 Spec 创建时的策略标签
+	Labels map[string]string `json:"labels"`
+	// Annotations 是使用 Spec 创建时的策略注解
+	Annotations map[string]string `json:"annotations"`
+	// Spec 是结构化的传播策略，包括资源选择器、调度策略、副本调度和故障迁移等
+	Spec *v1alpha1.PropagationSpec `json:"spec"`
 }
 
 // PostPropagationPolicyResponse defines the response structure for creating a propagation policy.
@@ -33,17 +43,24 @@ type PostPropagationPolicyRequest struct {
 type PostPropagationPolicyResponse struct {
 }
 
-// PutPropagationPolicyRequest defines the request structure for updating a propagation policy.
-// PutPropagationPolicyRequest 是更新传播策略的请求
+// PutPropagationPolicyRequest defines the request structure for updating a propagation policy, either from the
+// YAML in PropagationData or from the typed Labels, Annotations and Spec.
+// PutPropagationPolicyRequest 是更新传播策略的请求，使用 PropagationData 中的 YAML 或结构化的 Labels、Annotations 和 Spec
 type PutPropagationPolicyRequest struct {
-	// PropagationData 是传播策略的数据
-	PropagationData string `json:"propagationData" binding:"required"`
+	// PropagationData 是传播策略的 YAML，与 Spec 二选一
+	PropagationData string `json:"propagationData"`
 	// IsClusterScope 是是否集群范围
 	IsClusterScope  bool   `json:"isClusterScope"`
 	// Namespace 是命名空间
 	Namespace       string `json:"namespace"`
 	// Name 是名称
 	Name            string `json:"name" binding:"required"`
+	// Labels 是使用 Spec 更新时的策略标签，为空时保持不变
+	Labels map[string]string `json:"labels"`
+	// Annotations 是使用 Spec 更新时的策略注解，为空时保持不变
+	Annotations map[string]string `json:"annotations"`
+	// Spec 是结构化的传播策略，替换策略原有的 spec
+	Spec *v1alpha1.PropagationSpec `json:"spec"`
 }
 
 // PutPropagationPolicyResponse defines the response structure for updating a propagation policy.
@@ -51,6 +68,22 @@ type PutPropagationPolicyRequest struct {
 type PutPropagationPolicyResponse struct {
 }
 
+// PutClusterPropagationPolicyRequest defines the request structure for updating a cluster propagation policy, either
+// from the YAML in PropagationData or from the typed Labels, Annotations and Spec.
+// PutClusterPropagationPolicyRequest 是更新集群传播策略的请求，使用 PropagationData 中的 YAML 或结构化的 Labels、Annotations 和 Spec
+type PutClusterPropagationPolicyRequest struct {
+	// PropagationData 是集群传播策略的 YAML，与 Spec 二选一
+	PropagationData string `json:"propagationData"`
+	// Labels 是使用 Spec 更新时的策略标签，为空时保持不变
+	Labels map[string]string `json:"labels"`
+	// Annotations 是使用 Spec 更新时的策略注解，为空时保持不变
+	Annotations map[string]string `json:"annotations"`
+	// Spec 是结构化的传播策略，替换策略原有的 spec
+	Spec *v1alpha1.PropagationSpec `json:"spec"`
+	// ResourceVersion 是读取策略时的版本，策略已被修改时更新失败；为空时使用 YAML 中的 resourceVersion
+	ResourceVersion string `json:"resourceVersion"`
+}
+
 // DeletePropagationPolicyRequest defines the request structure for deleting a propagation policy.
 // DeletePropagationPolicyRequest 是删除传播策略的请求
 type DeletePropagationPolicyRequest struct {
@@ -66,3 +99,14 @@ type DeletePropagationPolicyRequest struct {
 // DeletePropagationPolicyResponse 是删除传播策略的响应
 type DeletePropagationPolicyResponse struct {
 }
+
+// SimulatePropagationPolicyRequest defines the request structure for simulating a propagation policy before it is saved.
+// SimulatePropagationPolicyRequest 是在保存前模拟传播策略的请求
+type SimulatePropagationPolicyRequest struct {
+	// PropagationData 是传播策略的 YAML
+	PropagationData string `json:"propagationData" binding:"required"`
+	// IsClusterScope 是是否集群范围
+	IsClusterScope bool `json:"isClusterScope"`
+	// Namespace 是命名空间，为空时使用策略中的命名空间
+	Namespace string `json:"namespace"`
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateBootstrapTokenRequest is the request body for creating a bootstrap token to register a pull mode cluster.
+// CreateBootstrapTokenRequest 是创建用于注册拉取模式集群的引导令牌的请求
+type CreateBootstrapTokenRequest struct {
+	// TTL 是令牌的有效期，例如 24h，默认为 24h，0 表示永不过期
+	TTL string `json:"ttl"`
+	// Description 是令牌的用途说明
+	Description string `json:"description"`
+	// ClusterName 是要注册的成员集群的名称，设置时同时返回注册用的清单
+	ClusterName string `json:"clusterName"`
+	// APIServerEndpoint 是成员集群访问 Karmada apiserver 的地址，默认使用 kube-public/cluster-info 中的地址
+	APIServerEndpoint string `json:"apiServerEndpoint"`
+	// MemberClusterNamespace 是 karmada-agent 在成员集群中的命名空间，默认为 karmada-system
+	MemberClusterNamespace string `json:"memberClusterNamespace"`
+	// ClusterProvider、ClusterRegion 和 ClusterZones 是 karmada-agent 上报的集群信息
+	ClusterProvider string   `json:"clusterProvider"`
+	ClusterRegion   string   `json:"clusterRegion"`
+	ClusterZones    []string `json:"clusterZones"`
+	// Agent 是 karmada-agent 的镜像和副本数等部署参数
+	Agent *AgentOptions `json:"agent"`
+}
+
+// BootstrapToken is a bootstrap token on Karmada control plane.
+// BootstrapToken 是 Karmada 控制面中的引导令牌
+type BootstrapToken struct {
+	// ID 是令牌的公开部分
+	ID string `json:"id"`
+	// Token 是完整的令牌，只在创建时返回
+	Token       string       `json:"token,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Expires     *metav1.Time `json:"expires,omitempty"`
+	Usages      []string     `json:"usages"`
+	Groups      []string     `json:"groups"`
+}
+
+// CreateBootstrapTokenResponse is the response body for creating a bootstrap token.
+// CreateBootstrapTokenResponse 是创建引导令牌的响应
+type CreateBootstrapTokenResponse struct {
+	BootstrapToken
+	// RegisterCommand 是在成员集群中执行的 karmadactl register 命令
+	RegisterCommand string `json:"registerCommand"`
+	// Manifests 是在成员集群中应用即可完成注册的清单，只在请求设置了集群名称时返回
+	Manifests string `json:"manifests,omitempty"`
+}
+
+// BootstrapTokenList is the response body for listing bootstrap tokens.
+// BootstrapTokenList 是引导令牌列表的响应
+type BootstrapTokenList struct {
+	Tokens []BootstrapToken `json:"tokens"`
+}
+
+// AgentCertificateSigningRequest is a pending certificate signing request of a karmada-agent.
+// AgentCertificateSigningRequest 是 karmada-agent 等待审批的证书签名请求
+type AgentCertificateSigningRequest struct {
+	Name string `json:"name"`
+	// Username 是提交请求的用户，使用引导令牌注册时为 system:bootstrap:<令牌 ID>
+	Username string `json:"username"`
+	// CommonName 是证书请求的 CN，例如 system:karmada:agent:member1
+	CommonName        string      `json:"commonName"`
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+}
+
+// AgentCertificateSigningRequestList is the response body for listing the pending certificate signing requests.
+// AgentCertificateSigningRequestList 是等待审批的证书签名请求列表的响应
+type AgentCertificateSigningRequestList struct {
+	Requests []AgentCertificateSigningRequest `json:"requests"`
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import "github.com/karmada-io/dashboard/pkg/apply"
+
+// CreateResourceRequest defines the request structure for creating a namespaced resource from a manifest, e.g. a
+// statefulset, service or configmap. The request can be sent as JSON or as a form.
+// CreateResourceRequest 是通过资源清单创建命名空间级资源的请求
+type CreateResourceRequest struct {
+	// Namespace 是命名空间，为空时使用清单中的命名空间
+	Namespace string `json:"namespace" form:"namespace"`
+	// Content 是 YAML 或 JSON 格式的资源清单
+	Content string `json:"content" form:"content" binding:"required"`
+}
+
+// UpdateResourceRequest defines the request structure for updating a namespaced resource with a manifest. The
+// namespace and name are taken from the path. The request can be sent as JSON or as a form.
+// UpdateResourceRequest 是使用资源清单更新命名空间级资源的请求
+type UpdateResourceRequest struct {
+	// Content 是 YAML 或 JSON 格式的资源清单
+	Content string `json:"content" form:"content" binding:"required"`
+}
+
+// ApplyRequest defines the request structure for applying a multi-document YAML or JSON bundle with server-side
+// apply.
+// ApplyRequest 是使用服务端应用（server-side apply）应用多文档 YAML 或 JSON 的请求
+type ApplyRequest struct {
+	// Content 是多文档的 YAML 或 JSON 内容
+	Content string `json:"content" form:"content" binding:"required"`
+	// Namespace 是未设置命名空间的对象使用的命名空间，为空时使用 default
+	Namespace string `json:"namespace" form:"namespace"`
+	// DryRun 表示只校验而不持久化对象
+	DryRun bool `json:"dryRun" form:"dryRun"`
+	// Force 表示在字段冲突时强制获取字段的所有权
+	Force bool `json:"force" form:"force"`
+}
+
+// ApplyResponse defines the response structure for applying a bundle.
+// ApplyResponse 是应用多文档内容的响应
+type ApplyResponse struct {
+	// Results 是每个对象的应用结果
+	Results []apply.Result `json:"results"`
+}
+
+// ExportRequest defines the request structure for exporting resources and their policies as manifests. The request
+// is sent as query parameters.
+// ExportRequest 是将资源及其策略导出为资源清单的请求
+type ExportRequest struct {
+	// Namespace 是要导出的命名空间，为空时导出除系统命名空间外的所有命名空间
+	Namespace string `json:"namespace" form:"namespace"`
+	// Kinds 是以逗号分隔的资源类型，例如 deployment,service,configmap
+	Kinds string `json:"kinds" form:"kinds"`
+	// LabelSelector 是资源的标签选择器
+	LabelSelector string `json:"labelSelector" form:"labelSelector"`
+	// Format 是输出格式，可选 yaml、tar 或 zip，默认为 yaml
+	Format string `json:"format" form:"format"`
+	// IncludePolicies 表示同时导出匹配的 PropagationPolicy 和 OverridePolicy
+	IncludePolicies bool `json:"includePolicies" form:"includePolicies"`
+}
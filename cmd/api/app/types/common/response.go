@@ -20,14 +20,31 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
 )
 
 // BaseResponse is the base response
 // BaseResponse 是基础响应
 type BaseResponse struct {
-	Code int         `json:"code"`
-	Msg  string      `json:"message"`
-	Data interface{} `json:"data"`
+	Code  int          `json:"code"`
+	Msg   string       `json:"message"`
+	Data  interface{}  `json:"data"`
+	Error *ErrorStatus `json:"error,omitempty"`
+}
+
+// ErrorStatus is the machine-readable part of a fail response, taken from the Kubernetes Status of the error.
+// ErrorStatus 是失败响应中可供程序解析的错误信息，来自错误对应的 Kubernetes Status
+type ErrorStatus struct {
+	// Reason 是错误原因，例如 NotFound、Forbidden、Conflict
+	Reason metav1.StatusReason `json:"reason"`
+	// Details 是与错误相关的资源信息
+	Details *metav1.StatusDetails `json:"details,omitempty"`
+	// Causes 是导致错误的具体原因，例如校验失败的字段
+	Causes []metav1.StatusCause `json:"causes,omitempty"`
+	// MessageKey 是前端用于本地化错误信息的键，例如 MSG_LOGIN_UNAUTHORIZED_ERROR
+	MessageKey string `json:"messageKey,omitempty"`
 }
 
 // Success generate success response
@@ -42,18 +59,45 @@ func Fail(c *gin.Context, err error) {
 	Response(c, err, nil)
 }
 
-// Response generate response
-// Response 生成响应
+// Abort generate fail response with the http status code of err, and stop the pending handlers
+// Abort 生成失败响应，使用错误对应的 HTTP 状态码，并中止后续处理
+func Abort(c *gin.Context, err error) {
+	statusCode, resp := errorResponse(err)
+	c.AbortWithStatusJSON(statusCode, resp)
+}
+
+// Response generate response, the http status code of a fail response is the status code of err
+// Response 生成响应，失败响应的 HTTP 状态码为错误对应的状态码
 func Response(c *gin.Context, err error, data interface{}) {
-	code := 200          // biz status code
-	message := "success" // biz status message
 	if err != nil {
-		code = 500
-		message = err.Error()
+		statusCode, resp := errorResponse(err)
+		c.JSON(statusCode, resp)
+		return
 	}
 	c.JSON(http.StatusOK, BaseResponse{
-		Code: code,
-		Msg:  message,
+		Code: http.StatusOK,
+		Msg:  "success",
 		Data: data,
 	})
 }
+
+// errorResponse 根据错误生成 HTTP 状态码和响应体
+func errorResponse(err error) (int, BaseResponse) {
+	statusCode, _ := errors.HandleError(err)
+	status := errors.Status(err)
+	errorStatus := &ErrorStatus{
+		Reason:     status.Reason,
+		MessageKey: errors.MessageKey(err),
+	}
+	if status.Details != nil {
+		details := *status.Details
+		errorStatus.Causes = details.Causes
+		details.Causes = nil
+		errorStatus.Details = &details
+	}
+	return statusCode, BaseResponse{
+		Code:  statusCode,
+		Msg:   errors.Message(errors.LocalizeError(err)),
+		Error: errorStatus,
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+)
+
+// FieldManager is the field manager of the objects applied by the dashboard.
+const FieldManager = "karmada-dashboard"
+
+// Action is what applying an object did to it.
+type Action string
+
+// List of the actions of an applied object.
+const (
+	// ActionCreated means the object did not exist and was created.
+	ActionCreated Action = "created"
+	// ActionConfigured means the object existed and was changed.
+	ActionConfigured Action = "configured"
+	// ActionUnchanged means the object existed and applying it changed nothing.
+	ActionUnchanged Action = "unchanged"
+	// ActionFailed means the object could not be applied, see Result.Error.
+	ActionFailed Action = "failed"
+)
+
+// Options holds the options of an apply request.
+// Options 保存应用请求的选项
+type Options struct {
+	// Namespace is set on the namespaced objects that do not set a namespace. Objects that set a different
+	// namespace fail. When empty, "default" is used for the objects without namespace.
+	Namespace string
+	// DryRun applies the objects without persisting them.
+	DryRun bool
+	// Force takes ownership of the fields owned by other field managers instead of failing with a conflict.
+	Force bool
+}
+
+// Result is the result of applying one object of the bundle.
+// Result 是应用单个对象的结果
+type Result struct {
+	// Index is the position of the object in the bundle, starting from 0. The items of a List share the index of
+	// the List.
+	Index      int    `json:"index"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Action     Action `json:"action"`
+	// Error is the reason why the object failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Applier applies objects with server-side apply.
+// Applier 使用服务端应用（server-side apply）应用对象
+type Applier struct {
+	client dynamic.Interface
+	mapper meta.RESTMapper
+}
+
+// NewApplier creates an Applier which uses mapper to find the resources of the objects.
+func NewApplier(client dynamic.Interface, mapper meta.RESTMapper) *Applier {
+	return &Applier{client: client, mapper: mapper}
+}
+
+// Apply decodes a multi-document YAML or JSON bundle and applies every object in order. An invalid bundle fails as
+// a whole before anything is applied, while the failure of one object does not stop the others.
+// Apply 解析多文档的 YAML 或 JSON 并依次应用每个对象，单个对象失败不影响其他对象
+func (a *Applier) Apply(ctx context.Context, content string, opts Options) ([]Result, error) {
+	docs, err := Decode(content)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, 0, len(docs))
+	for _, doc := range docs {
+		for _, obj := range doc.Objects {
+			results = append(results, a.applyObject(ctx, doc.Index, obj, opts))
+		}
+	}
+	return results, nil
+}
+
+// applyObject 应用单个对象并返回结果
+func (a *Applier) applyObject(ctx context.Context, index int, obj *unstructured.Unstructured, opts Options) Result {
+	result := Result{
+		Index:      index,
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Name:       obj.GetName(),
+	}
+	fail := func(err error) Result {
+		result.Action = ActionFailed
+		result.Error = errors.Message(errors.LocalizeError(err))
+		return result
+	}
+
+	if obj.GetName() == "" {
+		return fail(errors.NewBadRequest("the object has no name"))
+	}
+	mapping, err := a.mapper.RESTMapping(obj.GroupVersionKind().GroupKind(), obj.GroupVersionKind().Version)
+	if err != nil {
+		return fail(err)
+	}
+	var resource dynamic.ResourceInterface = a.client.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if err = setNamespace(obj, opts.Namespace); err != nil {
+			return fail(err)
+		}
+		resource = a.client.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+	} else {
+		obj.SetNamespace("")
+	}
+	result.Namespace = obj.GetNamespace()
+	// apply 请求中不能携带 managedFields，导出的清单通常包含该字段
+	obj.SetManagedFields(nil)
+
+	current, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fail(err)
+	}
+	applyOptions := metav1.ApplyOptions{FieldManager: FieldManager, Force: opts.Force}
+	if opts.DryRun {
+		applyOptions.DryRun = []string{metav1.DryRunAll}
+	}
+	applied, err := resource.Apply(ctx, obj.GetName(), obj, applyOptions)
+	if err != nil {
+		return fail(err)
+	}
+
+	switch {
+	case current == nil:
+		result.Action = ActionCreated
+	case changed(current, applied):
+		result.Action = ActionConfigured
+	default:
+		result.Action = ActionUnchanged
+	}
+	return result
+}
+
+// setNamespace 为未设置命名空间的对象设置命名空间，命名空间与请求不一致时返回错误
+func setNamespace(obj *unstructured.Unstructured, namespace string) error {
+	switch {
+	case obj.GetNamespace() == "" && namespace == "":
+		obj.SetNamespace(metav1.NamespaceDefault)
+	case obj.GetNamespace() == "":
+		obj.SetNamespace(namespace)
+	case namespace != "" && obj.GetNamespace() != namespace:
+		return errors.NewBadRequest(errors.MsgDeployNamespaceMismatchError)
+	}
+	return nil
+}
+
+// changed reports whether applying modified the object. The resourceVersion cannot be compared because dry-run
+// requests do not bump it, so the objects are compared without the fields that the apiserver maintains.
+// changed 判断应用是否修改了对象
+func changed(before, after *unstructured.Unstructured) bool {
+	return !equality.Semantic.DeepEqual(withoutServerFields(before), withoutServerFields(after))
+}
+
+// withoutServerFields 返回去除了由 apiserver 维护的字段的对象内容
+func withoutServerFields(obj *unstructured.Unstructured) map[string]interface{} {
+	content := obj.DeepCopy().Object
+	for _, field := range []string{"resourceVersion", "managedFields", "generation"} {
+		unstructured.RemoveNestedField(content, "metadata", field)
+	}
+	return content
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+)
+
+// decodeBufferSize 是解码器查找 JSON 或 YAML 起始内容的缓冲区大小
+const decodeBufferSize = 4096
+
+// Document is one document of a bundle.
+// Document 是多文档内容中的单个文档
+type Document struct {
+	// Index is the position of the document in the bundle, not counting empty documents.
+	Index int
+	// Objects holds the object of the document, or the items when the document is a List.
+	Objects []*unstructured.Unstructured
+}
+
+// Decode splits a multi-document YAML bundle, or a stream of JSON objects, into its objects. Empty documents are
+// skipped, and the items of a List, e.g. the output of `kubectl get -o yaml`, are expanded.
+// Decode 将多文档的 YAML 或 JSON 内容解析为对象，跳过空文档并展开 List
+func Decode(content string) ([]Document, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(content), decodeBufferSize)
+	var docs []Document
+	for {
+		obj := &unstructured.Unstructured{}
+		err := decoder.Decode(&obj.Object)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid document %d: %v", len(docs), err))
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		doc := Document{Index: len(docs)}
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid document %d: apiVersion and kind must be set", doc.Index))
+		}
+		if obj.IsList() {
+			err = obj.EachListItem(func(item runtime.Object) error {
+				doc.Objects = append(doc.Objects, item.(*unstructured.Unstructured))
+				return nil
+			})
+			if err != nil {
+				return nil, errors.NewBadRequest(fmt.Sprintf("invalid document %d: %v", doc.Index, err))
+			}
+		} else {
+			doc.Objects = []*unstructured.Unstructured{obj}
+		}
+		docs = append(docs, doc)
+	}
+	if len(docs) == 0 {
+		return nil, errors.NewBadRequest("no object found in the content")
+	}
+	return docs, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		// want 是每个文档中对象的名称
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name: "multi-document yaml with empty documents",
+			content: `---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: demo
+---
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  namespace: demo
+`,
+			want: [][]string{{"demo"}, {"app-config"}},
+		},
+		{
+			name:    "stream of json objects",
+			content: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}} {"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "b"}}`,
+			want:    [][]string{{"a"}, {"b"}},
+		},
+		{
+			name: "list is expanded",
+			content: `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: a
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: b
+`,
+			want: [][]string{{"a", "b"}},
+		},
+		{
+			name:    "missing kind",
+			content: "apiVersion: v1\nmetadata:\n  name: a\n",
+			wantErr: true,
+		},
+		{
+			name:    "empty content",
+			content: "---\n",
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			docs, err := Decode(c.content)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("Decode() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode() unexpected error: %v", err)
+			}
+			var got [][]string
+			for i, doc := range docs {
+				if doc.Index != i {
+					t.Errorf("Decode() document %d has index %d", i, doc.Index)
+				}
+				var names []string
+				for _, obj := range doc.Objects {
+					names = append(names, obj.GetName())
+				}
+				got = append(got, names)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Decode() = %v, expected %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the mutating actions performed through the dashboard to pluggable sinks.
+package audit
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+)
+
+// maxQueryEvents 是查询时从存储中读取的最近审计记录的最大数量
+const maxQueryEvents = 10000
+
+// Sink receives audit events.
+// Sink 接收审计记录
+type Sink interface {
+	// Write stores or forwards one event.
+	Write(event *Event) error
+	// Close flushes pending events and releases the resources of the sink.
+	Close() error
+}
+
+// Reader is a Sink whose events can be read back.
+// Reader 是可以读取审计记录的 Sink
+type Reader interface {
+	Sink
+	// Events returns the most recent events, at most limit of them.
+	Events(limit int) ([]Event, error)
+}
+
+var (
+	// lock 保护 sinks 和 reader
+	lock  sync.RWMutex
+	sinks []Sink
+	// reader 是用于查询审计记录的 Sink
+	reader Reader
+)
+
+// Init replaces the sinks that audit events are written to. The first sink that implements Reader serves the
+// queries of audit events. Auditing is disabled when no sink is given.
+// Init 设置审计记录写入的 Sink，第一个实现了 Reader 的 Sink 用于查询
+func Init(newSinks ...Sink) {
+	lock.Lock()
+	defer lock.Unlock()
+	sinks = newSinks
+	reader = nil
+	for _, sink := range newSinks {
+		if r, ok := sink.(Reader); ok {
+			reader = r
+			break
+		}
+	}
+}
+
+// Enabled returns whether audit events are recorded.
+// Enabled 返回是否记录审计记录
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return len(sinks) > 0
+}
+
+// Record writes event to all sinks. Failures are logged and do not fail the audited request.
+// Record 将审计记录写入所有 Sink，写入失败只记录日志
+func Record(event *Event) {
+	lock.RLock()
+	defer lock.RUnlock()
+	for _, sink := range sinks {
+		if err := sink.Write(event); err != nil {
+			klog.ErrorS(err, "Failed to write audit event", "verb", event.Verb, "path", event.Path, "user", event.User)
+		}
+	}
+}
+
+// Events returns the most recent audit events from the sink that serves queries.
+// Events 从用于查询的 Sink 中读取最近的审计记录
+func Events() ([]Event, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	if reader == nil {
+		return nil, errors.NewServiceUnavailable("no queryable audit sink is configured, enable the file or sqlite audit sink")
+	}
+	return reader.Events(maxQueryEvents)
+}
+
+// Shutdown closes all sinks.
+// Shutdown 关闭所有 Sink
+func Shutdown() {
+	lock.Lock()
+	defer lock.Unlock()
+	for _, sink := range sinks {
+		if err := sink.Close(); err != nil {
+			klog.ErrorS(err, "Failed to close audit sink")
+		}
+	}
+	sinks = nil
+	reader = nil
+}
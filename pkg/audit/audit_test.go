@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+func TestResolveTarget(t *testing.T) {
+	cases := []struct {
+		name      string
+		resource  string
+		namespace string
+		objName   string
+		body      string
+		want      Target
+	}{
+		{
+			name:      "path parameters",
+			resource:  "configmap",
+			namespace: "demo",
+			objName:   "app-config",
+			body:      `{"content": "data: {}"}`,
+			want:      Target{Resource: "configmap", Namespace: "demo", Name: "app-config"},
+		},
+		{
+			name:     "manifest in request field",
+			resource: "configmap",
+			body:     `{"namespace": "demo", "content": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app-config\n"}`,
+			want:     Target{Resource: "configmap", APIVersion: "v1", Kind: "ConfigMap", Namespace: "demo", Name: "app-config"},
+		},
+		{
+			name:     "raw object",
+			resource: "deployment",
+			body:     `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "nginx", "namespace": "demo"}}`,
+			want:     Target{Resource: "deployment", APIVersion: "apps/v1", Kind: "Deployment", Namespace: "demo", Name: "nginx"},
+		},
+		{
+			name:     "cluster scoped policy",
+			resource: "propagationpolicy",
+			body:     `{"isClusterScope": true, "name": "default"}`,
+			want:     Target{Resource: "clusterpropagationpolicy", Name: "default"},
+		},
+		{
+			name:     "cluster join",
+			resource: "cluster",
+			body:     `{"memberClusterName": "member1", "syncMode": "Push", "memberClusterKubeconfig": "apiVersion: v1\nkind: Config\nclusters: []\n"}`,
+			want:     Target{Resource: "cluster", Name: "member1"},
+		},
+		{
+			name:     "form body",
+			resource: "namespace",
+			body:     `content=abc`,
+			want:     Target{Resource: "namespace"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := ResolveTarget(c.resource, c.namespace, c.objName, []byte(c.body))
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("ResolveTarget() = %+v, expected %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	sink, err := NewFileSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatalf("NewFileSink() unexpected error: %v", err)
+	}
+	defer sink.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		event := &Event{Time: start.Add(time.Duration(i) * time.Minute), User: fmt.Sprintf("user-%d", i), Verb: "create", Outcome: OutcomeSuccess}
+		if err = sink.Write(event); err != nil {
+			t.Fatalf("Write() unexpected error: %v", err)
+		}
+	}
+	events, err := sink.Events(3)
+	if err != nil {
+		t.Fatalf("Events() unexpected error: %v", err)
+	}
+	var users []string
+	for _, event := range events {
+		users = append(users, event.User)
+	}
+	if want := []string{"user-2", "user-3", "user-4"}; !reflect.DeepEqual(users, want) {
+		t.Errorf("Events() returned users %v, expected %v", users, want)
+	}
+}
+
+func TestSelectEvents(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []Event{
+		{Time: start, User: "alice", Verb: "create"},
+		{Time: start.Add(time.Minute), User: "bob", Verb: "delete"},
+		{Time: start.Add(2 * time.Minute), User: "alice", Verb: "update"},
+	}
+	query := dataselect.NewDataSelectQuery(dataselect.NoPagination, dataselect.NoSort,
+		dataselect.NewFilterQuery([]string{string(UserProperty), "alice"}))
+	result := SelectEvents(events, query)
+	if result.ListMeta.TotalItems != 2 {
+		t.Fatalf("SelectEvents() total = %d, expected 2", result.ListMeta.TotalItems)
+	}
+	// 未指定排序时按时间倒序
+	if result.Events[0].Verb != "update" || result.Events[1].Verb != "create" {
+		t.Errorf("SelectEvents() = %+v, expected newest first", result.Events)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	value := "password"
+	sum := sha256.Sum256([]byte(value))
+	redacted := redact(value)
+	if strings.Contains(redacted, hex.EncodeToString(sum[:])[:12]) {
+		t.Errorf("redact() = %q contains the unkeyed sha256 of the value", redacted)
+	}
+	if redact(value) != redacted {
+		t.Errorf("redact() of the same value should not change within a process")
+	}
+	if redact("other") == redacted {
+		t.Errorf("redact() of different values should differ")
+	}
+}
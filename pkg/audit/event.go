@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"time"
+
+	"github.com/karmada-io/dashboard/pkg/common/types"
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+)
+
+// Outcome is the outcome of an audited action.
+// Outcome 是被审计操作的结果
+type Outcome string
+
+const (
+	// OutcomeSuccess means the action succeeded.
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure means the action was rejected or failed.
+	OutcomeFailure Outcome = "failure"
+)
+
+// Event is one audited mutating action of the dashboard.
+// Event 是一条被审计的 dashboard 变更操作记录
+type Event struct {
+	// Time 是操作完成的时间
+	Time time.Time `json:"time"`
+	// User 是 Karmada apiserver 认证的用户名，模拟用户时为被模拟的用户
+	User string `json:"user"`
+	// Groups 是用户所属的组
+	Groups []string `json:"groups,omitempty"`
+	// Impersonated 表示请求通过模拟用户请求头发送
+	Impersonated bool `json:"impersonated,omitempty"`
+	// SourceIP 是请求的来源 IP
+	SourceIP string `json:"sourceIP"`
+	// Verb 是操作类型，例如 create、update、delete
+	Verb string `json:"verb"`
+	// Method 和 Path 是请求的 HTTP 方法和路径
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Cluster 是成员集群名称，操作 Karmada 控制面时为空
+	Cluster string `json:"cluster,omitempty"`
+	// APIVersion、Kind、Namespace 和 Name 标识操作的对象，无法确定时为空
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name,omitempty"`
+	// Diff 是对象在操作前后的统一格式差异
+	Diff string `json:"diff,omitempty"`
+	// Outcome 是操作结果
+	Outcome Outcome `json:"outcome"`
+	// Code 是响应的 HTTP 状态码
+	Code int `json:"code"`
+	// Message 是失败时的错误信息
+	Message string `json:"message,omitempty"`
+}
+
+// Property names of an Event that can be used to filter and sort with dataselect.
+const (
+	UserProperty    dataselect.PropertyName = "user"
+	VerbProperty    dataselect.PropertyName = "verb"
+	ClusterProperty dataselect.PropertyName = "cluster"
+	KindProperty    dataselect.PropertyName = "kind"
+	OutcomeProperty dataselect.PropertyName = "outcome"
+	TimeProperty    dataselect.PropertyName = "time"
+)
+
+// EventCell is a cell representation of Event.
+type EventCell Event
+
+// GetProperty returns value of a given property.
+func (e EventCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case UserProperty:
+		return dataselect.StdComparableString(e.User)
+	case VerbProperty:
+		return dataselect.StdComparableString(e.Verb)
+	case ClusterProperty:
+		return dataselect.StdComparableString(e.Cluster)
+	case KindProperty:
+		return dataselect.StdComparableString(e.Kind)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(e.Namespace)
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(e.Name)
+	case OutcomeProperty:
+		return dataselect.StdComparableString(e.Outcome)
+	case TimeProperty, dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(e.Time)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+// EventList is a list of audit events selected with dataselect.
+// EventList 是经过 dataselect 选择的审计记录列表
+type EventList struct {
+	ListMeta types.ListMeta `json:"listMeta"`
+	Events   []Event        `json:"events"`
+}
+
+// SelectEvents filters, sorts and paginates events as instructed by dsQuery. Events are sorted by time, newest
+// first, unless dsQuery sorts them otherwise.
+// SelectEvents 按照 dsQuery 过滤、排序和分页审计记录，未指定排序时按时间倒序
+func SelectEvents(events []Event, dsQuery *dataselect.DataSelectQuery) *EventList {
+	if len(dsQuery.SortQuery.SortByList) == 0 {
+		query := *dsQuery
+		query.SortQuery = &dataselect.SortQuery{SortByList: []dataselect.SortBy{{Property: TimeProperty, Ascending: false}}}
+		dsQuery = &query
+	}
+	cells := make([]dataselect.DataCell, len(events))
+	for i := range events {
+		cells[i] = EventCell(events[i])
+	}
+	selected, total := dataselect.GenericDataSelectWithFilter(cells, dsQuery)
+	result := &EventList{
+		ListMeta: types.ListMeta{TotalItems: total},
+		Events:   make([]Event, len(selected)),
+	}
+	for i := range selected {
+		result.Events[i] = Event(selected[i].(EventCell))
+	}
+	return result
+}
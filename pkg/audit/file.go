@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// maxLineSize 是 JSON Lines 文件中单行的最大长度
+const maxLineSize = 4 << 20
+
+// FileSink appends audit events as JSON lines to a file.
+// FileSink 以 JSON Lines 格式将审计记录追加到文件
+type FileSink struct {
+	lock sync.Mutex
+	path string
+	file *os.File
+}
+
+var _ Reader = &FileSink{}
+
+// NewFileSink opens or creates the JSON lines file at path.
+// NewFileSink 打开或创建 JSON Lines 文件
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{path: path, file: file}, nil
+}
+
+// Write appends event to the file.
+func (s *FileSink) Write(event *Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, err = s.file.Write(append(line, '\n'))
+	return err
+}
+
+// Events reads the last limit events of the file. Lines that cannot be parsed are skipped.
+func (s *FileSink) Events(limit int) ([]Event, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// 使用环形缓冲区只保留最近的 limit 条记录，next 是最早记录的位置
+	events := make([]Event, 0, limit)
+	next := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		var event Event
+		if err = json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			klog.V(4).InfoS("Skipping malformed audit event", "file", s.path, "err", err)
+			continue
+		}
+		if len(events) < limit {
+			events = append(events, event)
+			continue
+		}
+		events[next] = event
+		next = (next + 1) % limit
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return append(events[next:len(events):len(events)], events[:next]...), nil
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/diff"
+)
+
+// unknownUser 是无法确定请求用户时记录的用户名
+const unknownUser = "unknown"
+
+// manifestFields 是请求体中以 YAML 携带资源清单的字段
+var manifestFields = []string{"content", "propagationData", "overrideData"}
+
+// redactKey 是进程启动时随机生成的 HMAC 密钥，审计记录和 Webhook 中的摘要无法离线穷举出 Secret 的值
+var redactKey = newRedactKey()
+
+// Target identifies the object of an audited request. Fields that cannot be determined are empty.
+// Target 标识被审计请求操作的对象
+type Target struct {
+	// Cluster 是成员集群名称，操作 Karmada 控制面时为空
+	Cluster string
+	// Resource 是路由中的资源名称，例如 deployment 或 clusterpropagationpolicy
+	Resource   string
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+// IsMutating returns whether requests with the HTTP method change objects and must be audited.
+// IsMutating 返回该 HTTP 方法的请求是否会变更对象
+func IsMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// verbFor 返回 HTTP 方法对应的操作类型
+func verbFor(method string) string {
+	switch method {
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut:
+		return "update"
+	case http.MethodPatch:
+		return "patch"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return strings.ToLower(method)
+	}
+}
+
+// ResolveTarget determines the object of a request from the resource and path parameters of its route and from
+// its body. The body may be the object itself, as for the _raw endpoints, or a JSON request that carries the object
+// as a manifest in one of the manifestFields, e.g. the content of a CreateResourceRequest or the propagationData of
+// a policy request. Cluster requests name the cluster in memberClusterName.
+// ResolveTarget 根据路由中的资源名称、路径参数和请求体确定请求操作的对象
+func ResolveTarget(resource, namespace, name string, body []byte) Target {
+	target := Target{Resource: resource, Namespace: namespace, Name: name}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return target
+	}
+	manifest := manifestOf(fields)
+	if manifest != nil {
+		target.APIVersion = manifest.GetAPIVersion()
+		target.Kind = manifest.GetKind()
+		if target.Namespace == "" {
+			target.Namespace = manifest.GetNamespace()
+		}
+		if target.Name == "" {
+			target.Name = manifest.GetName()
+		}
+	}
+	if value, ok := fields["namespace"].(string); ok && value != "" {
+		target.Namespace = value
+	}
+	if value, ok := fields["name"].(string); ok && target.Name == "" {
+		target.Name = value
+	}
+	if value, ok := fields["memberClusterName"].(string); ok && target.Name == "" {
+		target.Name = value
+	}
+	// 策略的请求通过 isClusterScope 区分命名空间级和集群级的策略
+	if clusterScope, _ := fields["isClusterScope"].(bool); clusterScope && !strings.HasPrefix(resource, "cluster") {
+		target.Resource = "cluster" + resource
+		target.Namespace = ""
+	}
+	return target
+}
+
+// manifestOf 返回请求体本身或 manifestFields 中的资源清单，其他字段（例如成员集群的 kubeconfig）不会被解析
+func manifestOf(fields map[string]interface{}) *unstructured.Unstructured {
+	if obj := (&unstructured.Unstructured{Object: fields}); obj.GetAPIVersion() != "" && obj.GetKind() != "" {
+		return obj
+	}
+	for _, field := range manifestFields {
+		content, ok := fields[field].(string)
+		if !ok || content == "" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(content), &obj.Object); err != nil {
+			continue
+		}
+		if obj.GetAPIVersion() != "" && obj.GetKind() != "" {
+			return obj
+		}
+	}
+	return nil
+}
+
+// Recording is an audited request in flight. It holds the object as it was before the request, so that the
+// change made by the request can be recorded as a diff.
+// Recording 是正在处理中的被审计请求，保存了请求前的对象以计算变更
+type Recording struct {
+	request  *http.Request
+	sourceIP string
+	target   Target
+	// resource 是操作对象的资源接口，无法确定对象时为空
+	resource dynamic.ResourceInterface
+	before   string
+}
+
+// Start starts auditing request, which operates on target. It reads the object of target with the credentials of
+// the request before the request is served.
+// Start 开始审计请求，在请求处理前使用请求的凭据读取操作的对象
+func Start(request *http.Request, sourceIP string, target Target) *Recording {
+	r := &Recording{request: request, sourceIP: sourceIP, target: target}
+	if target.Name == "" {
+		return r
+	}
+	resource, mapping, err := resourceFor(request, target)
+	if err != nil {
+		klog.V(4).InfoS("Cannot resolve the target of an audited request", "resource", target.Resource, "kind", target.Kind, "err", err)
+		return r
+	}
+	r.resource = resource
+	r.target.APIVersion = mapping.GroupVersionKind.GroupVersion().String()
+	r.target.Kind = mapping.GroupVersionKind.Kind
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		r.target.Namespace = ""
+	}
+	r.before = r.read()
+	return r
+}
+
+// Finish records the event of the request, with code and body of its response.
+// Finish 根据响应的状态码和响应体记录审计记录
+func (r *Recording) Finish(code int, body []byte) {
+	event := &Event{
+		Time:       time.Now(),
+		User:       unknownUser,
+		SourceIP:   r.sourceIP,
+		Verb:       verbFor(r.request.Method),
+		Method:     r.request.Method,
+		Path:       r.request.URL.Path,
+		Cluster:    r.target.Cluster,
+		APIVersion: r.target.APIVersion,
+		Kind:       r.target.Kind,
+		Namespace:  r.target.Namespace,
+		Name:       r.target.Name,
+		Outcome:    OutcomeSuccess,
+		Code:       code,
+	}
+	if event.Kind == "" {
+		event.Kind = r.target.Resource
+	}
+	if user, err := client.GetUserFromRequest(r.request); err == nil {
+		event.User = user.Username
+		event.Groups = user.Groups
+	}
+	event.Impersonated = r.request.Header.Get(client.ImpersonateUserHeader) != ""
+	if code >= http.StatusBadRequest {
+		event.Outcome = OutcomeFailure
+		event.Message = messageOf(body)
+	} else if r.resource != nil {
+		event.Diff = diff.Unified("before", "after", r.before, r.read())
+	}
+	Record(event)
+}
+
+// read 读取操作的对象并序列化为 YAML，对象不存在或无法读取时返回空字符串
+func (r *Recording) read() string {
+	if r.resource == nil {
+		return ""
+	}
+	obj, err := r.resource.Get(context.TODO(), r.target.Name, metav1.GetOptions{})
+	if err != nil {
+		return ""
+	}
+	normalize(obj)
+	content, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
+
+// resourceFor 使用请求的凭据获取操作对象的资源接口
+func resourceFor(request *http.Request, target Target) (dynamic.ResourceInterface, *meta.RESTMapping, error) {
+	mapper, err := client.GetRESTMapperFromRequest(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	var mapping *meta.RESTMapping
+	if target.APIVersion != "" && target.Kind != "" {
+		gvk := schema.FromAPIVersionAndKind(target.APIVersion, target.Kind)
+		mapping, err = mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	} else {
+		var gvr schema.GroupVersionResource
+		if gvr, err = mapper.ResourceFor(schema.ParseGroupResource(target.Resource).WithVersion("")); err == nil {
+			var gvk schema.GroupVersionKind
+			if gvk, err = mapper.KindFor(gvr); err == nil {
+				mapping, err = mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+			}
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dynamicClient dynamic.Interface
+	if target.Cluster != "" {
+		dynamicClient, err = client.GetMemberDynamicClientFromRequest(request, target.Cluster)
+	} else {
+		dynamicClient, err = client.GetDynamicClientFromRequest(request)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return dynamicClient.Resource(mapping.Resource).Namespace(target.Namespace), mapping, nil
+	}
+	return dynamicClient.Resource(mapping.Resource), mapping, nil
+}
+
+// normalize 去除对象中与请求无关的字段，并隐藏 Secret 的数据
+func normalize(obj *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(obj.Object, "status")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "generation")
+	if obj.GroupVersionKind().GroupKind().String() != "Secret" {
+		return
+	}
+	// last-applied-configuration 中包含 Secret 的数据
+	unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration")
+	for _, field := range []string{"data", "stringData"} {
+		values, found, _ := unstructured.NestedStringMap(obj.Object, field)
+		if !found {
+			continue
+		}
+		for key, value := range values {
+			values[key] = redact(value)
+		}
+		_ = unstructured.SetNestedStringMap(obj.Object, values, field)
+	}
+}
+
+// redact 将值替换为其 HMAC 的前缀，使同一进程记录的差异中仍能看出值是否变化
+func redact(value string) string {
+	mac := hmac.New(sha256.New, redactKey)
+	mac.Write([]byte(value))
+	return fmt.Sprintf("<redacted hmac:%s>", hex.EncodeToString(mac.Sum(nil))[:12])
+}
+
+// newRedactKey 生成随机的 HMAC 密钥
+func newRedactKey() []byte {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("failed to generate the audit redaction key: %v", err))
+	}
+	return key
+}
+
+// messageOf 从失败响应中获取错误信息
+func messageOf(body []byte) string {
+	response := struct {
+		Message string `json:"message"`
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return ""
+	}
+	return response.Message
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	_ "github.com/glebarez/sqlite" // Import the SQLite driver
+)
+
+const createEventsTableSQL = `
+CREATE TABLE IF NOT EXISTS audit_events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	time TEXT NOT NULL,
+	user TEXT NOT NULL,
+	groups TEXT,
+	impersonated INTEGER NOT NULL,
+	source_ip TEXT,
+	verb TEXT NOT NULL,
+	method TEXT NOT NULL,
+	path TEXT NOT NULL,
+	cluster TEXT,
+	api_version TEXT,
+	kind TEXT,
+	namespace TEXT,
+	name TEXT,
+	diff TEXT,
+	outcome TEXT NOT NULL,
+	code INTEGER NOT NULL,
+	message TEXT
+);
+CREATE INDEX IF NOT EXISTS audit_events_time ON audit_events (time);
+`
+
+const insertEventSQL = `
+INSERT INTO audit_events (time, user, groups, impersonated, source_ip, verb, method, path, cluster,
+	api_version, kind, namespace, name, diff, outcome, code, message)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+const selectEventsSQL = `
+SELECT time, user, groups, impersonated, source_ip, verb, method, path, cluster,
+	api_version, kind, namespace, name, diff, outcome, code, message
+FROM audit_events ORDER BY id DESC LIMIT ?
+`
+
+// SQLiteSink stores audit events in a SQLite database.
+// SQLiteSink 将审计记录保存到 SQLite 数据库
+type SQLiteSink struct {
+	db *sql.DB
+}
+
+var _ Reader = &SQLiteSink{}
+
+// NewSQLiteSink opens or creates the SQLite database at path and creates the table of audit events.
+// NewSQLiteSink 打开或创建 SQLite 数据库，并创建审计记录表
+func NewSQLiteSink(path string) (*SQLiteSink, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=rwc")
+	if err != nil {
+		return nil, err
+	}
+	// SQLite 不支持并发写入
+	db.SetMaxOpenConns(1)
+	if _, err = db.Exec(createEventsTableSQL); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &SQLiteSink{db: db}, nil
+}
+
+// Write inserts event into the database.
+func (s *SQLiteSink) Write(event *Event) error {
+	groups, err := json.Marshal(event.Groups)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(insertEventSQL, event.Time.UTC().Format(time.RFC3339Nano), event.User, string(groups),
+		event.Impersonated, event.SourceIP, event.Verb, event.Method, event.Path, event.Cluster, event.APIVersion,
+		event.Kind, event.Namespace, event.Name, event.Diff, string(event.Outcome), event.Code, event.Message)
+	return err
+}
+
+// Events returns the last limit events, oldest first.
+func (s *SQLiteSink) Events(limit int) ([]Event, error) {
+	rows, err := s.db.Query(selectEventsSQL, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var event Event
+		var eventTime, groups, outcome string
+		if err = rows.Scan(&eventTime, &event.User, &groups, &event.Impersonated, &event.SourceIP, &event.Verb,
+			&event.Method, &event.Path, &event.Cluster, &event.APIVersion, &event.Kind, &event.Namespace,
+			&event.Name, &event.Diff, &outcome, &event.Code, &event.Message); err != nil {
+			return nil, err
+		}
+		if event.Time, err = time.Parse(time.RFC3339Nano, eventTime); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(groups), &event.Groups); err != nil {
+			return nil, err
+		}
+		event.Outcome = Outcome(outcome)
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	// 查询结果按时间倒序，反转为与文件一致的时间正序
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	return events, nil
+}
+
+// Close closes the database.
+func (s *SQLiteSink) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// webhookQueueSize 是等待发送到 webhook 的审计记录的最大数量
+	webhookQueueSize = 1024
+	// webhookTimeout 是发送一条审计记录的超时时间
+	webhookTimeout = 10 * time.Second
+)
+
+// WebhookSink posts every audit event as JSON to a URL. Events are sent in the background so that a slow
+// webhook does not delay the audited requests, and dropped when the queue is full.
+// WebhookSink 在后台将审计记录以 JSON 格式发送到指定的 URL，队列已满时丢弃记录
+type WebhookSink struct {
+	url    string
+	client *http.Client
+	queue  chan *Event
+	// wg 等待后台发送协程退出
+	wg sync.WaitGroup
+}
+
+var _ Sink = &WebhookSink{}
+
+// NewWebhookSink creates a WebhookSink and starts sending events to url.
+// NewWebhookSink 创建 WebhookSink 并开始向 url 发送审计记录
+func NewWebhookSink(url string) *WebhookSink {
+	s := &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+		queue:  make(chan *Event, webhookQueueSize),
+	}
+	s.wg.Add(1)
+	go s.run()
+	return s
+}
+
+// Write queues event to be sent.
+func (s *WebhookSink) Write(event *Event) error {
+	select {
+	case s.queue <- event:
+		return nil
+	default:
+		return fmt.Errorf("audit webhook queue is full, dropping event")
+	}
+}
+
+// Close sends the queued events and stops the sink.
+func (s *WebhookSink) Close() error {
+	close(s.queue)
+	s.wg.Wait()
+	return nil
+}
+
+// run 逐条发送队列中的审计记录
+func (s *WebhookSink) run() {
+	defer s.wg.Done()
+	for event := range s.queue {
+		if err := s.send(event); err != nil {
+			klog.ErrorS(err, "Failed to send audit event to webhook", "url", s.url, "verb", event.Verb, "path", event.Path)
+		}
+	}
+}
+
+// send 发送一条审计记录
+func (s *WebhookSink) send(event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("audit webhook responded with status %s", response.Status)
+	}
+	return nil
+}
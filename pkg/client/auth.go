@@ -17,6 +17,7 @@ limitations under the License.
 package client
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -37,6 +38,10 @@ const (
 
 // karmadaConfigFromRequest 从 HTTP 请求创建一个 Karmada 配置
 func karmadaConfigFromRequest(request *http.Request) (*rest.Config, error) {
+	// 检查 Karmada 是否已初始化
+	if !isKarmadaInitialized() {
+		return nil, fmt.Errorf("client package not initialized")
+	}
 	// 构建授权信息
 	authInfo, err := buildAuthInfo(request)
 	if err != nil {
@@ -46,6 +51,13 @@ func karmadaConfigFromRequest(request *http.Request) (*rest.Config, error) {
 	return buildConfigFromAuthInfo(authInfo)
 }
 
+// memberConfigFor 基于 Karmada 配置创建一个通过 Karmada 集群代理访问成员集群的配置
+func memberConfigFor(config *rest.Config, clusterName string) *rest.Config {
+	memberConfig := rest.CopyConfig(config)
+	memberConfig.Host = karmadaRestConfig.Host + fmt.Sprintf(proxyURL, clusterName)
+	return memberConfig
+}
+
 // buildConfigFromAuthInfo 从授权信息构建一个 Karmada 配置
 func buildConfigFromAuthInfo(authInfo *clientcmdapi.AuthInfo) (*rest.Config, error) {
 	// clientcmdapi.AuthInfo 是 clientcmd 包中的一个结构体，用于存储认证信息
@@ -78,11 +90,19 @@ func buildConfigFromAuthInfo(authInfo *clientcmdapi.AuthInfo) (*rest.Config, err
 	}
 	// 设置当前上下文
 	cmdCfg.CurrentContext = DefaultCmdConfigName
-	// 返回 Karmada 配置
-	return clientcmd.NewDefaultClientConfig(
+	restConfig, err := clientcmd.NewDefaultClientConfig(
 		*cmdCfg,
 		&clientcmd.ConfigOverrides{},
 	).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	// 沿用 dashboard 自身配置的限流和用户代理
+	restConfig.QPS = karmadaRestConfig.QPS
+	restConfig.Burst = karmadaRestConfig.Burst
+	restConfig.UserAgent = karmadaRestConfig.UserAgent
+	// 返回 Karmada 配置
+	return restConfig, nil
 }
 
 // buildAuthInfo 构建授权信息
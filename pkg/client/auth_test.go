@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func initTestKarmadaConfig(t *testing.T) {
+	t.Helper()
+	oldRestConfig, oldAPIConfig := karmadaRestConfig, karmadaAPIConfig
+	karmadaRestConfig = &rest.Config{
+		Host:      "https://karmada-apiserver:5443",
+		QPS:       50,
+		Burst:     100,
+		UserAgent: DefaultUserAgent + "/test",
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure: true,
+		},
+	}
+	karmadaAPIConfig = clientcmdapi.NewConfig()
+	oldCache := requestClientCache
+	requestClientCache = newClientCache(DefaultClientCacheSize, DefaultClientCacheTTL)
+	t.Cleanup(func() {
+		karmadaRestConfig, karmadaAPIConfig = oldRestConfig, oldAPIConfig
+		requestClientCache = oldCache
+	})
+}
+
+func newTestRequest(headers map[string][]string) *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/cluster", nil)
+	for name, values := range headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	return req
+}
+
+func TestKarmadaConfigFromRequest(t *testing.T) {
+	initTestKarmadaConfig(t)
+
+	cases := []struct {
+		name             string
+		headers          map[string][]string
+		wantUnauthorized bool
+		wantToken        string
+		wantImpersonate  rest.ImpersonationConfig
+	}{
+		{
+			name:             "missing authorization header",
+			headers:          nil,
+			wantUnauthorized: true,
+		},
+		{
+			name:             "non bearer authorization header",
+			headers:          map[string][]string{authorizationHeader: {"Basic YWRtaW46YWRtaW4="}},
+			wantUnauthorized: true,
+		},
+		{
+			name:      "bearer token",
+			headers:   map[string][]string{authorizationHeader: {"Bearer my-token"}},
+			wantToken: "my-token",
+		},
+		{
+			name: "bearer token with impersonation",
+			headers: map[string][]string{
+				authorizationHeader:                {"Bearer my-token"},
+				ImpersonateUserHeader:              {"alice"},
+				ImpersonateGroupHeader:             {"dev", "ops"},
+				ImpersonateUserExtraHeader + "Foo": {"bar"},
+			},
+			wantToken: "my-token",
+			wantImpersonate: rest.ImpersonationConfig{
+				UserName: "alice",
+				Groups:   []string{"dev", "ops"},
+				Extra:    map[string][]string{"Foo": {"bar"}},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config, err := karmadaConfigFromRequest(newTestRequest(c.headers))
+			if c.wantUnauthorized {
+				if !k8serrors.IsUnauthorized(err) {
+					t.Fatalf("karmadaConfigFromRequest() error = %v, expected Unauthorized", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("karmadaConfigFromRequest() unexpected error: %v", err)
+			}
+			if config.BearerToken != c.wantToken {
+				t.Errorf("BearerToken = %q, expected %q", config.BearerToken, c.wantToken)
+			}
+			if config.Impersonate.UserName != c.wantImpersonate.UserName ||
+				!reflect.DeepEqual(config.Impersonate.Groups, c.wantImpersonate.Groups) ||
+				(len(c.wantImpersonate.Extra) > 0 && !reflect.DeepEqual(config.Impersonate.Extra, c.wantImpersonate.Extra)) {
+				t.Errorf("Impersonate = %#v, expected %#v", config.Impersonate, c.wantImpersonate)
+			}
+			if config.Host != karmadaRestConfig.Host {
+				t.Errorf("Host = %q, expected %q", config.Host, karmadaRestConfig.Host)
+			}
+			if config.QPS != karmadaRestConfig.QPS || config.Burst != karmadaRestConfig.Burst ||
+				config.UserAgent != karmadaRestConfig.UserAgent {
+				t.Errorf("QPS/Burst/UserAgent = %v/%v/%q, expected %v/%v/%q",
+					config.QPS, config.Burst, config.UserAgent,
+					karmadaRestConfig.QPS, karmadaRestConfig.Burst, karmadaRestConfig.UserAgent)
+			}
+		})
+	}
+}
+
+func TestMemberConfigFor(t *testing.T) {
+	initTestKarmadaConfig(t)
+
+	cases := []struct {
+		name        string
+		clusterName string
+		wantHost    string
+	}{
+		{
+			name:        "proxy host of member cluster",
+			clusterName: "member1",
+			wantHost:    "https://karmada-apiserver:5443/apis/cluster.karmada.io/v1alpha1/clusters/member1/proxy/",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config, err := karmadaConfigFromRequest(newTestRequest(map[string][]string{authorizationHeader: {"Bearer my-token"}}))
+			if err != nil {
+				t.Fatalf("karmadaConfigFromRequest() unexpected error: %v", err)
+			}
+			memberConfig := memberConfigFor(config, c.clusterName)
+			if memberConfig.Host != c.wantHost {
+				t.Errorf("Host = %q, expected %q", memberConfig.Host, c.wantHost)
+			}
+			if memberConfig.BearerToken != "my-token" {
+				t.Errorf("BearerToken = %q, expected %q", memberConfig.BearerToken, "my-token")
+			}
+			if config.Host != karmadaRestConfig.Host {
+				t.Errorf("memberConfigFor() modified the Karmada config host to %q", config.Host)
+			}
+		})
+	}
+}
+
+func TestGetKarmadaConfigFromRequestNotInitialized(t *testing.T) {
+	oldRestConfig, oldAPIConfig := karmadaRestConfig, karmadaAPIConfig
+	karmadaRestConfig, karmadaAPIConfig = nil, nil
+	defer func() {
+		karmadaRestConfig, karmadaAPIConfig = oldRestConfig, oldAPIConfig
+	}()
+
+	req := newTestRequest(map[string][]string{authorizationHeader: {"Bearer my-token"}})
+	if _, err := GetKarmadaConfigFromRequest(req); err == nil {
+		t.Errorf("GetKarmadaConfigFromRequest() expected error when client package is not initialized")
+	}
+}
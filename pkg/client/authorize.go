@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	kubeclient "k8s.io/client-go/kubernetes"
+)
+
+const (
+	// accessReviewCacheSize 是每个用户身份缓存的访问审查结果数量
+	accessReviewCacheSize = 128
+	// accessReviewTTL 是访问审查结果的缓存时间
+	accessReviewTTL = 30 * time.Second
+)
+
+// Authorize checks with a SelfSubjectAccessReview that the user of the request may perform the action described by
+// attributes in Karmada control plane. It must be called before serving a request from data that the dashboard read
+// with its own identity, e.g. from the informer cache. Results are cached per user for a short time.
+// Authorize 使用 SelfSubjectAccessReview 检查请求的用户是否有权限在 Karmada 控制面执行指定操作
+func Authorize(request *http.Request, attributes authorizationv1.ResourceAttributes) error {
+	clients, err := clientsFromRequest(request)
+	if err != nil {
+		return err
+	}
+	return authorize(clients.kube, clients.reviews, attributes)
+}
+
+// AuthorizeMember checks with a SelfSubjectAccessReview that the user of the request may perform the action described
+// by attributes in the given member cluster. The review is sent through the cluster proxy, so it is evaluated by the
+// member cluster for the impersonated user.
+// AuthorizeMember 使用 SelfSubjectAccessReview 检查请求的用户是否有权限在成员集群中执行指定操作
+func AuthorizeMember(request *http.Request, clusterName string, attributes authorizationv1.ResourceAttributes) error {
+	clients, err := clientsFromRequest(request)
+	if err != nil {
+		return err
+	}
+	member, err := clients.member(clusterName)
+	if err != nil {
+		return err
+	}
+	return authorize(member.kube, member.reviews, attributes)
+}
+
+// authorize 发送 SelfSubjectAccessReview 并缓存结果，不允许时返回 Forbidden 错误
+func authorize(kube kubeclient.Interface, reviews *utilcache.LRUExpireCache, attributes authorizationv1.ResourceAttributes) error {
+	key := fmt.Sprintf("%s/%s/%s/%s/%s/%s", attributes.Verb, attributes.Group, attributes.Resource,
+		attributes.Subresource, attributes.Namespace, attributes.Name)
+	allowed, ok := reviews.Get(key)
+	if !ok {
+		review, err := kube.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		allowed = review.Status.Allowed
+		reviews.Add(key, allowed, accessReviewTTL)
+	}
+	if !allowed.(bool) {
+		scope := "cluster scope"
+		if attributes.Namespace != "" {
+			scope = fmt.Sprintf("namespace %q", attributes.Namespace)
+		}
+		return k8serrors.NewForbidden(schema.GroupResource{Group: attributes.Group, Resource: attributes.Resource},
+			attributes.Name, fmt.Errorf("user cannot %s resource in %s", attributes.Verb, scope))
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/lru"
+)
+
+// requestClients holds the clients built for one user identity, i.e. one token plus impersonation headers.
+// All clients share the same http.Client, whose transport is shared with other identities of the same upstream.
+// requestClients 保存同一用户身份（令牌和模拟用户信息）的客户端
+type requestClients struct {
+	// config 是携带用户令牌的 Karmada 配置
+	config *rest.Config
+	// httpClient 是所有客户端共用的 http.Client
+	httpClient *http.Client
+	karmada    karmadaclientset.Interface
+	kube       kubeclient.Interface
+	dynamic    dynamic.Interface
+	// mapper 基于缓存的发现信息将 GroupVersionKind 映射为资源
+	mapper *expiringRESTMapper
+	// reviews 缓存 Karmada 控制面的访问审查结果
+	reviews *utilcache.LRUExpireCache
+	// userLock 保护 user
+	userLock sync.Mutex
+	// user 是 Karmada apiserver 认证的用户信息，首次查询后缓存
+	user *authenticationv1.UserInfo
+	// members 是成员集群名称到 *memberClientSet 的映射
+	members sync.Map
+}
+
+// memberClientSet 保存通过 Karmada 集群代理访问成员集群的客户端
+type memberClientSet struct {
+	kube    kubeclient.Interface
+	dynamic dynamic.Interface
+	// reviews 缓存成员集群的访问审查结果
+	reviews *utilcache.LRUExpireCache
+}
+
+// newRequestClients 为用户配置构建客户端
+func newRequestClients(config *rest.Config) (*requestClients, error) {
+	httpClient, err := httpClientFor(config)
+	if err != nil {
+		return nil, err
+	}
+	karmadaClient, err := karmadaclientset.NewForConfigAndClient(config, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubeclient.NewForConfigAndClient(config, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfigAndClient(config, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfigAndClient(config, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	return &requestClients{
+		config:     config,
+		httpClient: httpClient,
+		karmada:    karmadaClient,
+		kube:       kubeClient,
+		dynamic:    dynamicClient,
+		mapper:     newExpiringRESTMapper(discoveryClient, DefaultRESTMapperTTL),
+		reviews:    utilcache.NewLRUExpireCache(accessReviewCacheSize),
+	}, nil
+}
+
+// member 返回指定成员集群的客户端，首次访问时创建
+func (r *requestClients) member(clusterName string) (*memberClientSet, error) {
+	if value, ok := r.members.Load(clusterName); ok {
+		return value.(*memberClientSet), nil
+	}
+	config := memberConfigFor(r.config, clusterName)
+	kubeClient, err := kubeclient.NewForConfigAndClient(config, r.httpClient)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfigAndClient(config, r.httpClient)
+	if err != nil {
+		return nil, err
+	}
+	value, _ := r.members.LoadOrStore(clusterName, &memberClientSet{
+		kube:    kubeClient,
+		dynamic: dynamicClient,
+		reviews: utilcache.NewLRUExpireCache(accessReviewCacheSize),
+	})
+	return value.(*memberClientSet), nil
+}
+
+// clientCache is a bounded LRU cache of requestClients keyed by a hash of the user identity. An entry is
+// evicted when it has not been used for ttl, or when the cache is full and it is the least recently used one.
+// clientCache 是按用户身份哈希缓存客户端的 LRU 缓存，空闲超过 ttl 的条目会被淘汰
+type clientCache struct {
+	// lock 保证同一身份并发未命中时只构建一次客户端
+	lock  sync.Mutex
+	cache *lru.Cache
+	ttl   time.Duration
+}
+
+// clientCacheEntry 是缓存的客户端及其过期时间
+type clientCacheEntry struct {
+	clients *requestClients
+	expires time.Time
+}
+
+// newClientCache 创建客户端缓存
+func newClientCache(size int, ttl time.Duration) *clientCache {
+	if size <= 0 {
+		size = DefaultClientCacheSize
+	}
+	if ttl <= 0 {
+		ttl = DefaultClientCacheTTL
+	}
+	return &clientCache{
+		// 淘汰回调在 LRU 的锁内执行，不能再调用 Len，因此按条目增减更新指标
+		cache: lru.NewWithEvictionFunc(size, func(lru.Key, interface{}) {
+			clientCacheEntries.Dec()
+		}),
+		ttl: ttl,
+	}
+}
+
+// get 返回请求对应用户身份的客户端，不存在时创建并缓存
+func (c *clientCache) get(request *http.Request) (*requestClients, error) {
+	authInfo, err := buildAuthInfo(request)
+	if err != nil {
+		return nil, err
+	}
+	key := cacheKeyForAuthInfo(authInfo)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	if value, ok := c.cache.Get(key); ok {
+		entry := value.(*clientCacheEntry)
+		if now.Before(entry.expires) {
+			clientCacheRequests.WithLabelValues("hit").Inc()
+			// 刷新过期时间，实现按空闲时间淘汰
+			entry.expires = now.Add(c.ttl)
+			return entry.clients, nil
+		}
+		c.cache.Remove(key)
+	}
+	clientCacheRequests.WithLabelValues("miss").Inc()
+
+	config, err := karmadaConfigFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := newRequestClients(config)
+	if err != nil {
+		return nil, err
+	}
+	// 先计入新条目，容量已满时 Add 会通过淘汰回调减去被淘汰的条目
+	clientCacheEntries.Inc()
+	c.cache.Add(key, &clientCacheEntry{clients: clients, expires: now.Add(c.ttl)})
+	return clients, nil
+}
+
+// cacheKeyForAuthInfo 根据令牌和模拟用户信息计算缓存键，缓存中不保存明文令牌
+func cacheKeyForAuthInfo(authInfo *clientcmdapi.AuthInfo) string {
+	groups := append([]string(nil), authInfo.ImpersonateGroups...)
+	sort.Strings(groups)
+	extraNames := make([]string, 0, len(authInfo.ImpersonateUserExtra))
+	for name := range authInfo.ImpersonateUserExtra {
+		extraNames = append(extraNames, name)
+	}
+	sort.Strings(extraNames)
+
+	hash := sha256.New()
+	write := func(s string) {
+		hash.Write([]byte(s))
+		hash.Write([]byte{0})
+	}
+	write(authInfo.Token)
+	write(authInfo.Impersonate)
+	write(strings.Join(groups, ","))
+	for _, name := range extraNames {
+		values := append([]string(nil), authInfo.ImpersonateUserExtra[name]...)
+		sort.Strings(values)
+		write(name + "=" + strings.Join(values, ","))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+var (
+	// transportLock 保护 sharedTransports
+	transportLock sync.Mutex
+	// sharedTransports 是上游地址到共享 http.RoundTripper 的映射
+	sharedTransports = map[string]http.RoundTripper{}
+)
+
+// httpClientFor returns an http.Client for config which authenticates with the credentials of config, but reuses
+// the connection pool of the shared transport for the upstream of config.
+// httpClientFor 返回使用 config 中凭据的 http.Client，底层复用同一上游的共享连接池
+func httpClientFor(config *rest.Config) (*http.Client, error) {
+	transport, err := sharedTransportFor(config)
+	if err != nil {
+		return nil, err
+	}
+	rt, err := rest.HTTPWrappersForConfig(config, transport)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: rt,
+		Timeout:   config.Timeout,
+	}, nil
+}
+
+// sharedTransportFor 返回上游地址对应的共享 transport，共享 transport 不携带任何用户凭据
+func sharedTransportFor(config *rest.Config) (http.RoundTripper, error) {
+	u, err := url.Parse(config.Host)
+	if err != nil {
+		return nil, err
+	}
+	key := u.Scheme + "://" + u.Host
+
+	transportLock.Lock()
+	defer transportLock.Unlock()
+	if transport, ok := sharedTransports[key]; ok {
+		return transport, nil
+	}
+	transport, err := rest.TransportFor(&rest.Config{
+		Host: config.Host,
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure:   config.TLSClientConfig.Insecure,
+			ServerName: config.TLSClientConfig.ServerName,
+			CAFile:     config.TLSClientConfig.CAFile,
+			CAData:     config.TLSClientConfig.CAData,
+		},
+		Proxy: config.Proxy,
+		Dial:  config.Dial,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sharedTransports[key] = transport
+	return transport, nil
+}
+
+// requestClientCache 是全局的请求客户端缓存
+var requestClientCache = newClientCache(DefaultClientCacheSize, DefaultClientCacheTTL)
+
+// clientsFromRequest 返回请求对应用户身份的缓存客户端
+func clientsFromRequest(request *http.Request) (*requestClients, error) {
+	if !isKarmadaInitialized() {
+		return nil, fmt.Errorf("client package not initialized")
+	}
+	return requestClientCache.get(request)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestCacheKeyForAuthInfo(t *testing.T) {
+	base := &clientcmdapi.AuthInfo{
+		Token:                "token-a",
+		Impersonate:          "alice",
+		ImpersonateGroups:    []string{"dev", "ops"},
+		ImpersonateUserExtra: map[string][]string{"scopes": {"a", "b"}},
+	}
+	cases := []struct {
+		name      string
+		authInfo  *clientcmdapi.AuthInfo
+		wantEqual bool
+	}{
+		{
+			name: "groups and extra values in different order",
+			authInfo: &clientcmdapi.AuthInfo{
+				Token:                "token-a",
+				Impersonate:          "alice",
+				ImpersonateGroups:    []string{"ops", "dev"},
+				ImpersonateUserExtra: map[string][]string{"scopes": {"b", "a"}},
+			},
+			wantEqual: true,
+		},
+		{
+			name: "different token",
+			authInfo: &clientcmdapi.AuthInfo{
+				Token:                "token-b",
+				Impersonate:          "alice",
+				ImpersonateGroups:    []string{"dev", "ops"},
+				ImpersonateUserExtra: map[string][]string{"scopes": {"a", "b"}},
+			},
+		},
+		{
+			name: "different impersonated user",
+			authInfo: &clientcmdapi.AuthInfo{
+				Token:                "token-a",
+				Impersonate:          "bob",
+				ImpersonateGroups:    []string{"dev", "ops"},
+				ImpersonateUserExtra: map[string][]string{"scopes": {"a", "b"}},
+			},
+		},
+		{
+			name: "without impersonation",
+			authInfo: &clientcmdapi.AuthInfo{
+				Token: "token-a",
+			},
+		},
+	}
+	baseKey := cacheKeyForAuthInfo(base)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if equal := cacheKeyForAuthInfo(c.authInfo) == baseKey; equal != c.wantEqual {
+				t.Errorf("cacheKeyForAuthInfo() equal = %v, expected %v", equal, c.wantEqual)
+			}
+		})
+	}
+}
+
+func TestClientCacheGet(t *testing.T) {
+	initTestKarmadaConfig(t)
+	cache := newClientCache(1, time.Minute)
+	entries := cachedEntries(t)
+
+	tokenA := newTestRequest(map[string][]string{authorizationHeader: {"Bearer token-a"}})
+	tokenB := newTestRequest(map[string][]string{authorizationHeader: {"Bearer token-b"}})
+
+	first, err := cache.get(tokenA)
+	if err != nil {
+		t.Fatalf("get() unexpected error: %v", err)
+	}
+	second, err := cache.get(tokenA)
+	if err != nil {
+		t.Fatalf("get() unexpected error: %v", err)
+	}
+	if first != second {
+		t.Errorf("get() with the same token should return the cached clients")
+	}
+
+	other, err := cache.get(tokenB)
+	if err != nil {
+		t.Fatalf("get() unexpected error: %v", err)
+	}
+	if other == first {
+		t.Errorf("get() with a different token should not return the clients of another user")
+	}
+	if first.httpClient.Transport == other.httpClient.Transport {
+		t.Errorf("clients of different users should not share the authenticating round tripper")
+	}
+
+	// 缓存容量为 1，token-a 的客户端已被淘汰
+	third, err := cache.get(tokenA)
+	if err != nil {
+		t.Fatalf("get() unexpected error: %v", err)
+	}
+	if third == first {
+		t.Errorf("get() should rebuild clients evicted by the LRU")
+	}
+	if actual := cachedEntries(t) - entries; actual != 1 {
+		t.Errorf("entries gauge changed by %v, expected 1 after LRU evictions", actual)
+	}
+}
+
+func TestClientCacheExpire(t *testing.T) {
+	initTestKarmadaConfig(t)
+	cache := newClientCache(2, time.Millisecond)
+	entries := cachedEntries(t)
+
+	request := newTestRequest(map[string][]string{authorizationHeader: {"Bearer token-a"}})
+	first, err := cache.get(request)
+	if err != nil {
+		t.Fatalf("get() unexpected error: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	second, err := cache.get(request)
+	if err != nil {
+		t.Fatalf("get() unexpected error: %v", err)
+	}
+	if second == first {
+		t.Errorf("get() should rebuild clients idle for longer than the ttl")
+	}
+	if actual := cachedEntries(t) - entries; actual != 1 {
+		t.Errorf("entries gauge changed by %v, expected 1 after an expired entry was replaced", actual)
+	}
+}
+
+// cachedEntries 返回当前的缓存条目数指标
+func cachedEntries(t *testing.T) float64 {
+	value, err := testutil.GetGaugeMetricValue(clientCacheEntries)
+	if err != nil {
+		t.Fatalf("GetGaugeMetricValue() unexpected error: %v", err)
+	}
+	return value
+}
@@ -21,6 +21,8 @@ import (
 	"net/http"
 
 	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/dynamic"
 	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -114,23 +116,81 @@ func KubeClientSetFromKubeConfig(kubeconfig string) (*kubeclient.Clientset, erro
 }
 
 // GetKarmadaClientFromRequest creates a Karmada clientset from an HTTP request.
+// Clients are cached per token and impersonation headers, see clientCache.
 // GetKarmadaClientFromRequest 从 HTTP 请求创建一个 Karmada 客户端
 func GetKarmadaClientFromRequest(request *http.Request) (karmadaclientset.Interface, error) {
-	// 检查 Karmada 是否已初始化
-	if !isKarmadaInitialized() {
-		return nil, fmt.Errorf("client package not initialized")
+	clients, err := clientsFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	return clients.karmada, nil
+}
+
+// GetKarmadaConfigFromRequest creates a rest.Config for the Karmada apiserver which carries the token and
+// impersonation headers of an HTTP request.
+// GetKarmadaConfigFromRequest 从 HTTP 请求创建一个携带请求令牌和模拟用户信息的 Karmada 配置
+func GetKarmadaConfigFromRequest(request *http.Request) (*rest.Config, error) {
+	clients, err := clientsFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	return rest.CopyConfig(clients.config), nil
+}
+
+// GetKubeClientFromRequest creates a Kubernetes clientset for the Karmada apiserver from an HTTP request.
+func GetKubeClientFromRequest(request *http.Request) (kubeclient.Interface, error) {
+	clients, err := clientsFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	return clients.kube, nil
+}
+
+// GetDynamicClientFromRequest creates a dynamic client for the Karmada apiserver from an HTTP request.
+func GetDynamicClientFromRequest(request *http.Request) (dynamic.Interface, error) {
+	clients, err := clientsFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	return clients.dynamic, nil
+}
+
+// GetRESTMapperFromRequest returns a RESTMapper for the Karmada apiserver which maps kinds to resources with the
+// discovery information visible to the user of an HTTP request. Discovery is cached, and refreshed when a kind
+// cannot be found.
+// GetRESTMapperFromRequest 返回使用请求用户的发现信息将 kind 映射为资源的 RESTMapper
+func GetRESTMapperFromRequest(request *http.Request) (meta.RESTMapper, error) {
+	clients, err := clientsFromRequest(request)
+	if err != nil {
+		return nil, err
 	}
-	// 从 HTTP 请求创建 Karmada 客户端
-	return karmadaClientFromRequest(request)
+	return clients.mapper, nil
 }
 
-// karmadaClientFromRequest 从 HTTP 请求创建一个 Karmada 客户端
-func karmadaClientFromRequest(request *http.Request) (karmadaclientset.Interface, error) {
-	// 从 HTTP 请求创建 Karmada 配置
-	config, err := karmadaConfigFromRequest(request)
+// GetMemberClientFromRequest creates a Kubernetes clientset for the given member cluster from an HTTP request.
+// The member cluster is accessed through the cluster proxy of Karmada apiserver, so the caller's token is
+// checked by Karmada apiserver RBAC before the request is forwarded to the member cluster.
+func GetMemberClientFromRequest(request *http.Request, clusterName string) (kubeclient.Interface, error) {
+	clients, err := clientsFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	member, err := clients.member(clusterName)
 	if err != nil {
 		return nil, err
 	}
+	return member.kube, nil
+}
 
-	return karmadaclientset.NewForConfig(config)
+// GetMemberDynamicClientFromRequest creates a dynamic client for the given member cluster from an HTTP request.
+func GetMemberDynamicClientFromRequest(request *http.Request, clusterName string) (dynamic.Interface, error) {
+	clients, err := clientsFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	member, err := clients.member(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return member.dynamic, nil
 }
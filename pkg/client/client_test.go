@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestClientsFromRequest(t *testing.T) {
+	initTestKarmadaConfig(t)
+
+	constructors := map[string]func(*http.Request) (interface{}, error){
+		"GetKarmadaClientFromRequest": func(r *http.Request) (interface{}, error) {
+			return GetKarmadaClientFromRequest(r)
+		},
+		"GetKarmadaConfigFromRequest": func(r *http.Request) (interface{}, error) {
+			return GetKarmadaConfigFromRequest(r)
+		},
+		"GetKubeClientFromRequest": func(r *http.Request) (interface{}, error) {
+			return GetKubeClientFromRequest(r)
+		},
+		"GetDynamicClientFromRequest": func(r *http.Request) (interface{}, error) {
+			return GetDynamicClientFromRequest(r)
+		},
+		"GetRESTMapperFromRequest": func(r *http.Request) (interface{}, error) {
+			return GetRESTMapperFromRequest(r)
+		},
+		"GetMemberClientFromRequest": func(r *http.Request) (interface{}, error) {
+			return GetMemberClientFromRequest(r, "member1")
+		},
+		"GetMemberDynamicClientFromRequest": func(r *http.Request) (interface{}, error) {
+			return GetMemberDynamicClientFromRequest(r, "member1")
+		},
+		"VerberClient": func(r *http.Request) (interface{}, error) {
+			return VerberClient(r)
+		},
+	}
+	cases := []struct {
+		name             string
+		headers          map[string][]string
+		wantUnauthorized bool
+	}{
+		{
+			name:             "without token",
+			wantUnauthorized: true,
+		},
+		{
+			name:    "with token",
+			headers: map[string][]string{authorizationHeader: {"Bearer my-token"}},
+		},
+	}
+	for _, c := range cases {
+		for fn, constructor := range constructors {
+			t.Run(c.name+"/"+fn, func(t *testing.T) {
+				obj, err := constructor(newTestRequest(c.headers))
+				if c.wantUnauthorized {
+					if !k8serrors.IsUnauthorized(err) {
+						t.Errorf("%s() error = %v, expected Unauthorized", fn, err)
+					}
+					return
+				}
+				if err != nil || obj == nil {
+					t.Errorf("%s() = %v, %v, expected a client", fn, obj, err)
+				}
+			})
+		}
+	}
+}
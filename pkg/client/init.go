@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
 	kubeclient "k8s.io/client-go/kubernetes"
@@ -61,6 +62,9 @@ type configBuilder struct {
 	kubeContext    string
 	insecure       bool
 	userAgent      string
+	// clientCacheSize 和 clientCacheTTL 是请求客户端缓存的容量和空闲过期时间
+	clientCacheSize int
+	clientCacheTTL  time.Duration
 }
 
 // Option 是 configBuilder 的配置选项
@@ -94,6 +98,14 @@ func WithInsecureTLSSkipVerify(insecure bool) Option {
 	}
 }
 
+// WithClientCache 是设置请求客户端缓存容量和空闲过期时间的选项
+func WithClientCache(size int, ttl time.Duration) Option {
+	return func(c *configBuilder) {
+		c.clientCacheSize = size
+		c.clientCacheTTL = ttl
+	}
+}
+
 // newConfigBuilder 是创建 configBuilder 的函数
 func newConfigBuilder(options ...Option) *configBuilder {
 	builder := &configBuilder{}
@@ -240,6 +252,8 @@ func InitKarmadaConfig(options ...Option) {
 		os.Exit(1)
 	}
 	karmadaMemberConfig = memberConfig
+
+	requestClientCache = newClientCache(builder.clientCacheSize, builder.clientCacheTTL)
 }
 
 // InClusterKarmadaClient 返回一个 Karmada 客户端
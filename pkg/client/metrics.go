@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const metricsSubsystem = "client_cache"
+
+var (
+	// clientCacheRequests 统计请求客户端缓存的命中和未命中次数
+	clientCacheRequests = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      "karmada_dashboard",
+			Subsystem:      metricsSubsystem,
+			Name:           "requests_total",
+			Help:           "Number of lookups of request-scoped clients, partitioned by result (hit or miss).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"result"},
+	)
+	// clientCacheEntries 记录当前缓存的用户身份数量
+	clientCacheEntries = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Namespace:      "karmada_dashboard",
+			Subsystem:      metricsSubsystem,
+			Name:           "entries",
+			Help:           "Number of user identities whose clients are cached.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+)
+
+func init() {
+	legacyregistry.MustRegister(clientCacheRequests, clientCacheEntries)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+)
+
+// DefaultRESTMapperTTL is the default time after which the discovery information of a RESTMapper is refreshed.
+// 默认的 RESTMapper 发现信息刷新间隔
+const DefaultRESTMapperTTL = 5 * time.Minute
+
+// minRefreshInterval 是因查找不到 kind 而刷新发现信息的最小间隔
+const minRefreshInterval = 10 * time.Second
+
+// expiringRESTMapper is a RESTMapper backed by cached discovery information. The information is dropped once it is
+// older than ttl, so that new CRDs and removed APIs are picked up, and it is also refreshed when a kind or resource
+// cannot be found, at most once per minRefreshInterval.
+// expiringRESTMapper 是基于缓存发现信息的 RESTMapper，定期或查找失败时刷新发现信息
+type expiringRESTMapper struct {
+	mapper *restmapper.DeferredDiscoveryRESTMapper
+	ttl    time.Duration
+	// lock 保护 loaded
+	lock sync.Mutex
+	// loaded 是发现信息上次被重置的时间
+	loaded time.Time
+	now    func() time.Time
+}
+
+var _ meta.RESTMapper = &expiringRESTMapper{}
+
+// newExpiringRESTMapper 创建基于缓存发现信息的 RESTMapper
+func newExpiringRESTMapper(discoveryClient discovery.DiscoveryInterface, ttl time.Duration) *expiringRESTMapper {
+	if ttl <= 0 {
+		ttl = DefaultRESTMapperTTL
+	}
+	m := &expiringRESTMapper{
+		mapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+		ttl:    ttl,
+		now:    time.Now,
+	}
+	m.loaded = m.now()
+	return m
+}
+
+// resetOlderThan 在发现信息早于 age 时重置发现信息，返回是否重置
+func (m *expiringRESTMapper) resetOlderThan(age time.Duration) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := m.now()
+	if now.Sub(m.loaded) < age {
+		return false
+	}
+	m.mapper.Reset()
+	m.loaded = now
+	return true
+}
+
+// do 使用底层的 RESTMapper 调用 fn，查找不到 kind 或资源时刷新发现信息后重试一次
+func (m *expiringRESTMapper) do(fn func(mapper meta.RESTMapper) error) error {
+	m.resetOlderThan(m.ttl)
+	err := fn(m.mapper)
+	if meta.IsNoMatchError(err) && m.resetOlderThan(minRefreshInterval) {
+		err = fn(m.mapper)
+	}
+	return err
+}
+
+// KindFor takes a partial resource and returns the single match.
+func (m *expiringRESTMapper) KindFor(resource schema.GroupVersionResource) (gvk schema.GroupVersionKind, err error) {
+	err = m.do(func(mapper meta.RESTMapper) error {
+		gvk, err = mapper.KindFor(resource)
+		return err
+	})
+	return gvk, err
+}
+
+// KindsFor takes a partial resource and returns the list of potential kinds in priority order.
+func (m *expiringRESTMapper) KindsFor(resource schema.GroupVersionResource) (gvks []schema.GroupVersionKind, err error) {
+	err = m.do(func(mapper meta.RESTMapper) error {
+		gvks, err = mapper.KindsFor(resource)
+		return err
+	})
+	return gvks, err
+}
+
+// ResourceFor takes a partial resource and returns the single match.
+func (m *expiringRESTMapper) ResourceFor(input schema.GroupVersionResource) (gvr schema.GroupVersionResource, err error) {
+	err = m.do(func(mapper meta.RESTMapper) error {
+		gvr, err = mapper.ResourceFor(input)
+		return err
+	})
+	return gvr, err
+}
+
+// ResourcesFor takes a partial resource and returns the list of potential resources in priority order.
+func (m *expiringRESTMapper) ResourcesFor(input schema.GroupVersionResource) (gvrs []schema.GroupVersionResource, err error) {
+	err = m.do(func(mapper meta.RESTMapper) error {
+		gvrs, err = mapper.ResourcesFor(input)
+		return err
+	})
+	return gvrs, err
+}
+
+// RESTMapping identifies a preferred resource mapping for the provided group kind.
+func (m *expiringRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (mapping *meta.RESTMapping, err error) {
+	err = m.do(func(mapper meta.RESTMapper) error {
+		mapping, err = mapper.RESTMapping(gk, versions...)
+		return err
+	})
+	return mapping, err
+}
+
+// RESTMappings returns the RESTMappings for the provided group kind in a rough internal preferred order.
+func (m *expiringRESTMapper) RESTMappings(gk schema.GroupKind, versions ...string) (mappings []*meta.RESTMapping, err error) {
+	err = m.do(func(mapper meta.RESTMapper) error {
+		mappings, err = mapper.RESTMappings(gk, versions...)
+		return err
+	})
+	return mappings, err
+}
+
+// ResourceSingularizer converts a resource name from plural to singular.
+func (m *expiringRESTMapper) ResourceSingularizer(resource string) (singular string, err error) {
+	err = m.do(func(mapper meta.RESTMapper) error {
+		singular, err = mapper.ResourceSingularizer(resource)
+		return err
+	})
+	return singular, err
+}
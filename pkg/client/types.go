@@ -17,6 +17,8 @@ limitations under the License.
 package client
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -46,6 +48,12 @@ const (
 	// 模拟用户额外头名称
 	// It is optional, and it requires ImpersonateUserHeader to be set.
 	ImpersonateUserExtraHeader = "Impersonate-Extra-"
+	// DefaultClientCacheSize is the default max number of user identities whose clients are cached.
+	// 默认缓存的用户身份数量上限
+	DefaultClientCacheSize = 256
+	// DefaultClientCacheTTL is the default time after which the clients of an idle user identity are evicted.
+	// 默认空闲用户身份客户端的过期时间
+	DefaultClientCacheTTL = 10 * time.Minute
 )
 
 // ResourceVerber 是负责对所有支持的资源执行通用 CRUD 操作的接口
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetUserFromRequest returns the user of the request as authenticated by Karmada apiserver with a
+// SelfSubjectReview. When the request impersonates another user, the impersonated user is returned. The result is
+// cached together with the clients of the user identity.
+// GetUserFromRequest 使用 SelfSubjectReview 获取 Karmada apiserver 认证的请求用户，模拟用户时返回被模拟的用户
+func GetUserFromRequest(request *http.Request) (*authenticationv1.UserInfo, error) {
+	clients, err := clientsFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	clients.userLock.Lock()
+	defer clients.userLock.Unlock()
+	if clients.user != nil {
+		return clients.user, nil
+	}
+	review, err := clients.kube.AuthenticationV1().SelfSubjectReviews().Create(context.TODO(),
+		&authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	clients.user = &review.Status.UserInfo
+	return clients.user, nil
+}
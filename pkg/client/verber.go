@@ -20,103 +20,61 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 
-	"github.com/gobuffalo/flect"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
-	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 )
 
-var (
-	// kindToGroupVersionResource 是 kind 到 GroupVersionResource 的映射
-	kindToGroupVersionResource = map[string]schema.GroupVersionResource{}
-)
-
 // resourceVerber 是一个负责对资源执行常见 CRUD 操作的结构体，例如 DELETE、PUT、UPDATE。
 type resourceVerber struct {
-	client    dynamic.Interface
-	discovery discovery.DiscoveryInterface
+	client dynamic.Interface
+	mapper meta.RESTMapper
 }
 
-// groupVersionResourceFromUnstructured 从 Unstructured 对象获取 GroupVersionResource
-func (v *resourceVerber) groupVersionResourceFromUnstructured(object *unstructured.Unstructured) schema.GroupVersionResource {
-	gvk := object.GetObjectKind().GroupVersionKind()
-
-	return schema.GroupVersionResource{
-		Group:    gvk.Group,
-		Version:  gvk.Version,
-		Resource: flect.Pluralize(strings.ToLower(gvk.Kind)),
+// mappingForUnstructured returns the mapping of the full GroupVersionKind of object.
+// mappingForUnstructured 根据对象完整的 GroupVersionKind 获取资源映射
+func (v *resourceVerber) mappingForUnstructured(object *unstructured.Unstructured) (*meta.RESTMapping, error) {
+	gvk := object.GroupVersionKind()
+	if gvk.Kind == "" || gvk.Version == "" {
+		return nil, fmt.Errorf("apiVersion and kind must be set on object %q", object.GetName())
 	}
+	return v.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 }
 
-// groupVersionResourceFromKind 从 kind 获取 GroupVersionResource
-func (v *resourceVerber) groupVersionResourceFromKind(kind string) (schema.GroupVersionResource, error) {
-	if gvr, exists := kindToGroupVersionResource[kind]; exists {
-		klog.V(3).InfoS("GroupVersionResource cache hit", "kind", kind)
-		return gvr, nil
-	}
-
-	klog.V(3).InfoS("GroupVersionResource cache miss", "kind", kind)
-	_, resourceList, err := v.discovery.ServerGroupsAndResources()
+// mappingForKind returns the mapping of kind, which is either a resource or a lowercase kind, optionally qualified
+// by its group, e.g. "deployment", "deployments.apps" or "propagationpolicies.policy.karmada.io".
+// mappingForKind 根据资源名称或小写的 kind（可以带有 group）获取资源映射
+func (v *resourceVerber) mappingForKind(kind string) (*meta.RESTMapping, error) {
+	gvr, err := v.mapper.ResourceFor(schema.ParseGroupResource(kind).WithVersion(""))
 	if err != nil {
-		return schema.GroupVersionResource{}, err
-	}
-
-	// Update cache
-	if err = v.buildGroupVersionResourceCache(resourceList); err != nil {
-		return schema.GroupVersionResource{}, err
+		return nil, err
 	}
-
-	if gvr, exists := kindToGroupVersionResource[kind]; exists {
-		return gvr, nil
+	gvk, err := v.mapper.KindFor(gvr)
+	if err != nil {
+		return nil, err
 	}
-
-	return schema.GroupVersionResource{}, fmt.Errorf("could not find GVR for kind %s", kind)
+	return v.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 }
 
-// buildGroupVersionResourceCache 构建 GroupVersionResource 缓存
-func (v *resourceVerber) buildGroupVersionResourceCache(resourceList []*metav1.APIResourceList) error {
-	for _, resource := range resourceList {
-		gv, err := schema.ParseGroupVersion(resource.GroupVersion)
-		if err != nil {
-			return err
-		}
-
-		for _, apiResource := range resource.APIResources {
-			crdKind := fmt.Sprintf("%s.%s", apiResource.Name, gv.Group)
-			gvr := schema.GroupVersionResource{
-				Group:    gv.Group,
-				Version:  gv.Version,
-				Resource: apiResource.Name,
-			}
-
-			// Ignore sub-resources. Top level resource names should not contain slash
-			if strings.Contains(apiResource.Name, "/") {
-				continue
-			}
-
-			// Mapping for core resources
-			kindToGroupVersionResource[strings.ToLower(apiResource.Kind)] = gvr
-
-			// Mapping for CRD resources with custom kind
-			kindToGroupVersionResource[crdKind] = gvr
-		}
+// resource 返回映射对应的资源接口，集群级别的资源忽略命名空间
+func (v *resourceVerber) resource(mapping *meta.RESTMapping, namespace string) dynamic.ResourceInterface {
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return v.client.Resource(mapping.Resource).Namespace(namespace)
 	}
-
-	return nil
+	return v.client.Resource(mapping.Resource)
 }
 
 // Delete 删除指定命名空间和名称的资源
 func (v *resourceVerber) Delete(kind string, namespace string, name string, deleteNow bool) error {
-	gvr, err := v.groupVersionResourceFromKind(kind)
+	mapping, err := v.mappingForKind(kind)
 	if err != nil {
 		return err
 	}
@@ -132,18 +90,23 @@ func (v *resourceVerber) Delete(kind string, namespace string, name string, dele
 		defaultDeleteOptions.GracePeriodSeconds = &gracePeriodSeconds
 	}
 
-	return v.client.Resource(gvr).Namespace(namespace).Delete(context.TODO(), name, defaultDeleteOptions)
+	return v.resource(mapping, namespace).Delete(context.TODO(), name, defaultDeleteOptions)
 }
 
 // Update 更新指定命名空间和名称的资源
 func (v *resourceVerber) Update(object *unstructured.Unstructured) error {
 	name := object.GetName()
 	namespace := object.GetNamespace()
-	gvr := v.groupVersionResourceFromUnstructured(object)
+	mapping, err := v.mappingForUnstructured(object)
+	if err != nil {
+		return err
+	}
+	gvr := mapping.Resource
+	resource := v.resource(mapping, namespace)
 
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		klog.V(2).InfoS("fetching latest resource version", "group", gvr.Group, "version", gvr.Version, "resource", gvr.Resource, "name", name, "namespace", namespace)
-		result, getErr := v.client.Resource(gvr).Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		result, getErr := resource.Get(context.TODO(), name, metav1.GetOptions{})
 		if getErr != nil {
 			return fmt.Errorf("failed to get latest %s version: %v", gvr.Resource, getErr)
 		}
@@ -166,49 +129,37 @@ func (v *resourceVerber) Update(object *unstructured.Unstructured) error {
 		}
 
 		klog.V(3).InfoS("patching resource", "group", gvr.Group, "version", gvr.Version, "resource", gvr.Resource, "name", name, "namespace", namespace, "patch", string(patchBytes))
-		_, updateErr := v.client.Resource(gvr).Namespace(namespace).Patch(context.TODO(), name, k8stypes.MergePatchType, patchBytes, metav1.PatchOptions{})
+		_, updateErr := resource.Patch(context.TODO(), name, k8stypes.MergePatchType, patchBytes, metav1.PatchOptions{})
 		return updateErr
 	})
 }
 
 // Get 获取指定命名空间和名称的资源
 func (v *resourceVerber) Get(kind string, namespace string, name string) (runtime.Object, error) {
-	gvr, err := v.groupVersionResourceFromKind(kind)
+	mapping, err := v.mappingForKind(kind)
 	if err != nil {
 		return nil, err
 	}
-	return v.client.Resource(gvr).Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	return v.resource(mapping, namespace).Get(context.TODO(), name, metav1.GetOptions{})
 }
 
 // Create 创建指定命名空间和名称的资源
 func (v *resourceVerber) Create(object *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	namespace := object.GetNamespace()
-	gvr := v.groupVersionResourceFromUnstructured(object)
-
-	return v.client.Resource(gvr).Namespace(namespace).Create(context.TODO(), object, metav1.CreateOptions{})
-}
-
-// VerberClient 返回一个 resourceVerber 客户端
-func VerberClient(_ *http.Request) (ResourceVerber, error) {
-	// todo currently ignore rest.config from http.Request
-	restConfig, _, err := GetKarmadaConfig()
-	if err != nil {
-		return nil, err
-	}
-	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	mapping, err := v.mappingForUnstructured(object)
 	if err != nil {
 		return nil, err
 	}
+	return v.resource(mapping, object.GetNamespace()).Create(context.TODO(), object, metav1.CreateOptions{})
+}
 
-	dynamicConfig := dynamic.ConfigFor(restConfig)
-
-	dynamicClient, err := dynamic.NewForConfig(dynamicConfig)
+// VerberClient 返回一个使用请求中令牌访问 Karmada API 服务器的 resourceVerber 客户端
+func VerberClient(request *http.Request) (ResourceVerber, error) {
+	clients, err := clientsFromRequest(request)
 	if err != nil {
 		return nil, err
 	}
-
 	return &resourceVerber{
-		client:    dynamicClient,
-		discovery: discoveryClient,
+		client: clients.dynamic,
+		mapper: clients.mapper,
 	}, nil
 }
@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+var (
+	appsResources = &metav1.APIResourceList{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "deployments", SingularName: "deployment", Namespaced: true, Kind: "Deployment"},
+			{Name: "deployments/status", Namespaced: true, Kind: "Deployment"},
+		},
+	}
+	policyResources = &metav1.APIResourceList{
+		GroupVersion: "policy.karmada.io/v1alpha1",
+		APIResources: []metav1.APIResource{
+			{Name: "propagationpolicies", SingularName: "propagationpolicy", Namespaced: true, Kind: "PropagationPolicy"},
+			{Name: "clusterpropagationpolicies", SingularName: "clusterpropagationpolicy", Kind: "ClusterPropagationPolicy"},
+		},
+	}
+	// crdResources 的复数形式无法通过规则推导
+	crdResources = &metav1.APIResourceList{
+		GroupVersion: "example.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "octopi", SingularName: "octopus", Namespaced: true, Kind: "Octopus"},
+		},
+	}
+)
+
+// newTestRESTMapper 创建使用假发现客户端的 RESTMapper
+func newTestRESTMapper(resources ...*metav1.APIResourceList) (*expiringRESTMapper, *fakediscovery.FakeDiscovery, *time.Time) {
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: resources}}
+	now := time.Now()
+	mapper := newExpiringRESTMapper(discoveryClient, time.Minute)
+	mapper.now = func() time.Time { return now }
+	mapper.loaded = now
+	return mapper, discoveryClient, &now
+}
+
+func TestMappingForKind(t *testing.T) {
+	mapper, _, _ := newTestRESTMapper(appsResources, policyResources, crdResources)
+	verber := &resourceVerber{mapper: mapper}
+	cases := []struct {
+		kind       string
+		wantGVR    schema.GroupVersionResource
+		namespaced bool
+	}{
+		{"deployment", schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, true},
+		{"deployments.apps", schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, true},
+		{"propagationpolicy", schema.GroupVersionResource{Group: "policy.karmada.io", Version: "v1alpha1", Resource: "propagationpolicies"}, true},
+		{"clusterpropagationpolicies.policy.karmada.io", schema.GroupVersionResource{Group: "policy.karmada.io", Version: "v1alpha1", Resource: "clusterpropagationpolicies"}, false},
+		{"octopus", schema.GroupVersionResource{Group: "example.io", Version: "v1", Resource: "octopi"}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.kind, func(t *testing.T) {
+			mapping, err := verber.mappingForKind(c.kind)
+			if err != nil {
+				t.Fatalf("mappingForKind(%q) unexpected error: %v", c.kind, err)
+			}
+			if mapping.Resource != c.wantGVR {
+				t.Errorf("mappingForKind(%q) = %v, expected %v", c.kind, mapping.Resource, c.wantGVR)
+			}
+			if namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace; namespaced != c.namespaced {
+				t.Errorf("mappingForKind(%q) namespaced = %v, expected %v", c.kind, namespaced, c.namespaced)
+			}
+		})
+	}
+}
+
+func TestMappingForUnstructured(t *testing.T) {
+	mapper, _, _ := newTestRESTMapper(crdResources)
+	verber := &resourceVerber{mapper: mapper}
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("example.io/v1")
+	obj.SetKind("Octopus")
+	mapping, err := verber.mappingForUnstructured(obj)
+	if err != nil {
+		t.Fatalf("mappingForUnstructured() unexpected error: %v", err)
+	}
+	if mapping.Resource.Resource != "octopi" {
+		t.Errorf("mappingForUnstructured() resource = %q, expected %q", mapping.Resource.Resource, "octopi")
+	}
+
+	obj.SetAPIVersion("example.io/v2")
+	if _, err = verber.mappingForUnstructured(obj); !meta.IsNoMatchError(err) {
+		t.Errorf("mappingForUnstructured() of an unknown version error = %v, expected a no match error", err)
+	}
+}
+
+func TestExpiringRESTMapperRefresh(t *testing.T) {
+	mapper, discoveryClient, now := newTestRESTMapper(appsResources)
+	octopus := schema.GroupKind{Group: "example.io", Kind: "Octopus"}
+	if _, err := mapper.RESTMapping(octopus); !meta.IsNoMatchError(err) {
+		t.Fatalf("RESTMapping() error = %v, expected a no match error", err)
+	}
+
+	// 新增的 CRD 在刷新间隔内不会被发现
+	discoveryClient.Resources = append(discoveryClient.Resources, crdResources)
+	*now = now.Add(minRefreshInterval / 2)
+	if _, err := mapper.RESTMapping(octopus); !meta.IsNoMatchError(err) {
+		t.Fatalf("RESTMapping() error = %v, expected a no match error", err)
+	}
+
+	// 超过刷新间隔后，查找失败会刷新发现信息
+	*now = now.Add(minRefreshInterval)
+	if _, err := mapper.RESTMapping(octopus); err != nil {
+		t.Fatalf("RESTMapping() unexpected error after refresh: %v", err)
+	}
+
+	// 发现信息过期后，已删除的 API 不再被映射
+	discoveryClient.Resources = []*metav1.APIResourceList{crdResources}
+	deployment := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	if _, err := mapper.RESTMapping(deployment); err != nil {
+		t.Fatalf("RESTMapping() unexpected error before expiry: %v", err)
+	}
+	*now = now.Add(time.Minute + time.Second)
+	if _, err := mapper.RESTMapping(deployment); !meta.IsNoMatchError(err) {
+		t.Errorf("RESTMapping() error after expiry = %v, expected a no match error", err)
+	}
+}
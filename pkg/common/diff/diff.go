@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diff renders line based differences of text, e.g. of two YAML manifests, in the unified format.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines 是每个变更块前后保留的未变更行数
+const contextLines = 3
+
+// maxTableSize 是计算最长公共子序列的表格的最大大小，超出时将整个文本视为被替换
+const maxTableSize = 4 << 20
+
+// opKind 是行的变更类型
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+// op 是一行的变更
+type op struct {
+	kind opKind
+	line string
+	// from 和 to 是该行在原文本和新文本中的行号，从 0 开始
+	from, to int
+}
+
+// Unified returns the differences between from and to in the unified format, with fromName and toName as the
+// names in the file headers. An empty string is returned when both texts are equal.
+// Unified 以统一格式（unified format）返回两段文本的差异，文本相同时返回空字符串
+func Unified(fromName, toName, from, to string) string {
+	if from == to {
+		return ""
+	}
+	ops := diffLines(splitLines(from), splitLines(to))
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+	for start := 0; start < len(ops); {
+		// 跳过未变更的行，找到下一个变更块
+		for start < len(ops) && ops[start].kind == opEqual {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+		begin := max(start-contextLines, 0)
+		end := start
+		// 相邻变更之间的未变更行不超过两倍上下文时合并为一个块
+		for end < len(ops) {
+			if ops[end].kind != opEqual {
+				end++
+				continue
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == opEqual {
+				next++
+			}
+			if next == len(ops) || next-end > 2*contextLines {
+				end = min(end+contextLines, len(ops))
+				break
+			}
+			end = next
+		}
+		writeHunk(&b, ops[begin:end])
+		start = end
+	}
+	return b.String()
+}
+
+// writeHunk 写入一个变更块
+func writeHunk(b *strings.Builder, ops []op) {
+	fromStart, toStart := -1, -1
+	fromCount, toCount := 0, 0
+	for _, o := range ops {
+		if o.kind != opInsert {
+			if fromStart < 0 {
+				fromStart = o.from
+			}
+			fromCount++
+		}
+		if o.kind != opDelete {
+			if toStart < 0 {
+				toStart = o.to
+			}
+			toCount++
+		}
+	}
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(fromStart, fromCount, ops[0].from), hunkRange(toStart, toCount, ops[0].to))
+	for _, o := range ops {
+		fmt.Fprintf(b, "%c%s\n", o.kind, o.line)
+	}
+}
+
+// hunkRange 返回变更块的行范围，空范围的起始行为前一行的行号
+func hunkRange(start, count, fallback int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", fallback)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines 将文本按行拆分，忽略末尾的换行符
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines 基于最长公共子序列计算两组行的变更
+func diffLines(a, b []string) []op {
+	if len(a)*len(b) > maxTableSize {
+		return replaceAll(a, b)
+	}
+	// lcs[i][j] 是 a[i:] 和 b[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	ops := make([]op, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, op{kind: opEqual, line: a[i], from: i, to: j})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, op{kind: opDelete, line: a[i], from: i, to: j})
+			i++
+		default:
+			ops = append(ops, op{kind: opInsert, line: b[j], from: i, to: j})
+			j++
+		}
+	}
+	return ops
+}
+
+// replaceAll 将原文本的所有行视为删除，将新文本的所有行视为插入
+func replaceAll(a, b []string) []op {
+	ops := make([]op, 0, len(a)+len(b))
+	for i, line := range a {
+		ops = append(ops, op{kind: opDelete, line: line, from: i})
+	}
+	for j, line := range b {
+		ops = append(ops, op{kind: opInsert, line: line, from: len(a), to: j})
+	}
+	return ops
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import "testing"
+
+func TestUnified(t *testing.T) {
+	cases := []struct {
+		name string
+		from string
+		to   string
+		want string
+	}{
+		{
+			name: "equal",
+			from: "a\nb\n",
+			to:   "a\nb\n",
+			want: "",
+		},
+		{
+			name: "created",
+			from: "",
+			to:   "a\nb\n",
+			want: "--- before\n+++ after\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "deleted",
+			from: "a\n",
+			to:   "",
+			want: "--- before\n+++ after\n@@ -1 +0,0 @@\n-a\n",
+		},
+		{
+			name: "changes far apart are separate hunks",
+			from: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			to:   "1\nTWO\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n",
+			want: "--- before\n+++ after\n" +
+				"@@ -1,5 +1,5 @@\n 1\n-2\n+TWO\n 3\n 4\n 5\n" +
+				"@@ -10,3 +10,4 @@\n 10\n 11\n 12\n+13\n",
+		},
+		{
+			name: "changes close together are one hunk",
+			from: "1\n2\n3\n4\n5\n",
+			to:   "ONE\n2\n3\n4\nFIVE\n",
+			want: "--- before\n+++ after\n@@ -1,5 +1,5 @@\n-1\n+ONE\n 2\n 3\n 4\n-5\n+FIVE\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Unified("before", "after", c.from, c.to); got != c.want {
+				t.Errorf("Unified() =\n%s\nexpected\n%s", got, c.want)
+			}
+		})
+	}
+}
@@ -15,6 +15,8 @@
 package errors
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -82,6 +84,11 @@ func NewNotFound(reason string) *k8serrors.StatusError {
 	}
 }
 
+// NewAlreadyExists returns an error indicating the resource already exists.
+func NewAlreadyExists(qualifiedResource schema.GroupResource, name string) *k8serrors.StatusError {
+	return k8serrors.NewAlreadyExists(qualifiedResource, name)
+}
+
 // NewInternal return a statusError
 // which is an error intended for consumption by a REST API server; it can also be
 // reconstructed by clients from a REST response. Public to allow easy type switches.
@@ -172,3 +179,22 @@ func NewGenericResponse(code int, serverMessage string) *k8serrors.StatusError {
 		Message: message,
 	}}
 }
+
+// NewBindingError returns a BadRequest error for an error of decoding a request body. When a JSON value has the
+// wrong type, the error has a cause whose field is the JSON path of the value, e.g. spec.placement.clusterAffinity.
+func NewBindingError(err error) *k8serrors.StatusError {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Field == "" {
+		return NewBadRequest(err.Error())
+	}
+	message := fmt.Sprintf("cannot decode %s into %s", typeErr.Value, typeErr.Type)
+	statusErr := NewBadRequest(fmt.Sprintf("%s: %s", typeErr.Field, message))
+	statusErr.ErrStatus.Details = &metav1.StatusDetails{
+		Causes: []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   typeErr.Field,
+		}},
+	}
+	return statusErr
+}
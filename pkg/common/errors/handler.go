@@ -20,6 +20,7 @@ import (
 
 	"github.com/emicklei/go-restful/v3"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 )
 
@@ -28,6 +29,7 @@ import (
 var nonCriticalErrors = []int32{http.StatusForbidden}
 
 // HandleError processes the incoming error and returns the corresponding HTTP status code and error message based on the error type.
+// The status code of errors carrying a Kubernetes Status is kept, other errors are reported as internal errors.
 func HandleError(err error) (int, error) {
 	if IsUnauthorized(err) {
 		return http.StatusUnauthorized, NewUnauthorized(MsgLoginUnauthorizedError)
@@ -37,7 +39,26 @@ func HandleError(err error) (int, error) {
 		return http.StatusForbidden, NewForbidden(MsgForbiddenError, err)
 	}
 
-	return http.StatusInternalServerError, err
+	return int(Status(err).Code), err
+}
+
+// Status returns the Kubernetes Status carried by err. Errors that don't carry a Status, i.e. errors that were
+// not returned by an apiserver or created by this package, are reported as an internal error.
+func Status(err error) metav1.Status {
+	var apiStatus k8sErrors.APIStatus
+	if errors.As(err, &apiStatus) {
+		status := apiStatus.Status()
+		if status.Code == 0 {
+			status.Code = http.StatusInternalServerError
+		}
+		return status
+	}
+	return metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusInternalServerError,
+		Reason:  metav1.StatusReasonInternalError,
+		Message: err.Error(),
+	}
 }
 
 // ExtractErrors handles single error, that occurred during API GET call. If it is not critical, then it will be
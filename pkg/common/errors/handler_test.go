@@ -0,0 +1,92 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+)
+
+func TestHandleError(t *testing.T) {
+	deployments := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	cases := []struct {
+		err            error
+		expectedCode   int
+		expectedReason metav1.StatusReason
+		expectedKey    string
+	}{
+		{
+			errors.NewUnauthorized(errors.MsgLoginUnauthorizedError),
+			http.StatusUnauthorized,
+			metav1.StatusReasonUnauthorized,
+			errors.MsgLoginUnauthorizedError,
+		},
+		{
+			k8serrors.NewForbidden(deployments, "nginx", fmt.Errorf("no permission")),
+			http.StatusForbidden,
+			metav1.StatusReasonForbidden,
+			errors.MsgForbiddenError,
+		},
+		{
+			k8serrors.NewNotFound(deployments, "nginx"),
+			http.StatusNotFound,
+			metav1.StatusReasonNotFound,
+			"",
+		},
+		{
+			k8serrors.NewConflict(deployments, "nginx", fmt.Errorf("the object has been modified")),
+			http.StatusConflict,
+			metav1.StatusReasonConflict,
+			"",
+		},
+		{
+			errors.NewBadRequest("the namespace of the provided object does not match the namespace sent on the request"),
+			http.StatusBadRequest,
+			metav1.StatusReasonBadRequest,
+			errors.MsgDeployNamespaceMismatchError,
+		},
+		{
+			fmt.Errorf("wrapped: %w", k8serrors.NewNotFound(deployments, "nginx")),
+			http.StatusNotFound,
+			metav1.StatusReasonNotFound,
+			"",
+		},
+		{
+			fmt.Errorf("some unknown error"),
+			http.StatusInternalServerError,
+			metav1.StatusReasonInternalError,
+			"",
+		},
+	}
+	for _, c := range cases {
+		code, _ := errors.HandleError(c.err)
+		if code != c.expectedCode {
+			t.Errorf("HandleError(%+v) code == %d, expected %d", c.err, code, c.expectedCode)
+		}
+		if reason := errors.Status(c.err).Reason; reason != c.expectedReason {
+			t.Errorf("Status(%+v).Reason == %q, expected %q", c.err, reason, c.expectedReason)
+		}
+		if key := errors.MessageKey(c.err); key != c.expectedKey {
+			t.Errorf("MessageKey(%+v) == %q, expected %q", c.err, key, c.expectedKey)
+		}
+	}
+}
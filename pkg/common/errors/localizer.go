@@ -44,6 +44,52 @@ var partialsToErrorsMap = map[string]string{
 	"the server has asked for the client to provide credentials": MsgLoginUnauthorizedError,
 }
 
+// errorsToMessagesMap maps the error codes above to a readable message, which is returned to the clients
+// that don't localize the error codes themselves.
+var errorsToMessagesMap = map[string]string{
+	MsgDeployNamespaceMismatchError:    "the namespace of the provided object does not match the namespace sent on the request",
+	MsgDeployEmptyNamespaceError:       "an empty namespace may not be set when a resource name is provided",
+	MsgLoginUnauthorizedError:          "the request is not authorized, please log in with a valid token",
+	MsgForbiddenError:                  "the user is not allowed to perform this action",
+	MsgDashboardExclusiveResourceError: "the resource is managed exclusively by the dashboard",
+	MsgTokenExpiredError:               "the token has expired, please log in again",
+	MsgCSRFValidationError:             "the CSRF token is invalid",
+}
+
+// Message returns a readable message for err. Errors that carry one of the error codes above are translated
+// to the matching message, others are returned as is.
+func Message(err error) string {
+	if err == nil {
+		return ""
+	}
+	if message, ok := errorsToMessagesMap[err.Error()]; ok {
+		return message
+	}
+	return err.Error()
+}
+
+// MessageKey returns the error code that frontend can use to localize err, or an empty string if err
+// cannot be mapped to any of the error codes above.
+func MessageKey(err error) string {
+	if err == nil {
+		return ""
+	}
+	if localized := LocalizeError(err); localized != nil {
+		if _, ok := errorsToMessagesMap[localized.Error()]; ok {
+			return localized.Error()
+		}
+	}
+	switch {
+	case IsTokenExpired(err):
+		return MsgTokenExpiredError
+	case IsUnauthorized(err):
+		return MsgLoginUnauthorizedError
+	case IsForbidden(err):
+		return MsgForbiddenError
+	}
+	return ""
+}
+
 // LocalizeError returns error code (string) that can be used by frontend to localize error message.
 func LocalizeError(err error) error {
 	if err == nil {
@@ -54,3 +54,29 @@ func areErrorsEqual(err1, err2 error) bool {
 	return (err1 != nil && err2 != nil && err1.Error() == err2.Error()) ||
 		(err1 == nil && err2 == nil)
 }
+
+func TestMessage(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{
+			nil,
+			"",
+		},
+		{
+			errors.NewUnauthorized(errors.MsgLoginUnauthorizedError),
+			"the request is not authorized, please log in with a valid token",
+		},
+		{
+			errors.NewInternal("some unknown error"),
+			"Internal error occurred: some unknown error",
+		},
+	}
+	for _, c := range cases {
+		actual := errors.Message(c.err)
+		if actual != c.expected {
+			t.Errorf("Message(%+v) == %q, expected %q", c.err, actual, c.expected)
+		}
+	}
+}
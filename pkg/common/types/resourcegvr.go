@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// List of Karmada resource kinds which are only used by the API server.
+const (
+	ResourceKindResourceBinding        = "resourcebinding"
+	ResourceKindClusterResourceBinding = "clusterresourcebinding"
+)
+
+// resourceAPI describes where a resource kind is served.
+type resourceAPI struct {
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+// resourceAPIs maps the resource kinds to the API they are served by.
+var resourceAPIs = map[ResourceKind]resourceAPI{
+	ResourceKindCluster:                  {schema.GroupVersionResource{Group: "cluster.karmada.io", Version: "v1alpha1", Resource: "clusters"}, false},
+	ResourceKindPropagationPolicy:        {schema.GroupVersionResource{Group: "policy.karmada.io", Version: "v1alpha1", Resource: "propagationpolicies"}, true},
+	ResourceKindClusterPropagationPolicy: {schema.GroupVersionResource{Group: "policy.karmada.io", Version: "v1alpha1", Resource: "clusterpropagationpolicies"}, false},
+	ResourceKindOverridePolicy:           {schema.GroupVersionResource{Group: "policy.karmada.io", Version: "v1alpha1", Resource: "overridepolicies"}, true},
+	ResourceKindClusterOverridePolicy:    {schema.GroupVersionResource{Group: "policy.karmada.io", Version: "v1alpha1", Resource: "clusteroverridepolicies"}, false},
+	ResourceKindResourceBinding:          {schema.GroupVersionResource{Group: "work.karmada.io", Version: "v1alpha2", Resource: "resourcebindings"}, true},
+	ResourceKindClusterResourceBinding:   {schema.GroupVersionResource{Group: "work.karmada.io", Version: "v1alpha2", Resource: "clusterresourcebindings"}, false},
+	ResourceKindConfigMap:                {schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, true},
+	ResourceKindDaemonSet:                {schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}, true},
+	ResourceKindDeployment:               {schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, true},
+	ResourceKindEvent:                    {schema.GroupVersionResource{Version: "v1", Resource: "events"}, true},
+	ResourceKindIngress:                  {schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}, true},
+	ResourceKindJob:                      {schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}, true},
+	ResourceKindCronJob:                  {schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}, true},
+	ResourceKindNamespace:                {schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, false},
+	ResourceKindNode:                     {schema.GroupVersionResource{Version: "v1", Resource: "nodes"}, false},
+	ResourceKindPod:                      {schema.GroupVersionResource{Version: "v1", Resource: "pods"}, true},
+	ResourceKindSecret:                   {schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, true},
+	ResourceKindService:                  {schema.GroupVersionResource{Version: "v1", Resource: "services"}, true},
+	ResourceKindStatefulSet:              {schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}, true},
+}
+
+// GroupVersionResource returns the GroupVersionResource that serves ResourceKind, and whether the kind is known.
+func (k ResourceKind) GroupVersionResource() (schema.GroupVersionResource, bool) {
+	api, ok := resourceAPIs[k]
+	return api.gvr, ok
+}
+
+// Namespaced returns whether ResourceKind is a namespaced resource.
+func (k ResourceKind) Namespaced() bool {
+	return resourceAPIs[k].namespaced
+}
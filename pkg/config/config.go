@@ -23,12 +23,15 @@ import (
 
 	"github.com/karmada-io/karmada/pkg/util/fedinformer"
 	"gopkg.in/yaml.v3"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
 )
 
 // dashboardConfig 是 dashboard 的配置
@@ -134,6 +137,33 @@ func UpdateDashboardConfig(k8sClient kubernetes.Interface, newDashboardConfig Da
 	return nil
 }
 
+// AuthorizeDashboardConfigUpdate checks whether the user behind userClient may update the dashboard ConfigMap.
+// The ConfigMap lives in the host cluster and is written with the dashboard's own identity, so the caller
+// is authorized against the same ConfigMap in Karmada apiserver with a SelfSubjectAccessReview first.
+// AuthorizeDashboardConfigUpdate 使用 SelfSubjectAccessReview 检查用户是否有权限更新 dashboard 配置
+func AuthorizeDashboardConfigUpdate(userClient kubernetes.Interface) error {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: configNamespace,
+				Verb:      "update",
+				Resource:  "configmaps",
+				Name:      configName,
+			},
+		},
+	}
+	result, err := userClient.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), review, metav1.CreateOptions{})
+	if err != nil {
+		klog.Errorf("Failed to review access to ConfigMap %s: %v", configName, err)
+		return err
+	}
+	if !result.Status.Allowed {
+		return errors.NewForbidden(errors.MsgForbiddenError,
+			fmt.Errorf("user cannot update configmap %s/%s: %s", configNamespace, configName, result.Status.Reason))
+	}
+	return nil
+}
+
 // InitDashboardConfigFromMountFile 从挂载的文件初始化 dashboard 配置
 func InitDashboardConfigFromMountFile(mountPath string) error {
 	_, err := os.Stat(mountPath)
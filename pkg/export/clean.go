@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// serverMetadataFields are the metadata fields that are set by the apiserver and must not be re-applied.
+var serverMetadataFields = []string{
+	"creationTimestamp",
+	"deletionGracePeriodSeconds",
+	"deletionTimestamp",
+	"generation",
+	"managedFields",
+	"resourceVersion",
+	"selfLink",
+	"uid",
+}
+
+// noisyAnnotations are the annotations written by clients and controllers which do not belong to a manifest.
+var noisyAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"deployment.kubernetes.io/revision",
+}
+
+// jobControllerLabels are the labels that the job controller adds to the pod template and the generated selector.
+var jobControllerLabels = []string{
+	"controller-uid",
+	"job-name",
+	"batch.kubernetes.io/controller-uid",
+	"batch.kubernetes.io/job-name",
+}
+
+// Clean strips everything from obj that was set by the apiserver, Karmada or other controllers, so that obj can
+// be committed to Git and re-applied to another control plane: the status, the server-set metadata, the labels and
+// annotations of the karmada.io domain, the cluster IPs of services and the generated selector of jobs.
+// Clean 去除对象中由 apiserver、Karmada 和其他控制器设置的字段，使对象可以被重新应用
+func Clean(obj *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(obj.Object, "status")
+	for _, field := range serverMetadataFields {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	obj.SetLabels(withoutKarmadaKeys(obj.GetLabels()))
+	annotations := withoutKarmadaKeys(obj.GetAnnotations())
+	for _, key := range noisyAnnotations {
+		delete(annotations, key)
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+
+	switch obj.GroupVersionKind().GroupKind().String() {
+	case "Service":
+		// ClusterIP 由 apiserver 分配，在另一个集群中可能已被占用
+		unstructured.RemoveNestedField(obj.Object, "spec", "clusterIP")
+		unstructured.RemoveNestedField(obj.Object, "spec", "clusterIPs")
+	case "Job.batch":
+		cleanJob(obj)
+	}
+}
+
+// cleanJob 去除 Job 控制器生成的选择器和 Pod 模板标签，除非 Job 使用了手动选择器
+func cleanJob(obj *unstructured.Unstructured) {
+	if manual, _, _ := unstructured.NestedBool(obj.Object, "spec", "manualSelector"); manual {
+		return
+	}
+	unstructured.RemoveNestedField(obj.Object, "spec", "selector")
+	for _, label := range jobControllerLabels {
+		unstructured.RemoveNestedField(obj.Object, "spec", "template", "metadata", "labels", label)
+	}
+	if labels, found, _ := unstructured.NestedMap(obj.Object, "spec", "template", "metadata", "labels"); found && len(labels) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "spec", "template", "metadata", "labels")
+	}
+}
+
+// withoutKarmadaKeys 返回去除了 karmada.io 域名下的键的副本，结果为空时返回 nil
+func withoutKarmadaKeys(values map[string]string) map[string]string {
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		if !isKarmadaKey(key) {
+			result[key] = value
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// isKarmadaKey 判断标签或注解的键是否属于 karmada.io 域名，例如 propagationpolicy.karmada.io/name
+func isKarmadaKey(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	return prefix == "karmada.io" || strings.HasSuffix(prefix, ".karmada.io")
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// parseObject 将 YAML 解析为 unstructured 对象
+func parseObject(t *testing.T, content string) *unstructured.Unstructured {
+	t.Helper()
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(content), &obj.Object); err != nil {
+		t.Fatalf("failed to parse object: %v", err)
+	}
+	return obj
+}
+
+func TestClean(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "deployment",
+			in: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  namespace: demo
+  uid: 0f5b1c8e
+  resourceVersion: "42"
+  generation: 3
+  creationTimestamp: "2024-01-01T00:00:00Z"
+  managedFields:
+  - manager: kubectl
+  labels:
+    app: nginx
+    propagationpolicy.karmada.io/permanent-id: 6d3c
+  annotations:
+    deployment.kubernetes.io/revision: "3"
+    propagationpolicy.karmada.io/name: nginx-pp
+    team: web
+spec:
+  replicas: 2
+status:
+  replicas: 2
+`,
+			want: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  namespace: demo
+  labels:
+    app: nginx
+  annotations:
+    team: web
+spec:
+  replicas: 2
+`,
+		},
+		{
+			name: "service",
+			in: `apiVersion: v1
+kind: Service
+metadata:
+  name: nginx
+  namespace: demo
+  annotations:
+    resourcetemplate.karmada.io/generation: "1"
+spec:
+  clusterIP: 10.0.0.12
+  clusterIPs:
+  - 10.0.0.12
+  ports:
+  - port: 80
+`,
+			want: `apiVersion: v1
+kind: Service
+metadata:
+  name: nginx
+  namespace: demo
+spec:
+  ports:
+  - port: 80
+`,
+		},
+		{
+			name: "job with generated selector",
+			in: `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: pi
+  namespace: demo
+spec:
+  selector:
+    matchLabels:
+      batch.kubernetes.io/controller-uid: 8f2d
+  template:
+    metadata:
+      labels:
+        batch.kubernetes.io/controller-uid: 8f2d
+        batch.kubernetes.io/job-name: pi
+        controller-uid: 8f2d
+        job-name: pi
+`,
+			want: `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: pi
+  namespace: demo
+spec:
+  template:
+    metadata: {}
+`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := parseObject(t, c.in)
+			Clean(got)
+			want := parseObject(t, c.want)
+			if !reflect.DeepEqual(got.Object, want.Object) {
+				gotYAML, _ := yaml.Marshal(got.Object)
+				t.Errorf("Clean() =\n%s\nexpected\n%s", gotYAML, c.want)
+			}
+		})
+	}
+}
+
+func TestIsKarmadaKey(t *testing.T) {
+	cases := map[string]bool{
+		"propagationpolicy.karmada.io/name": true,
+		"karmada.io/managed":                true,
+		"app":                               false,
+		"app.kubernetes.io/name":            false,
+		"notkarmada.io/name":                false,
+	}
+	for key, want := range cases {
+		if got := isKarmadaKey(key); got != want {
+			t.Errorf("isKarmadaKey(%q) = %v, expected %v", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/informer"
+)
+
+// DefaultKinds are the kinds exported when no kind is given. Secrets are only exported on request.
+var DefaultKinds = []string{
+	"deployment", "statefulset", "daemonset", "job", "cronjob",
+	"service", "ingress", "configmap",
+}
+
+// systemNamespacePrefixes 是导出所有命名空间时跳过的系统命名空间前缀
+var systemNamespacePrefixes = []string{"kube-", "karmada-"}
+
+// Options holds the selection of the resources to export.
+// Options 保存要导出的资源的选择条件
+type Options struct {
+	// Namespace restricts the namespaced resources to one namespace. When empty, all namespaces except the system
+	// namespaces of Kubernetes and Karmada are exported.
+	Namespace string
+	// Kinds are the kinds or resources to export, optionally qualified by their group, e.g. "deployment" or
+	// "octopi.example.io". DefaultKinds is used when empty.
+	Kinds []string
+	// LabelSelector restricts the resources to the ones matching the selector.
+	LabelSelector string
+	// IncludePolicies adds the PropagationPolicies and OverridePolicies of the exported resources.
+	IncludePolicies bool
+}
+
+// Policies holds the policies that the exported resources are matched against.
+// Policies 保存用于匹配导出资源的策略
+type Policies struct {
+	PropagationPolicies        []*policyv1alpha1.PropagationPolicy
+	ClusterPropagationPolicies []*policyv1alpha1.ClusterPropagationPolicy
+	OverridePolicies           []*policyv1alpha1.OverridePolicy
+	ClusterOverridePolicies    []*policyv1alpha1.ClusterOverridePolicy
+}
+
+// PoliciesFromListers reads the policies that may apply to resources in namespace from the informer cache. All
+// namespaced policies are read when namespace is empty.
+// PoliciesFromListers 从 informer 缓存中读取可能作用于命名空间中资源的策略
+func PoliciesFromListers(listers *informer.KarmadaListers, namespace string) (*Policies, error) {
+	pps, err := listers.PropagationPolicies.PropagationPolicies(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	cpps, err := listers.ClusterPropagationPolicies.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	ops, err := listers.OverridePolicies.OverridePolicies(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	cops, err := listers.ClusterOverridePolicies.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return &Policies{
+		PropagationPolicies:        pps,
+		ClusterPropagationPolicies: cpps,
+		OverridePolicies:           ops,
+		ClusterOverridePolicies:    cops,
+	}, nil
+}
+
+// Collect lists the selected resources and, if requested, the policies that apply to them. The returned objects
+// are cleaned with Clean and sorted so that namespaces come first and policies last.
+// Collect 列出选择的资源及其策略，返回的对象已被清理并排序
+func Collect(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, policies *Policies, opts Options) ([]*unstructured.Unstructured, error) {
+	kinds := opts.Kinds
+	if len(kinds) == 0 {
+		kinds = DefaultKinds
+	}
+	var objs []*unstructured.Unstructured
+	for _, kind := range kinds {
+		mapping, err := mappingForKind(mapper, kind)
+		if err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("unknown kind %q: %v", kind, err))
+		}
+		resource := dynamic.ResourceInterface(client.Resource(mapping.Resource))
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			resource = client.Resource(mapping.Resource).Namespace(opts.Namespace)
+		}
+		list, err := resource.List(ctx, metav1.ListOptions{LabelSelector: opts.LabelSelector})
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			if obj := &list.Items[i]; !skip(obj, opts.Namespace) {
+				objs = append(objs, obj)
+			}
+		}
+	}
+
+	if opts.IncludePolicies && policies != nil {
+		matched, err := matchPolicies(objs, policies)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, matched...)
+	}
+	for _, obj := range objs {
+		Clean(obj)
+	}
+	sortObjects(objs)
+	return objs, nil
+}
+
+// mappingForKind 根据资源名称或小写的 kind（可以带有 group）获取资源映射
+func mappingForKind(mapper meta.RESTMapper, kind string) (*meta.RESTMapping, error) {
+	gvr, err := mapper.ResourceFor(schema.ParseGroupResource(strings.TrimSpace(kind)).WithVersion(""))
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := mapper.KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+}
+
+// skip reports whether obj should be left out of the export: objects owned by another object are recreated by
+// their owner, and the objects that Kubernetes creates in every namespace do not belong to the user.
+// skip 判断对象是否不应被导出
+func skip(obj *unstructured.Unstructured, namespace string) bool {
+	if len(obj.GetOwnerReferences()) > 0 {
+		return true
+	}
+	if namespace == "" && obj.GetNamespace() != "" {
+		for _, prefix := range systemNamespacePrefixes {
+			if strings.HasPrefix(obj.GetNamespace(), prefix) {
+				return true
+			}
+		}
+	}
+	switch obj.GroupVersionKind().GroupKind().String() {
+	case "ConfigMap":
+		return obj.GetName() == "kube-root-ca.crt"
+	case "Service":
+		return obj.GetNamespace() == metav1.NamespaceDefault && obj.GetName() == "kubernetes"
+	case "Secret":
+		secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+		return secretType == string(corev1.SecretTypeServiceAccountToken)
+	}
+	return false
+}
+
+// matchPolicies returns the policies that apply to objs. The PropagationPolicy or ClusterPropagationPolicy that
+// claimed a resource is taken from its annotations, the policies whose selectors match are used for resources that
+// have not been claimed yet. OverridePolicies apply to every resource they match.
+// matchPolicies 返回作用于导出资源的策略
+func matchPolicies(objs []*unstructured.Unstructured, policies *Policies) ([]*unstructured.Unstructured, error) {
+	matched := map[schema.GroupVersionKind]map[string]runtime.Object{}
+	add := func(gvk schema.GroupVersionKind, obj metav1.Object, policy runtime.Object) {
+		if matched[gvk] == nil {
+			matched[gvk] = map[string]runtime.Object{}
+		}
+		matched[gvk][obj.GetNamespace()+"/"+obj.GetName()] = policy
+	}
+
+	for _, obj := range objs {
+		annotations := obj.GetAnnotations()
+		claimedPP := annotations[policyv1alpha1.PropagationPolicyNameAnnotation]
+		claimedCPP := annotations[policyv1alpha1.ClusterPropagationPolicyAnnotation]
+		claimed := claimedPP != "" || claimedCPP != ""
+		for _, pp := range policies.PropagationPolicies {
+			if pp.Namespace != obj.GetNamespace() {
+				continue
+			}
+			if (claimed && pp.Name == claimedPP) || (!claimed && karmadautil.ResourceMatchSelectors(obj, pp.Spec.ResourceSelectors...)) {
+				add(policyv1alpha1.SchemeGroupVersion.WithKind(policyv1alpha1.ResourceKindPropagationPolicy), pp, pp)
+			}
+		}
+		for _, cpp := range policies.ClusterPropagationPolicies {
+			if (claimed && cpp.Name == claimedCPP) || (!claimed && karmadautil.ResourceMatchSelectors(obj, cpp.Spec.ResourceSelectors...)) {
+				add(policyv1alpha1.SchemeGroupVersion.WithKind(policyv1alpha1.ResourceKindClusterPropagationPolicy), cpp, cpp)
+			}
+		}
+		for _, op := range policies.OverridePolicies {
+			if op.Namespace == obj.GetNamespace() && overrideMatches(obj, op.Spec.ResourceSelectors) {
+				add(policyv1alpha1.SchemeGroupVersion.WithKind(policyv1alpha1.ResourceKindOverridePolicy), op, op)
+			}
+		}
+		for _, cop := range policies.ClusterOverridePolicies {
+			if overrideMatches(obj, cop.Spec.ResourceSelectors) {
+				add(policyv1alpha1.SchemeGroupVersion.WithKind(policyv1alpha1.ResourceKindClusterOverridePolicy), cop, cop)
+			}
+		}
+	}
+
+	var result []*unstructured.Unstructured
+	for gvk, byName := range matched {
+		for _, policy := range byName {
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policy)
+			if err != nil {
+				return nil, err
+			}
+			obj := &unstructured.Unstructured{Object: content}
+			obj.SetGroupVersionKind(gvk)
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
+
+// overrideMatches 判断覆盖策略的资源选择器是否匹配对象，选择器为空时匹配所有资源
+func overrideMatches(obj *unstructured.Unstructured, selectors []policyv1alpha1.ResourceSelector) bool {
+	return len(selectors) == 0 || karmadautil.ResourceMatchSelectors(obj, selectors...)
+}
+
+// sortObjects 按命名空间、普通资源、策略的顺序排序，同类对象按 kind、命名空间和名称排序
+func sortObjects(objs []*unstructured.Unstructured) {
+	rank := func(obj *unstructured.Unstructured) int {
+		switch {
+		case obj.GroupVersionKind().GroupKind().String() == "Namespace":
+			return 0
+		case obj.GroupVersionKind().Group == policyv1alpha1.GroupName:
+			return 2
+		default:
+			return 1
+		}
+	}
+	sort.SliceStable(objs, func(i, j int) bool {
+		a, b := objs[i], objs[j]
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		if a.GetKind() != b.GetKind() {
+			return a.GetKind() < b.GetKind()
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+}
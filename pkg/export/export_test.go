@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMatchPolicies(t *testing.T) {
+	deploymentSelector := policyv1alpha1.ResourceSelector{APIVersion: "apps/v1", Kind: "Deployment"}
+	policies := &Policies{
+		PropagationPolicies: []*policyv1alpha1.PropagationPolicy{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "claimed", Namespace: "demo"},
+				Spec:       policyv1alpha1.PropagationSpec{ResourceSelectors: []policyv1alpha1.ResourceSelector{deploymentSelector}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "also-matching", Namespace: "demo"},
+				Spec:       policyv1alpha1.PropagationSpec{ResourceSelectors: []policyv1alpha1.ResourceSelector{deploymentSelector}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "services", Namespace: "demo"},
+				Spec: policyv1alpha1.PropagationSpec{ResourceSelectors: []policyv1alpha1.ResourceSelector{
+					{APIVersion: "v1", Kind: "Service"},
+				}},
+			},
+		},
+		OverridePolicies: []*policyv1alpha1.OverridePolicy{
+			{ObjectMeta: metav1.ObjectMeta{Name: "all-in-demo", Namespace: "demo"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "all-in-other", Namespace: "other"}},
+		},
+	}
+	deployment := parseObject(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  namespace: demo
+  annotations:
+    propagationpolicy.karmada.io/namespace: demo
+    propagationpolicy.karmada.io/name: claimed
+`)
+	service := parseObject(t, `apiVersion: v1
+kind: Service
+metadata:
+  name: nginx
+  namespace: demo
+`)
+
+	matched, err := matchPolicies([]*unstructured.Unstructured{deployment, service}, policies)
+	if err != nil {
+		t.Fatalf("matchPolicies() unexpected error: %v", err)
+	}
+	var got []string
+	for _, obj := range matched {
+		if obj.GetAPIVersion() != "policy.karmada.io/v1alpha1" {
+			t.Errorf("policy %s has apiVersion %q", obj.GetName(), obj.GetAPIVersion())
+		}
+		got = append(got, obj.GetKind()+"/"+obj.GetName())
+	}
+	sort.Strings(got)
+	want := []string{"OverridePolicy/all-in-demo", "PropagationPolicy/claimed", "PropagationPolicy/services"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("matchPolicies() = %v, expected %v", got, want)
+	}
+}
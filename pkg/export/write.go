@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+)
+
+// Format is the output format of an export.
+// Format 是导出的输出格式
+type Format string
+
+const (
+	// FormatYAML writes all objects to one multi-document YAML file.
+	FormatYAML Format = "yaml"
+	// FormatTar writes one YAML file per object to a tar archive.
+	FormatTar Format = "tar"
+	// FormatZip writes one YAML file per object to a zip archive.
+	FormatZip Format = "zip"
+)
+
+// clusterScopedDir 是归档中集群级对象所在的目录
+const clusterScopedDir = "_cluster"
+
+// ParseFormat parses the format of an export, an empty string is parsed as FormatYAML.
+// ParseFormat 解析导出格式，空字符串解析为 FormatYAML
+func ParseFormat(format string) (Format, error) {
+	switch f := Format(strings.ToLower(format)); f {
+	case "":
+		return FormatYAML, nil
+	case FormatYAML, FormatTar, FormatZip:
+		return f, nil
+	default:
+		return "", errors.NewBadRequest(fmt.Sprintf("unsupported export format %q, expected one of yaml, tar or zip", format))
+	}
+}
+
+// ContentType returns the MIME type of the format.
+// ContentType 返回格式的 MIME 类型
+func (f Format) ContentType() string {
+	switch f {
+	case FormatTar:
+		return "application/x-tar"
+	case FormatZip:
+		return "application/zip"
+	default:
+		return "application/yaml"
+	}
+}
+
+// Write writes objs to w in the given format.
+// Write 将对象以指定格式写入 w
+func Write(w io.Writer, format Format, objs []*unstructured.Unstructured) error {
+	switch format {
+	case FormatTar:
+		return writeTar(w, objs)
+	case FormatZip:
+		return writeZip(w, objs)
+	default:
+		return writeYAML(w, objs)
+	}
+}
+
+// writeYAML 将对象写为以 --- 分隔的多文档 YAML
+func writeYAML(w io.Writer, objs []*unstructured.Unstructured) error {
+	for i, obj := range objs {
+		content, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err = io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err = w.Write(content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTar 将每个对象写为 tar 归档中的一个 YAML 文件
+func writeTar(w io.Writer, objs []*unstructured.Unstructured) error {
+	tw := tar.NewWriter(w)
+	now := time.Now()
+	for _, obj := range objs {
+		content, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{
+			Name:    fileName(obj),
+			Mode:    0o644,
+			Size:    int64(len(content)),
+			ModTime: now,
+		}
+		if err = tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err = tw.Write(content); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// writeZip 将每个对象写为 zip 归档中的一个 YAML 文件
+func writeZip(w io.Writer, objs []*unstructured.Unstructured) error {
+	zw := zip.NewWriter(w)
+	for _, obj := range objs {
+		content, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		fw, err := zw.Create(fileName(obj))
+		if err != nil {
+			return err
+		}
+		if _, err = fw.Write(content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// fileName 返回对象在归档中的路径，例如 demo/deployment-nginx.yaml 或 _cluster/clusterpropagationpolicy-default.yaml
+func fileName(obj *unstructured.Unstructured) string {
+	dir := obj.GetNamespace()
+	if dir == "" {
+		dir = clusterScopedDir
+	}
+	return path.Join(dir, fmt.Sprintf("%s-%s.yaml", strings.ToLower(obj.GetKind()), obj.GetName()))
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"math"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Availability is how long a cluster was Ready in a time window. A cluster that flaps has many transitions and short
+// outages, a cluster that is really down has a long outage.
+// Availability 是集群在时间窗口内的可用性，频繁抖动的集群变化次数多且中断时间短，真正宕机的集群中断时间长
+type Availability struct {
+	// Since 和 Until 是时间窗口
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+	// Percentage 是已知状态的时间中集群 Ready 的时间占比，窗口内状态未知时为空
+	Percentage *float64 `json:"percentage"`
+	// ReadySeconds、NotReadySeconds 和 UnknownSeconds 是集群 Ready、非 Ready 和没有记录的时长
+	ReadySeconds    int64 `json:"readySeconds"`
+	NotReadySeconds int64 `json:"notReadySeconds"`
+	UnknownSeconds  int64 `json:"unknownSeconds"`
+	// Transitions 是 Ready 条件在窗口内的变化次数
+	Transitions int `json:"transitions"`
+	// LongestOutageSeconds 是窗口内最长的一次非 Ready 时长
+	LongestOutageSeconds int64 `json:"longestOutageSeconds"`
+}
+
+// computeAvailability 根据窗口开始前最后一条 Ready 记录和窗口内的记录计算可用性，没有记录的时间计为未知
+func computeAvailability(initial *Event, events []Event, since, until time.Time) *Availability {
+	availability := &Availability{Since: since, Until: until}
+	var ready, notReady, unknown, outage, longestOutage time.Duration
+	state := ""
+	if initial != nil {
+		state = initial.Value
+	}
+	last := since
+	advance := func(to time.Time) {
+		if to.Before(last) {
+			return
+		}
+		elapsed := to.Sub(last)
+		switch state {
+		case "":
+			unknown += elapsed
+		case string(metav1.ConditionTrue):
+			ready += elapsed
+		default:
+			notReady += elapsed
+			outage += elapsed
+			longestOutage = max(longestOutage, outage)
+		}
+		last = to
+	}
+	for _, event := range events {
+		if event.Type != EventTypeCondition || event.Name != clusterv1alpha1.ClusterConditionReady || event.Time.Before(since) || !event.Time.Before(until) {
+			continue
+		}
+		advance(event.Time)
+		if state != "" && event.Value != state {
+			availability.Transitions++
+		}
+		if event.Value == string(metav1.ConditionTrue) {
+			outage = 0
+		}
+		state = event.Value
+	}
+	advance(until)
+
+	availability.ReadySeconds = int64(ready / time.Second)
+	availability.NotReadySeconds = int64(notReady / time.Second)
+	availability.UnknownSeconds = int64(unknown / time.Second)
+	availability.LongestOutageSeconds = int64(longestOutage / time.Second)
+	if known := ready + notReady; known > 0 {
+		percentage := math.Round(float64(ready)/float64(known)*10000) / 100
+		availability.Percentage = &percentage
+	}
+	return availability
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package history records how the status of the member clusters changes over time.
+package history
+
+import (
+	"sync"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// EventType is the kind of change of a cluster that an Event records.
+// EventType 是历史记录的集群变化类型
+type EventType string
+
+const (
+	// EventTypeCondition records a change of the status of a cluster condition.
+	EventTypeCondition EventType = "Condition"
+	// EventTypeKubernetesVersion records a change of the Kubernetes version of a cluster.
+	EventTypeKubernetesVersion EventType = "KubernetesVersion"
+	// EventTypeResourceSummary records a snapshot of the resource summary of a cluster.
+	EventTypeResourceSummary EventType = "ResourceSummary"
+)
+
+// Event is one recorded change of the status of a cluster.
+// Event 是一条集群状态变化的历史记录
+type Event struct {
+	// Time 是变化发生的时间
+	Time time.Time `json:"time"`
+	// Cluster 是集群名称
+	Cluster string `json:"cluster"`
+	// Type 是变化的类型
+	Type EventType `json:"type"`
+	// Name 是条件的类型，例如 Ready，其他类型的记录为空
+	Name string `json:"name,omitempty"`
+	// Value 是条件的状态或 Kubernetes 版本
+	Value string `json:"value,omitempty"`
+	// Previous 是变化前的条件状态或 Kubernetes 版本，首次记录时为空
+	Previous string `json:"previous,omitempty"`
+	// Reason 和 Message 是条件的原因和说明
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	// ResourceSummary 是集群资源概况的快照
+	ResourceSummary *clusterv1alpha1.ResourceSummary `json:"resourceSummary,omitempty"`
+}
+
+// key 返回记录所属的序列，同一序列中的记录依次变化
+func (e *Event) key() string {
+	return string(e.Type) + "/" + e.Name
+}
+
+// Store persists the events of the clusters.
+// Store 保存集群的历史记录
+type Store interface {
+	// Append stores one event.
+	Append(event *Event) error
+	// Events returns the events of cluster in [since, until), oldest first.
+	Events(cluster string, since, until time.Time) ([]Event, error)
+	// Latest returns the latest event of each type and name of cluster before the given time.
+	Latest(cluster string, before time.Time) ([]Event, error)
+	// Prune deletes the events before the given time, except the latest event of each type and name of a cluster,
+	// which tells the status of the cluster at that time.
+	Prune(before time.Time) error
+	// Close releases the resources of the store.
+	Close() error
+}
+
+var (
+	// lock 保护 recorder
+	lock sync.RWMutex
+	// recorder 是用于查询历史记录的 Recorder
+	recorder *Recorder
+)
+
+// Init sets the recorder that serves the queries of the cluster history.
+// Init 设置用于查询集群历史记录的 Recorder
+func Init(r *Recorder) {
+	lock.Lock()
+	defer lock.Unlock()
+	recorder = r
+}
+
+// current 返回 Init 设置的 Recorder，未设置时返回 ServiceUnavailable 错误
+func current() (*Recorder, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	if recorder == nil {
+		return nil, errors.NewServiceUnavailable("the cluster history is not recorded")
+	}
+	return recorder, nil
+}
+
+// Timeline returns the events of cluster in [since, until), oldest first.
+// Timeline 返回集群在时间窗口内的历史记录
+func Timeline(cluster string, since, until time.Time) ([]Event, error) {
+	r, err := current()
+	if err != nil {
+		return nil, err
+	}
+	return r.store.Events(cluster, since, until)
+}
+
+// ClusterAvailability returns how long cluster was Ready in [since, until).
+// ClusterAvailability 返回集群在时间窗口内的可用性
+func ClusterAvailability(cluster string, since, until time.Time) (*Availability, error) {
+	r, err := current()
+	if err != nil {
+		return nil, err
+	}
+	latest, err := r.store.Latest(cluster, since)
+	if err != nil {
+		return nil, err
+	}
+	events, err := r.store.Events(cluster, since, until)
+	if err != nil {
+		return nil, err
+	}
+	var initial *Event
+	for i := range latest {
+		if latest[i].Type == EventTypeCondition && latest[i].Name == clusterv1alpha1.ClusterConditionReady {
+			initial = &latest[i]
+		}
+	}
+	return computeAvailability(initial, events, since, until), nil
+}
+
+// Retention returns how long the events are kept.
+// Retention 返回历史记录的保留时间
+func Retention() time.Duration {
+	r, err := current()
+	if err != nil {
+		return 0
+	}
+	return r.retention
+}
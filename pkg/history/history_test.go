@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// testCluster 返回 Ready 条件为 status 的集群
+func testCluster(status metav1.ConditionStatus, version string, cpu string) *clusterv1alpha1.Cluster {
+	return &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "member1"},
+		Status: clusterv1alpha1.ClusterStatus{
+			KubernetesVersion: version,
+			Conditions:        []metav1.Condition{{Type: clusterv1alpha1.ClusterConditionReady, Status: status}},
+			ResourceSummary: &clusterv1alpha1.ResourceSummary{
+				Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			},
+		},
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	store := NewMemoryStore()
+	r := NewRecorder(store, time.Hour)
+	r.now = func() time.Time { return now }
+
+	r.OnAdd(testCluster(metav1.ConditionTrue, "v1.30.0", "8"), true)
+	now = start.Add(time.Minute)
+	r.OnUpdate(nil, testCluster(metav1.ConditionTrue, "v1.30.0", "4"))
+	now = start.Add(2 * time.Minute)
+	r.OnUpdate(nil, testCluster(metav1.ConditionFalse, "v1.30.1", "4"))
+	now = start.Add(20 * time.Minute)
+	r.OnUpdate(nil, testCluster(metav1.ConditionFalse, "v1.30.1", "4"))
+
+	events, err := store.Events("member1", start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, event := range events {
+		got = append(got, string(event.Type)+"="+event.Value)
+	}
+	// 资源概况在快照间隔内的变化和没有变化的状态不被记录
+	want := []string{"Condition=True", "KubernetesVersion=v1.30.0", "ResourceSummary=", "Condition=False", "KubernetesVersion=v1.30.1", "ResourceSummary="}
+	if len(got) != len(want) {
+		t.Fatalf("recorded %v, expected %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("recorded %v, expected %v", got, want)
+		}
+	}
+	if events[3].Previous != string(metav1.ConditionTrue) {
+		t.Errorf("the transition to False should record the previous status, got %q", events[3].Previous)
+	}
+
+	// 重新创建的 Recorder 从 Store 中加载最后的状态，不重复记录
+	r = NewRecorder(store, time.Hour)
+	r.now = func() time.Time { return now }
+	r.OnAdd(testCluster(metav1.ConditionFalse, "v1.30.1", "4"), true)
+	if events, _ = store.Events("member1", start, start.Add(time.Hour)); len(events) != len(want) {
+		t.Errorf("restarted recorder recorded %d events, expected %d", len(events), len(want))
+	}
+
+	if err = store.Prune(start.Add(30 * time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if latest, _ := store.Latest("member1", now.Add(time.Minute)); len(latest) != 3 {
+		t.Errorf("Prune() should keep the latest event of each type, got %v", latest)
+	}
+}
+
+func TestComputeAvailability(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(100 * time.Minute)
+	ready := func(minute int, status metav1.ConditionStatus) Event {
+		return Event{Time: since.Add(time.Duration(minute) * time.Minute), Type: EventTypeCondition,
+			Name: clusterv1alpha1.ClusterConditionReady, Value: string(status)}
+	}
+	initial := ready(-10, metav1.ConditionTrue)
+	events := []Event{
+		ready(10, metav1.ConditionFalse),
+		ready(15, metav1.ConditionUnknown),
+		ready(30, metav1.ConditionTrue),
+		ready(50, metav1.ConditionFalse),
+		ready(55, metav1.ConditionTrue),
+	}
+
+	availability := computeAvailability(&initial, events, since, until)
+	if availability.ReadySeconds != 75*60 || availability.NotReadySeconds != 25*60 || availability.UnknownSeconds != 0 {
+		t.Errorf("computeAvailability() = %+v", availability)
+	}
+	if availability.Percentage == nil || *availability.Percentage != 75 {
+		t.Errorf("computeAvailability() percentage = %v, expected 75", availability.Percentage)
+	}
+	if availability.Transitions != 5 || availability.LongestOutageSeconds != 20*60 {
+		t.Errorf("computeAvailability() transitions = %d, longest outage = %ds", availability.Transitions, availability.LongestOutageSeconds)
+	}
+
+	// 没有窗口开始前的记录时，第一条记录之前的时间计为未知
+	availability = computeAvailability(nil, events, since, until)
+	if availability.UnknownSeconds != 10*60 || availability.Transitions != 4 {
+		t.Errorf("computeAvailability() without initial status = %+v", availability)
+	}
+	if availability = computeAvailability(nil, nil, since, until); availability.Percentage != nil {
+		t.Errorf("computeAvailability() without events should not report a percentage")
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxMemoryEvents 是内存中每个集群保存的最大记录数，超出时丢弃最早的记录
+const maxMemoryEvents = 10000
+
+// MemoryStore keeps the events in memory, they are lost when the dashboard restarts.
+// MemoryStore 将历史记录保存在内存中，dashboard 重启后记录丢失
+type MemoryStore struct {
+	// lock 保护 events
+	lock sync.RWMutex
+	// events 是每个集群按追加顺序保存的记录
+	events map[string][]Event
+}
+
+var _ Store = &MemoryStore{}
+
+// NewMemoryStore creates an empty MemoryStore.
+// NewMemoryStore 创建空的 MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{events: map[string][]Event{}}
+}
+
+// Append stores one event.
+func (s *MemoryStore) Append(event *Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	events := append(s.events[event.Cluster], *event)
+	if len(events) > maxMemoryEvents {
+		events = events[len(events)-maxMemoryEvents:]
+	}
+	s.events[event.Cluster] = events
+	return nil
+}
+
+// Events returns the events of cluster in [since, until), oldest first.
+func (s *MemoryStore) Events(cluster string, since, until time.Time) ([]Event, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var result []Event
+	for _, event := range s.events[cluster] {
+		if !event.Time.Before(since) && event.Time.Before(until) {
+			result = append(result, event)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+	return result, nil
+}
+
+// Latest returns the latest event of each type and name of cluster before the given time.
+func (s *MemoryStore) Latest(cluster string, before time.Time) ([]Event, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	latest := map[string]Event{}
+	var keys []string
+	for _, event := range s.events[cluster] {
+		if !event.Time.Before(before) {
+			continue
+		}
+		if _, ok := latest[event.key()]; !ok {
+			keys = append(keys, event.key())
+		}
+		latest[event.key()] = event
+	}
+	result := make([]Event, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, latest[key])
+	}
+	return result, nil
+}
+
+// Prune deletes the events before the given time, except the latest event of each type and name of a cluster.
+func (s *MemoryStore) Prune(before time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for cluster, events := range s.events {
+		latest := map[string]int{}
+		for i, event := range events {
+			latest[event.key()] = i
+		}
+		kept := events[:0]
+		for i, event := range events {
+			if !event.Time.Before(before) || latest[event.key()] == i {
+				kept = append(kept, event)
+			}
+		}
+		s.events[cluster] = kept
+	}
+	return nil
+}
+
+// Close does nothing.
+func (s *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultRetention is the default time for which the events are kept.
+	// DefaultRetention 是历史记录默认的保留时间
+	DefaultRetention = 7 * 24 * time.Hour
+	// snapshotInterval 是资源概况快照的最小间隔，Karmada 每次同步集群状态都会更新资源概况
+	snapshotInterval = 10 * time.Minute
+	// pruneInterval 是删除过期记录的间隔
+	pruneInterval = time.Hour
+)
+
+// recordedConditions 是记录变化的集群条件
+var recordedConditions = []string{
+	clusterv1alpha1.ClusterConditionReady,
+	clusterv1alpha1.ClusterConditionCompleteAPIEnablements,
+}
+
+// Recorder records the changes of the clusters it is notified of into a Store. It is registered as an event handler
+// of the cluster informer.
+// Recorder 将集群状态的变化记录到 Store 中，作为集群 Informer 的事件处理器注册
+type Recorder struct {
+	store     Store
+	retention time.Duration
+	now       func() time.Time
+	// lock 保护 last
+	lock sync.Mutex
+	// last 是每个集群每个序列的最后一条记录，集群首次出现时从 Store 中加载
+	last map[string]map[string]Event
+}
+
+var _ cache.ResourceEventHandler = &Recorder{}
+
+// NewRecorder creates a Recorder that stores the events in store and keeps them for retention.
+// NewRecorder 创建将记录保存到 store 中并保留 retention 时长的 Recorder
+func NewRecorder(store Store, retention time.Duration) *Recorder {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &Recorder{
+		store:     store,
+		retention: retention,
+		now:       time.Now,
+		last:      map[string]map[string]Event{},
+	}
+}
+
+// Run prunes the expired events periodically until ctx is done, and then closes the store.
+// Run 定期删除过期的记录，ctx 结束时关闭 Store
+func (r *Recorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		r.prune()
+		select {
+		case <-ctx.Done():
+			if err := r.store.Close(); err != nil {
+				klog.ErrorS(err, "Failed to close cluster history store")
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// prune 删除超过保留时间的记录
+func (r *Recorder) prune() {
+	if err := r.store.Prune(r.now().Add(-r.retention)); err != nil {
+		klog.ErrorS(err, "Failed to prune cluster history")
+	}
+}
+
+// OnAdd records the status of a cluster that is listed or created.
+func (r *Recorder) OnAdd(obj interface{}, _ bool) {
+	r.observe(obj)
+}
+
+// OnUpdate records the changes of the status of a cluster.
+func (r *Recorder) OnUpdate(_, newObj interface{}) {
+	r.observe(newObj)
+}
+
+// OnDelete forgets the last status of a removed cluster, its events are kept until they expire.
+func (r *Recorder) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if cluster, ok := obj.(*clusterv1alpha1.Cluster); ok {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		delete(r.last, cluster.Name)
+	}
+}
+
+// observe 将集群状态与最后一条记录比较，记录发生的变化
+func (r *Recorder) observe(obj interface{}) {
+	cluster, ok := obj.(*clusterv1alpha1.Cluster)
+	if !ok {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	last, err := r.lastEvents(cluster.Name)
+	if err != nil {
+		klog.ErrorS(err, "Failed to load cluster history", "cluster", cluster.Name)
+		return
+	}
+	now := r.now()
+	for _, event := range changes(cluster, last, now) {
+		if err = r.store.Append(&event); err != nil {
+			klog.ErrorS(err, "Failed to record cluster history", "cluster", cluster.Name, "type", event.Type)
+			continue
+		}
+		last[event.key()] = event
+	}
+}
+
+// lastEvents 返回集群每个序列的最后一条记录
+func (r *Recorder) lastEvents(cluster string) (map[string]Event, error) {
+	if last, ok := r.last[cluster]; ok {
+		return last, nil
+	}
+	latest, err := r.store.Latest(cluster, r.now().Add(time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	last := make(map[string]Event, len(latest))
+	for _, event := range latest {
+		last[event.key()] = event
+	}
+	r.last[cluster] = last
+	return last, nil
+}
+
+// changes 返回集群状态相对于最后一条记录的变化
+func changes(cluster *clusterv1alpha1.Cluster, last map[string]Event, now time.Time) []Event {
+	var events []Event
+	for _, conditionType := range recordedConditions {
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, conditionType)
+		if condition == nil {
+			continue
+		}
+		event := Event{Time: now, Cluster: cluster.Name, Type: EventTypeCondition, Name: conditionType,
+			Value: string(condition.Status), Reason: condition.Reason, Message: condition.Message}
+		previous, found := last[event.key()]
+		if found && previous.Value == event.Value {
+			continue
+		}
+		// 使用条件的变化时间，但不早于上一条记录，以免记录的顺序颠倒
+		if transition := condition.LastTransitionTime.Time; !transition.IsZero() && transition.Before(now) && (!found || transition.After(previous.Time)) {
+			event.Time = transition
+		}
+		event.Previous = previous.Value
+		events = append(events, event)
+	}
+
+	if version := cluster.Status.KubernetesVersion; version != "" {
+		event := Event{Time: now, Cluster: cluster.Name, Type: EventTypeKubernetesVersion, Value: version}
+		if previous, found := last[event.key()]; !found || previous.Value != version {
+			event.Previous = previous.Value
+			events = append(events, event)
+		}
+	}
+
+	if summary := cluster.Status.ResourceSummary; summary != nil {
+		event := Event{Time: now, Cluster: cluster.Name, Type: EventTypeResourceSummary, ResourceSummary: summary.DeepCopy()}
+		previous, found := last[event.key()]
+		if !found || (now.Sub(previous.Time) >= snapshotInterval && !equality.Semantic.DeepEqual(previous.ResourceSummary, summary)) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+
+	_ "github.com/glebarez/sqlite" // Import the SQLite driver
+)
+
+// 时间以 Unix 纳秒保存，使比较和排序与时间顺序一致
+const createEventsTableSQL = `
+CREATE TABLE IF NOT EXISTS cluster_history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	time INTEGER NOT NULL,
+	cluster TEXT NOT NULL,
+	type TEXT NOT NULL,
+	name TEXT,
+	value TEXT,
+	previous TEXT,
+	reason TEXT,
+	message TEXT,
+	resource_summary TEXT
+);
+CREATE INDEX IF NOT EXISTS cluster_history_cluster_time ON cluster_history (cluster, time);
+`
+
+const insertEventSQL = `
+INSERT INTO cluster_history (time, cluster, type, name, value, previous, reason, message, resource_summary)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+const selectEventsSQL = `
+SELECT time, cluster, type, name, value, previous, reason, message, resource_summary
+FROM cluster_history WHERE cluster = ? AND time >= ? AND time < ? ORDER BY time, id
+`
+
+const selectLatestSQL = `
+SELECT time, cluster, type, name, value, previous, reason, message, resource_summary
+FROM cluster_history WHERE id IN (
+	SELECT MAX(id) FROM cluster_history WHERE cluster = ? AND time < ? GROUP BY type, name
+) ORDER BY id
+`
+
+const pruneEventsSQL = `
+DELETE FROM cluster_history WHERE time < ? AND id NOT IN (
+	SELECT MAX(id) FROM cluster_history GROUP BY cluster, type, name
+)
+`
+
+// SQLiteStore keeps the events in a SQLite database, so that they survive restarts of the dashboard.
+// SQLiteStore 将历史记录保存到 SQLite 数据库，dashboard 重启后记录仍然保留
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+var _ Store = &SQLiteStore{}
+
+// NewSQLiteStore opens or creates the SQLite database at path and creates the table of events.
+// NewSQLiteStore 打开或创建 SQLite 数据库，并创建历史记录表
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=rwc")
+	if err != nil {
+		return nil, err
+	}
+	// SQLite 不支持并发写入
+	db.SetMaxOpenConns(1)
+	if _, err = db.Exec(createEventsTableSQL); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// Append stores one event.
+func (s *SQLiteStore) Append(event *Event) error {
+	var summary []byte
+	if event.ResourceSummary != nil {
+		var err error
+		if summary, err = json.Marshal(event.ResourceSummary); err != nil {
+			return err
+		}
+	}
+	_, err := s.db.Exec(insertEventSQL, event.Time.UnixNano(), event.Cluster, string(event.Type), event.Name,
+		event.Value, event.Previous, event.Reason, event.Message, string(summary))
+	return err
+}
+
+// Events returns the events of cluster in [since, until), oldest first.
+func (s *SQLiteStore) Events(cluster string, since, until time.Time) ([]Event, error) {
+	return s.query(selectEventsSQL, cluster, since.UnixNano(), until.UnixNano())
+}
+
+// Latest returns the latest event of each type and name of cluster before the given time.
+func (s *SQLiteStore) Latest(cluster string, before time.Time) ([]Event, error) {
+	return s.query(selectLatestSQL, cluster, before.UnixNano())
+}
+
+// Prune deletes the events before the given time, except the latest event of each type and name of a cluster.
+func (s *SQLiteStore) Prune(before time.Time) error {
+	_, err := s.db.Exec(pruneEventsSQL, before.UnixNano())
+	return err
+}
+
+// Close closes the database.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// query 执行查询并读取历史记录
+func (s *SQLiteStore) query(query string, args ...interface{}) ([]Event, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var event Event
+		var eventTime int64
+		var eventType, summary string
+		if err = rows.Scan(&eventTime, &event.Cluster, &eventType, &event.Name, &event.Value, &event.Previous,
+			&event.Reason, &event.Message, &summary); err != nil {
+			return nil, err
+		}
+		event.Time = time.Unix(0, eventTime)
+		event.Type = EventType(eventType)
+		if summary != "" {
+			event.ResourceSummary = &clusterv1alpha1.ResourceSummary{}
+			if err = json.Unmarshal([]byte(summary), event.ResourceSummary); err != nil {
+				return nil, err
+			}
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informer
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	"github.com/karmada-io/karmada/pkg/generated/informers/externalversions"
+	clusterlisters "github.com/karmada-io/karmada/pkg/generated/listers/cluster/v1alpha1"
+	policylisters "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
+	worklisters "github.com/karmada-io/karmada/pkg/generated/listers/work/v1alpha2"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/client"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+)
+
+// KarmadaListers holds the listers of the Karmada objects cached by the API server.
+// The informers behind them use the identity of the dashboard, so callers must authorize the user of the request,
+// e.g. with client.Authorize, before returning cached objects.
+// KarmadaListers 保存 API 服务器缓存的 Karmada 对象的 Lister
+type KarmadaListers struct {
+	Clusters                   clusterlisters.ClusterLister
+	PropagationPolicies        policylisters.PropagationPolicyLister
+	ClusterPropagationPolicies policylisters.ClusterPropagationPolicyLister
+	OverridePolicies           policylisters.OverridePolicyLister
+	ClusterOverridePolicies    policylisters.ClusterOverridePolicyLister
+	ResourceBindings           worklisters.ResourceBindingLister
+	ClusterResourceBindings    worklisters.ClusterResourceBindingLister
+}
+
+var (
+	// karmadaListers 是 Init 创建的 Lister
+	karmadaListers *KarmadaListers
+	// synced 表示 Karmada 对象的缓存是否已经同步完成
+	synced atomic.Bool
+)
+
+// Init starts the informers of the Karmada objects, and stops the informers of member clusters when the clusters are
+// removed from Karmada. It returns immediately, HasSynced reports when the caches are ready.
+// Init 启动 Karmada 对象的 Informer，立即返回，缓存是否同步完成由 HasSynced 返回
+func Init(ctx context.Context, karmadaClient karmadaclientset.Interface, options ...Option) {
+	for _, option := range options {
+		option(&config)
+	}
+
+	factory := externalversions.NewSharedInformerFactory(karmadaClient, 0)
+	clusterInformer := factory.Cluster().V1alpha1().Clusters()
+	policyInformers := factory.Policy().V1alpha1()
+	workInformers := factory.Work().V1alpha2()
+	karmadaListers = &KarmadaListers{
+		Clusters:                   clusterInformer.Lister(),
+		PropagationPolicies:        policyInformers.PropagationPolicies().Lister(),
+		ClusterPropagationPolicies: policyInformers.ClusterPropagationPolicies().Lister(),
+		OverridePolicies:           policyInformers.OverridePolicies().Lister(),
+		ClusterOverridePolicies:    policyInformers.ClusterOverridePolicies().Lister(),
+		ResourceBindings:           workInformers.ResourceBindings().Lister(),
+		ClusterResourceBindings:    workInformers.ClusterResourceBindings().Lister(),
+	}
+	// 集群被删除时停止该集群的 Informer
+	_, err := clusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if cluster, ok := obj.(*clusterv1alpha1.Cluster); ok {
+				stopMember(cluster.Name)
+			}
+		},
+	})
+	if err != nil {
+		klog.ErrorS(err, "Failed to add event handler to cluster informer")
+	}
+	for _, handler := range config.clusterHandlers {
+		if _, err = clusterInformer.Informer().AddEventHandler(handler); err != nil {
+			klog.ErrorS(err, "Failed to add event handler to cluster informer")
+		}
+	}
+
+	factory.Start(ctx.Done())
+	go func() {
+		for informerType, ok := range factory.WaitForCacheSync(ctx.Done()) {
+			if !ok {
+				klog.ErrorS(nil, "Failed to sync informer cache", "type", informerType)
+				return
+			}
+		}
+		synced.Store(true)
+		klog.InfoS("Karmada informer caches synced")
+	}()
+	go func() {
+		<-ctx.Done()
+		stopAllMembers()
+		factory.Shutdown()
+	}()
+}
+
+// HasSynced returns whether the caches of the Karmada objects have synced.
+// HasSynced 返回 Karmada 对象的缓存是否已经同步完成
+func HasSynced() bool {
+	return synced.Load()
+}
+
+// Listers returns the listers of the Karmada objects, or a ServiceUnavailable error until the caches have synced.
+// Listers 返回 Karmada 对象的 Lister，缓存同步完成前返回 ServiceUnavailable 错误
+func Listers() (*KarmadaListers, error) {
+	if !HasSynced() {
+		return nil, errors.NewServiceUnavailable("informer caches of Karmada objects are not synced yet")
+	}
+	return karmadaListers, nil
+}
+
+// ListersForList returns the listers of the Karmada objects after checking that the user of the request may list
+// every given kind in namespace.
+// ListersForList 检查请求的用户是否有权限在命名空间中列出指定类型的资源，并返回 Lister
+func ListersForList(request *http.Request, namespace string, kinds ...types.ResourceKind) (*KarmadaListers, error) {
+	listers, err := Listers()
+	if err != nil {
+		return nil, err
+	}
+	for _, kind := range kinds {
+		if err = authorize(request, "list", kind, namespace, ""); err != nil {
+			return nil, err
+		}
+	}
+	return listers, nil
+}
+
+// ListersForGet returns the listers of the Karmada objects after checking that the user of the request may get the
+// named object of the given kind.
+// ListersForGet 检查请求的用户是否有权限获取指定资源，并返回 Lister
+func ListersForGet(request *http.Request, kind types.ResourceKind, namespace, name string) (*KarmadaListers, error) {
+	listers, err := Listers()
+	if err != nil {
+		return nil, err
+	}
+	if err = authorize(request, "get", kind, namespace, name); err != nil {
+		return nil, err
+	}
+	return listers, nil
+}
+
+// authorize 检查请求的用户是否有权限对指定类型的资源执行操作
+func authorize(request *http.Request, verb string, kind types.ResourceKind, namespace, name string) error {
+	gvr, _ := kind.GroupVersionResource()
+	return client.Authorize(request, authorizationv1.ResourceAttributes{
+		Verb:      verb,
+		Group:     gvr.Group,
+		Resource:  gvr.Resource,
+		Namespace: namespace,
+		Name:      name,
+	})
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/client"
+)
+
+// memberSyncTimeout 是等待成员集群 Informer 同步的最长时间
+const memberSyncTimeout = 10 * time.Second
+
+// Option configures the informer cache.
+type Option func(*options)
+
+// options 是缓存的配置
+type options struct {
+	// memberCache 表示是否为成员集群启动 Informer
+	memberCache bool
+	// clusterHandlers 是注册到集群 Informer 的事件处理器
+	clusterHandlers []cache.ResourceEventHandler
+}
+
+// config 是 Init 使用的配置
+var config options
+
+// WithMemberClusterCache enables the lazily started informers of member clusters.
+// WithMemberClusterCache 启用按需启动的成员集群 Informer
+func WithMemberClusterCache(enabled bool) Option {
+	return func(o *options) {
+		o.memberCache = enabled
+	}
+}
+
+// WithClusterEventHandler registers handler to the informer of the clusters.
+// WithClusterEventHandler 向集群 Informer 注册事件处理器
+func WithClusterEventHandler(handler cache.ResourceEventHandler) Option {
+	return func(o *options) {
+		o.clusterHandlers = append(o.clusterHandlers, handler)
+	}
+}
+
+// MemberCache holds the informers of one member cluster, which are started on first use.
+// MemberCache 保存单个成员集群的 Informer，Informer 在首次使用时启动
+type MemberCache struct {
+	clusterName string
+	factory     informers.SharedInformerFactory
+	stopCh      chan struct{}
+}
+
+var (
+	// memberLock 保护 memberCaches
+	memberLock sync.Mutex
+	// memberCaches 是成员集群名称到 *MemberCache 的映射
+	memberCaches = map[string]*MemberCache{}
+)
+
+// Member returns the cache of the given member cluster, and false if the member cluster cache is disabled.
+// Member 返回成员集群的缓存，未启用成员集群缓存时返回 false
+func Member(clusterName string) (*MemberCache, bool) {
+	if !config.memberCache {
+		return nil, false
+	}
+	memberLock.Lock()
+	defer memberLock.Unlock()
+	if memberCache, ok := memberCaches[clusterName]; ok {
+		return memberCache, true
+	}
+	memberClient := client.InClusterClientForMemberCluster(clusterName)
+	if memberClient == nil {
+		return nil, false
+	}
+	memberCache := &MemberCache{
+		clusterName: clusterName,
+		factory:     informers.NewSharedInformerFactory(memberClient, 0),
+		stopCh:      make(chan struct{}),
+	}
+	memberCaches[clusterName] = memberCache
+	return memberCache, true
+}
+
+// Nodes returns the lister of the nodes of the member cluster, starting its informer and waiting for it to sync.
+// Nodes 返回成员集群节点的 Lister，必要时启动 Informer 并等待同步完成
+func (m *MemberCache) Nodes(ctx context.Context) (corelisters.NodeLister, error) {
+	nodeInformer := m.factory.Core().V1().Nodes()
+	if err := m.waitForSync(ctx, nodeInformer.Informer()); err != nil {
+		return nil, err
+	}
+	return nodeInformer.Lister(), nil
+}
+
+// waitForSync 启动尚未启动的 Informer 并等待指定的 Informer 同步完成
+func (m *MemberCache) waitForSync(ctx context.Context, informer cache.SharedIndexInformer) error {
+	m.factory.Start(m.stopCh)
+	ctx, cancel := context.WithTimeout(ctx, memberSyncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return errors.NewServiceUnavailable(fmt.Sprintf("informer cache of member cluster %s is not synced yet", m.clusterName))
+	}
+	return nil
+}
+
+// stopMember 停止成员集群的 Informer
+func stopMember(clusterName string) {
+	memberLock.Lock()
+	memberCache, ok := memberCaches[clusterName]
+	delete(memberCaches, clusterName)
+	memberLock.Unlock()
+	if ok {
+		klog.InfoS("Stopping informers of removed member cluster", "cluster", clusterName)
+		memberCache.stop()
+	}
+}
+
+// stopAllMembers 停止所有成员集群的 Informer
+func stopAllMembers() {
+	memberLock.Lock()
+	stopping := memberCaches
+	memberCaches = map[string]*MemberCache{}
+	memberLock.Unlock()
+	for _, memberCache := range stopping {
+		memberCache.stop()
+	}
+}
+
+// stop 停止 Informer，不等待 Informer 退出
+func (m *MemberCache) stop() {
+	close(m.stopCh)
+	go m.factory.Shutdown()
+}
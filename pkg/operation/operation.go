@@ -0,0 +1,262 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package operation runs long-running dashboard actions in the background as a sequence of steps whose progress can
+// be queried, and rolls the finished steps back when one of them fails.
+package operation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/klog/v2"
+)
+
+// rollbackTimeout 是回滚一个操作的超时时间，回滚不受已超时的操作上下文影响
+const rollbackTimeout = 2 * time.Minute
+
+// groupResource 是操作在错误信息中使用的资源名称
+var groupResource = schema.GroupResource{Resource: "operations"}
+
+// Phase is the phase of an operation.
+// Phase 是操作的阶段
+type Phase string
+
+const (
+	// PhaseRunning means that the steps of the operation are being run.
+	PhaseRunning Phase = "Running"
+	// PhaseSucceeded means that all steps of the operation succeeded.
+	PhaseSucceeded Phase = "Succeeded"
+	// PhaseFailed means that a step of the operation failed and the finished steps were rolled back.
+	PhaseFailed Phase = "Failed"
+)
+
+// StepPhase is the phase of a step of an operation.
+// StepPhase 是操作步骤的阶段
+type StepPhase string
+
+const (
+	// StepPending means that the step has not been started.
+	StepPending StepPhase = "Pending"
+	// StepRunning means that the step is being run.
+	StepRunning StepPhase = "Running"
+	// StepSucceeded means that the step succeeded.
+	StepSucceeded StepPhase = "Succeeded"
+	// StepFailed means that the step failed, or that it could not be rolled back.
+	StepFailed StepPhase = "Failed"
+	// StepRolledBack means that the changes of the step were rolled back after a later step failed.
+	StepRolledBack StepPhase = "RolledBack"
+)
+
+// Step is a unit of work of an operation.
+// Step 是操作中的一个步骤
+type Step struct {
+	// Name identifies the step in the status of the operation.
+	Name string
+	// Run does the work of the step and returns a message that describes the result.
+	Run func(ctx context.Context) (string, error)
+	// Rollback undoes the changes of the step. It is called for the failed step and the steps before it, in
+	// reverse order, so it must tolerate changes that were only partially made. Nil means nothing to undo.
+	Rollback func(ctx context.Context) error
+}
+
+// StepStatus is the progress of a step.
+// StepStatus 是步骤的进度
+type StepStatus struct {
+	Name           string     `json:"name"`
+	Phase          StepPhase  `json:"phase"`
+	Message        string     `json:"message,omitempty"`
+	StartTime      *time.Time `json:"startTime,omitempty"`
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+}
+
+// Operation is the status of an operation.
+// Operation 是操作的状态
+type Operation struct {
+	ID string `json:"id"`
+	// Kind is the kind of the operation, e.g. "join".
+	Kind string `json:"kind"`
+	// Target is the name of the object the operation acts on.
+	Target         string       `json:"target"`
+	Phase          Phase        `json:"phase"`
+	Steps          []StepStatus `json:"steps"`
+	Error          string       `json:"error,omitempty"`
+	StartTime      time.Time    `json:"startTime"`
+	CompletionTime *time.Time   `json:"completionTime,omitempty"`
+}
+
+// deepCopy 返回操作状态的副本，避免调用方读取时与后台更新竞争
+func (o *Operation) deepCopy() *Operation {
+	out := *o
+	out.Steps = append([]StepStatus(nil), o.Steps...)
+	return &out
+}
+
+// Store runs operations and keeps their status in memory. Finished operations are forgotten after the retention.
+// Store 运行操作并在内存中保存其状态，结束的操作在保留时间后被清除
+type Store struct {
+	// lock 保护 operations
+	lock       sync.Mutex
+	operations map[string]*Operation
+	retention  time.Duration
+	now        func() time.Time
+}
+
+// NewStore creates a Store that keeps finished operations for retention.
+// NewStore 创建 Store，结束的操作保留 retention 时间
+func NewStore(retention time.Duration) *Store {
+	return &Store{
+		operations: map[string]*Operation{},
+		retention:  retention,
+		now:        time.Now,
+	}
+}
+
+// Start runs steps in the background and returns the initial status of the operation. The steps are cancelled once
+// timeout has passed. Only one operation of a kind may run for a target at a time, a Conflict error is returned
+// otherwise.
+// Start 在后台运行步骤并返回操作的初始状态，同一对象同一类型的操作同时只能运行一个
+func (s *Store) Start(kind, target string, timeout time.Duration, steps []Step) (*Operation, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.evictLocked()
+	for _, op := range s.operations {
+		if op.Kind == kind && op.Target == target && op.Phase == PhaseRunning {
+			return nil, errors.NewConflict(groupResource, op.ID, fmt.Errorf("a %s operation for %s is already running", kind, target))
+		}
+	}
+
+	op := &Operation{
+		ID:        string(uuid.NewUUID()),
+		Kind:      kind,
+		Target:    target,
+		Phase:     PhaseRunning,
+		Steps:     make([]StepStatus, 0, len(steps)),
+		StartTime: s.now(),
+	}
+	for _, step := range steps {
+		op.Steps = append(op.Steps, StepStatus{Name: step.Name, Phase: StepPending})
+	}
+	s.operations[op.ID] = op
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		s.run(ctx, op.ID, steps)
+	}()
+	return op.deepCopy(), nil
+}
+
+// Get returns the status of the operation with the given ID.
+// Get 返回指定 ID 的操作的状态
+func (s *Store) Get(id string) (*Operation, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.evictLocked()
+	op, ok := s.operations[id]
+	if !ok {
+		return nil, errors.NewNotFound(groupResource, id)
+	}
+	return op.deepCopy(), nil
+}
+
+// evictLocked 清除结束时间早于保留时间的操作，调用方需持有锁
+func (s *Store) evictLocked() {
+	now := s.now()
+	for id, op := range s.operations {
+		if op.CompletionTime != nil && now.Sub(*op.CompletionTime) > s.retention {
+			delete(s.operations, id)
+		}
+	}
+}
+
+// update 在持有锁的情况下修改操作的状态
+func (s *Store) update(id string, fn func(op *Operation, now time.Time)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if op, ok := s.operations[id]; ok {
+		fn(op, s.now())
+	}
+}
+
+// setStep 设置步骤的阶段和消息，并记录开始和结束时间
+func (s *Store) setStep(id string, index int, phase StepPhase, message string) {
+	s.update(id, func(op *Operation, now time.Time) {
+		step := &op.Steps[index]
+		step.Phase = phase
+		step.Message = message
+		if phase == StepRunning {
+			step.StartTime = &now
+		} else {
+			step.CompletionTime = &now
+		}
+	})
+}
+
+// finish 设置操作的最终阶段
+func (s *Store) finish(id string, phase Phase, err error) {
+	s.update(id, func(op *Operation, now time.Time) {
+		op.Phase = phase
+		if err != nil {
+			op.Error = err.Error()
+		}
+		op.CompletionTime = &now
+	})
+}
+
+// run 依次运行步骤，某个步骤失败时按相反顺序回滚该步骤及其之前的步骤
+func (s *Store) run(ctx context.Context, id string, steps []Step) {
+	for i, step := range steps {
+		s.setStep(id, i, StepRunning, "")
+		message, err := step.Run(ctx)
+		if err == nil {
+			s.setStep(id, i, StepSucceeded, message)
+			continue
+		}
+		klog.ErrorS(err, "Operation step failed", "operation", id, "step", step.Name)
+		s.setStep(id, i, StepFailed, err.Error())
+		s.rollback(id, steps[:i+1])
+		s.finish(id, PhaseFailed, fmt.Errorf("step %s failed: %w", step.Name, err))
+		return
+	}
+	s.finish(id, PhaseSucceeded, nil)
+}
+
+// rollback 按相反顺序回滚步骤，回滚失败的步骤被标记为失败
+func (s *Store) rollback(id string, steps []Step) {
+	ctx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+	defer cancel()
+	for i := len(steps) - 1; i >= 0; i-- {
+		if steps[i].Rollback == nil {
+			continue
+		}
+		if err := steps[i].Rollback(ctx); err != nil {
+			klog.ErrorS(err, "Operation rollback failed", "operation", id, "step", steps[i].Name)
+			s.setStep(id, i, StepFailed, fmt.Sprintf("rollback failed: %v", err))
+			continue
+		}
+		if i == len(steps)-1 {
+			// 失败的步骤保留其错误信息，仅在日志中记录回滚
+			klog.InfoS("Rolled back failed operation step", "operation", id, "step", steps[i].Name)
+			continue
+		}
+		s.setStep(id, i, StepRolledBack, "")
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// waitForCompletion 等待操作结束并返回其状态
+func waitForCompletion(t *testing.T, store *Store, id string) *Operation {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		op, err := store.Get(id)
+		if err != nil {
+			t.Fatalf("Get() unexpected error: %v", err)
+		}
+		if op.Phase != PhaseRunning {
+			return op
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("operation %s did not finish", id)
+	return nil
+}
+
+func TestStoreRollback(t *testing.T) {
+	var lock sync.Mutex
+	var rolledBack []string
+	step := func(name string, err error) Step {
+		return Step{
+			Name: name,
+			Run:  func(context.Context) (string, error) { return "done " + name, err },
+			Rollback: func(context.Context) error {
+				lock.Lock()
+				defer lock.Unlock()
+				rolledBack = append(rolledBack, name)
+				return nil
+			},
+		}
+	}
+	store := NewStore(time.Hour)
+	op, err := store.Start("join", "member1", time.Minute, []Step{
+		step("namespace", nil),
+		{Name: "secret", Run: func(context.Context) (string, error) { return "", nil }},
+		step("agent", errors.New("image pull failed")),
+		step("clusterReady", nil),
+	})
+	if err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+	op = waitForCompletion(t, store, op.ID)
+
+	if op.Phase != PhaseFailed || op.Error != "step agent failed: image pull failed" {
+		t.Errorf("operation finished with phase %s and error %q", op.Phase, op.Error)
+	}
+	var phases []StepPhase
+	for _, step := range op.Steps {
+		phases = append(phases, step.Phase)
+	}
+	wantPhases := []StepPhase{StepRolledBack, StepSucceeded, StepFailed, StepPending}
+	if !reflect.DeepEqual(phases, wantPhases) {
+		t.Errorf("step phases = %v, expected %v", phases, wantPhases)
+	}
+	if want := []string{"agent", "namespace"}; !reflect.DeepEqual(rolledBack, want) {
+		t.Errorf("rolled back %v, expected %v", rolledBack, want)
+	}
+}
+
+func TestStoreConflict(t *testing.T) {
+	store := NewStore(time.Hour)
+	release := make(chan struct{})
+	blocking := []Step{{Name: "wait", Run: func(context.Context) (string, error) {
+		<-release
+		return "", nil
+	}}}
+	first, err := store.Start("join", "member1", time.Minute, blocking)
+	if err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+	if _, err = store.Start("join", "member1", time.Minute, blocking); !apierrors.IsConflict(err) {
+		t.Errorf("Start() expected a conflict, got %v", err)
+	}
+	close(release)
+	if op := waitForCompletion(t, store, first.ID); op.Phase != PhaseSucceeded {
+		t.Errorf("operation finished with phase %s", op.Phase)
+	}
+	if _, err = store.Start("join", "member1", time.Minute, blocking); err != nil {
+		t.Errorf("Start() after completion unexpected error: %v", err)
+	}
+}
+
+func TestStoreEviction(t *testing.T) {
+	store := NewStore(time.Minute)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	op, err := store.Start("join", "member1", time.Minute, nil)
+	if err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+	waitForCompletion(t, store, op.ID)
+
+	now = now.Add(2 * time.Minute)
+	if _, err = store.Get(op.ID); !apierrors.IsNotFound(err) {
+		t.Errorf("Get() expected the operation to be evicted, got %v", err)
+	}
+}
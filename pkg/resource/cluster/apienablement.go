@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// APIEnablementState tells whether a cluster serves an API resource.
+// APIEnablementState 表示集群是否提供某个 API 资源
+type APIEnablementState string
+
+const (
+	// APIEnabled means the cluster serves the API resource.
+	APIEnabled APIEnablementState = "Enabled"
+	// APIDisabled means the cluster does not serve the API resource.
+	APIDisabled APIEnablementState = "Disabled"
+	// APIUnknown means the cluster has not reported the API resource, but its list of APIs is incomplete, e.g. because
+	// some aggregated API servers of the cluster are unavailable.
+	APIUnknown APIEnablementState = "Unknown"
+)
+
+// APIResourceEnablement is a row of the API enablement matrix, it tells which clusters serve an API resource.
+// APIResourceEnablement 是 API 启用矩阵中的一行，表示各集群是否提供该 API 资源
+type APIResourceEnablement struct {
+	// GroupVersion 是 API 资源的组和版本，例如 apps/v1
+	GroupVersion string `json:"groupVersion"`
+	// Kind 是 API 资源的类型，例如 Deployment
+	Kind string `json:"kind"`
+	// Resource 是 API 资源的复数名称，例如 deployments，没有集群提供该资源时为空
+	Resource string `json:"resource,omitempty"`
+	// Clusters 是每个集群提供该 API 资源的状态
+	Clusters map[string]APIEnablementState `json:"clusters"`
+}
+
+// APIEnablementMatrix is the API resources served by each cluster, which is reported in Cluster.Status.APIEnablements.
+// APIEnablementMatrix 是各集群提供的 API 资源，来自 Cluster.Status.APIEnablements
+type APIEnablementMatrix struct {
+	// Clusters 是矩阵中的集群名称，按名称排序
+	Clusters []string `json:"clusters"`
+	// IncompleteClusters 是 API 列表不完整的集群，这些集群未列出的 API 资源状态为 Unknown
+	IncompleteClusters []string `json:"incompleteClusters"`
+	// Resources 是至少一个集群提供的 API 资源，按组、版本和类型排序
+	Resources []APIResourceEnablement `json:"resources"`
+}
+
+// APIEnablementCheck is the result of checking whether the target clusters serve the kinds that are propagated to
+// them.
+// APIEnablementCheck 是检查目标集群是否提供被分发资源类型的结果
+type APIEnablementCheck struct {
+	// Clusters 是被检查的目标集群名称
+	Clusters []string `json:"clusters"`
+	// Resources 是被分发的资源类型在各目标集群中的状态
+	Resources []APIResourceEnablement `json:"resources"`
+	// Warnings 是目标集群不提供或可能不提供被分发资源类型的警告
+	Warnings []string `json:"warnings"`
+}
+
+// GetAPIEnablementMatrix returns the API resources served by the clusters.
+// GetAPIEnablementMatrix 返回各集群提供的 API 资源矩阵
+func GetAPIEnablementMatrix(clusters []*v1alpha1.Cluster) *APIEnablementMatrix {
+	clusters = sortedClusters(clusters)
+	matrix := &APIEnablementMatrix{
+		Clusters:           make([]string, 0, len(clusters)),
+		IncompleteClusters: []string{},
+		Resources:          []APIResourceEnablement{},
+	}
+	rows := map[schema.GroupVersionKind]*APIResourceEnablement{}
+	for _, cluster := range clusters {
+		matrix.Clusters = append(matrix.Clusters, cluster.Name)
+		if !apiEnablementsComplete(cluster) {
+			matrix.IncompleteClusters = append(matrix.IncompleteClusters, cluster.Name)
+		}
+		for _, enablement := range cluster.Status.APIEnablements {
+			for _, resource := range enablement.Resources {
+				gvk := schema.FromAPIVersionAndKind(enablement.GroupVersion, resource.Kind)
+				if _, ok := rows[gvk]; !ok {
+					rows[gvk] = &APIResourceEnablement{GroupVersion: enablement.GroupVersion, Kind: resource.Kind, Resource: resource.Name}
+				}
+			}
+		}
+	}
+	for _, row := range rows {
+		row.Clusters = make(map[string]APIEnablementState, len(clusters))
+		for _, cluster := range clusters {
+			row.Clusters[cluster.Name] = ClusterAPIEnablement(cluster, row.GroupVersion, row.Kind)
+		}
+		matrix.Resources = append(matrix.Resources, *row)
+	}
+	sortAPIResources(matrix.Resources)
+	return matrix
+}
+
+// CheckAPIEnablement checks whether the target clusters serve the kinds, given as API versions and kinds, that are
+// propagated to them.
+// CheckAPIEnablement 检查目标集群是否提供被分发的资源类型，返回各集群的状态和警告
+func CheckAPIEnablement(targets []*v1alpha1.Cluster, gvks []schema.GroupVersionKind) *APIEnablementCheck {
+	targets = sortedClusters(targets)
+	check := &APIEnablementCheck{
+		Clusters:  make([]string, 0, len(targets)),
+		Resources: []APIResourceEnablement{},
+		Warnings:  []string{},
+	}
+	for _, cluster := range targets {
+		check.Clusters = append(check.Clusters, cluster.Name)
+	}
+	seen := map[schema.GroupVersionKind]bool{}
+	for _, gvk := range gvks {
+		if seen[gvk] {
+			continue
+		}
+		seen[gvk] = true
+		groupVersion := gvk.GroupVersion().String()
+		row := APIResourceEnablement{GroupVersion: groupVersion, Kind: gvk.Kind, Clusters: make(map[string]APIEnablementState, len(targets))}
+		for _, cluster := range targets {
+			state := ClusterAPIEnablement(cluster, groupVersion, gvk.Kind)
+			row.Clusters[cluster.Name] = state
+			switch state {
+			case APIDisabled:
+				check.Warnings = append(check.Warnings, fmt.Sprintf("cluster %s does not serve %s %s", cluster.Name, gvk.Kind, groupVersion))
+			case APIUnknown:
+				check.Warnings = append(check.Warnings, fmt.Sprintf("cluster %s has not reported all of its APIs, %s %s may not be served", cluster.Name, gvk.Kind, groupVersion))
+			}
+			if row.Resource == "" {
+				row.Resource, _ = apiResourceName(cluster, groupVersion, gvk.Kind)
+			}
+		}
+		check.Resources = append(check.Resources, row)
+	}
+	sortAPIResources(check.Resources)
+	return check
+}
+
+// ClusterAPIEnablement returns whether the cluster serves the kind in groupVersion. It is the same check as the
+// APIEnablement plugin of the Karmada scheduler.
+// ClusterAPIEnablement 返回集群是否提供 groupVersion 中的 kind，与 Karmada 调度器的 APIEnablement 插件一致
+func ClusterAPIEnablement(cluster *v1alpha1.Cluster, groupVersion, kind string) APIEnablementState {
+	if _, found := apiResourceName(cluster, groupVersion, kind); found {
+		return APIEnabled
+	}
+	if !apiEnablementsComplete(cluster) {
+		return APIUnknown
+	}
+	return APIDisabled
+}
+
+// apiResourceName 返回集群提供的 groupVersion 中 kind 的资源名称，以及集群是否提供该资源
+func apiResourceName(cluster *v1alpha1.Cluster, groupVersion, kind string) (string, bool) {
+	for _, enablement := range cluster.Status.APIEnablements {
+		if enablement.GroupVersion != groupVersion {
+			continue
+		}
+		for _, resource := range enablement.Resources {
+			if resource.Kind == kind {
+				return resource.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// apiEnablementsComplete 判断集群上报的 API 列表是否完整
+func apiEnablementsComplete(cluster *v1alpha1.Cluster) bool {
+	return meta.IsStatusConditionTrue(cluster.Status.Conditions, v1alpha1.ClusterConditionCompleteAPIEnablements)
+}
+
+// sortedClusters 返回按名称排序的集群副本
+func sortedClusters(clusters []*v1alpha1.Cluster) []*v1alpha1.Cluster {
+	sorted := append([]*v1alpha1.Cluster(nil), clusters...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// sortAPIResources 按组、版本和类型排序 API 资源
+func sortAPIResources(resources []APIResourceEnablement) {
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].GroupVersion != resources[j].GroupVersion {
+			return resources[i].GroupVersion < resources[j].GroupVersion
+		}
+		return resources[i].Kind < resources[j].Kind
+	})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// apiCluster 返回提供 Deployment 和 kinds 中 example.io/v1 资源类型的集群
+func apiCluster(name string, complete bool, kinds ...string) *v1alpha1.Cluster {
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"name": name}},
+		Status: v1alpha1.ClusterStatus{
+			APIEnablements: []v1alpha1.APIEnablement{
+				{GroupVersion: "apps/v1", Resources: []v1alpha1.APIResource{{Name: "deployments", Kind: "Deployment"}}},
+			},
+		},
+	}
+	if len(kinds) > 0 {
+		enablement := v1alpha1.APIEnablement{GroupVersion: "example.io/v1"}
+		for _, kind := range kinds {
+			enablement.Resources = append(enablement.Resources, v1alpha1.APIResource{Kind: kind})
+		}
+		cluster.Status.APIEnablements = append(cluster.Status.APIEnablements, enablement)
+	}
+	status := metav1.ConditionFalse
+	if complete {
+		status = metav1.ConditionTrue
+	}
+	cluster.Status.Conditions = []metav1.Condition{{Type: v1alpha1.ClusterConditionCompleteAPIEnablements, Status: status}}
+	return cluster
+}
+
+func TestAPIEnablement(t *testing.T) {
+	clusters := []*v1alpha1.Cluster{
+		apiCluster("member2", true),
+		apiCluster("member1", true, "Widget"),
+		apiCluster("member3", false),
+	}
+
+	matrix := GetAPIEnablementMatrix(clusters)
+	if len(matrix.Clusters) != 3 || matrix.Clusters[0] != "member1" {
+		t.Fatalf("GetAPIEnablementMatrix() clusters = %v", matrix.Clusters)
+	}
+	if len(matrix.IncompleteClusters) != 1 || matrix.IncompleteClusters[0] != "member3" {
+		t.Errorf("GetAPIEnablementMatrix() incomplete clusters = %v", matrix.IncompleteClusters)
+	}
+	if len(matrix.Resources) != 2 {
+		t.Fatalf("GetAPIEnablementMatrix() resources = %v", matrix.Resources)
+	}
+	widget := matrix.Resources[1]
+	if widget.Kind != "Widget" || widget.Clusters["member1"] != APIEnabled || widget.Clusters["member2"] != APIDisabled ||
+		widget.Clusters["member3"] != APIUnknown {
+		t.Errorf("GetAPIEnablementMatrix() widget row = %v", widget)
+	}
+
+	check := CheckAPIEnablement(clusters, []schema.GroupVersionKind{
+		{Group: "apps", Version: "v1", Kind: "Deployment"},
+		{Group: "example.io", Version: "v1", Kind: "Widget"},
+		{Group: "apps", Version: "v1", Kind: "Deployment"},
+	})
+	if len(check.Resources) != 2 {
+		t.Errorf("CheckAPIEnablement() should check each kind once, got %v", check.Resources)
+	}
+	if len(check.Warnings) != 2 {
+		t.Errorf("CheckAPIEnablement() warnings = %v, expected member2 and member3 to be reported", check.Warnings)
+	}
+}
+
+func TestPlacementClusters(t *testing.T) {
+	clusters := []*v1alpha1.Cluster{apiCluster("member2", true), apiCluster("member1", true), apiCluster("member3", true)}
+	clusters[2].Spec.Taints = []corev1.Taint{CordonTaint}
+
+	selected := PlacementClusters(nil, clusters)
+	if len(selected) != 2 || selected[0].Name != "member1" || selected[1].Name != "member2" {
+		t.Errorf("PlacementClusters() without placement should skip tainted clusters, got %d clusters", len(selected))
+	}
+
+	placement := &policyv1alpha1.Placement{
+		ClusterAffinity:    &policyv1alpha1.ClusterAffinity{ClusterNames: []string{"member2", "member3"}},
+		ClusterTolerations: []corev1.Toleration{{Key: CordonTaint.Key, Operator: corev1.TolerationOpExists}},
+	}
+	selected = PlacementClusters(placement, clusters)
+	if len(selected) != 2 || selected[0].Name != "member2" || selected[1].Name != "member3" {
+		t.Errorf("PlacementClusters() with affinity and tolerations selected %d clusters", len(selected))
+	}
+}
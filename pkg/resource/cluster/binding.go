@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	worklisters "github.com/karmada-io/karmada/pkg/generated/listers/work/v1alpha2"
+	"github.com/karmada-io/karmada/pkg/util/names"
+
+	"github.com/karmada-io/dashboard/pkg/common/types"
+)
+
+// BindingOf returns the kind and name of the binding Karmada creates for a resource. Namespaced resources are bound by
+// a ResourceBinding in the same namespace, cluster scoped resources by a ClusterResourceBinding.
+// BindingOf 返回 Karmada 为资源创建的绑定的类型和名称，命名空间级资源使用同一命名空间中的 ResourceBinding，
+// 集群级资源使用 ClusterResourceBinding
+func BindingOf(kind, namespace, name string) (types.ResourceKind, string) {
+	bindingName := names.GenerateBindingName(kind, name)
+	if namespace != "" {
+		return types.ResourceKindResourceBinding, bindingName
+	}
+	return types.ResourceKindClusterResourceBinding, bindingName
+}
+
+// ScheduledClusters returns the names of the clusters the binding of a resource is scheduled to. It returns a NotFound
+// error when the resource is not propagated.
+// ScheduledClusters 返回资源的绑定调度到的集群名称，资源未被分发时返回 NotFound 错误
+func ScheduledClusters(rbLister worklisters.ResourceBindingLister, crbLister worklisters.ClusterResourceBindingLister,
+	kind, namespace, name string) ([]string, error) {
+	_, bindingName := BindingOf(kind, namespace, name)
+	var spec *workv1alpha2.ResourceBindingSpec
+	if namespace != "" {
+		binding, err := rbLister.ResourceBindings(namespace).Get(bindingName)
+		if err != nil {
+			return nil, err
+		}
+		spec = &binding.Spec
+	} else {
+		binding, err := crbLister.Get(bindingName)
+		if err != nil {
+			return nil, err
+		}
+		spec = &binding.Spec
+	}
+	targets := make([]string, 0, len(spec.Clusters))
+	for _, target := range spec.Clusters {
+		targets = append(targets, target.Name)
+	}
+	return targets, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"reflect"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	worklisters "github.com/karmada-io/karmada/pkg/generated/listers/work/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/karmada-io/dashboard/pkg/common/types"
+)
+
+func TestBindingOf(t *testing.T) {
+	if kind, name := BindingOf("Deployment", "default", "nginx"); kind != types.ResourceKindResourceBinding || name != "nginx-deployment" {
+		t.Errorf("BindingOf(namespaced) = %s, %s", kind, name)
+	}
+	if kind, name := BindingOf("ClusterRole", "", "viewer"); kind != types.ResourceKindClusterResourceBinding || name != "viewer-clusterrole" {
+		t.Errorf("BindingOf(cluster scoped) = %s, %s", kind, name)
+	}
+}
+
+func TestScheduledClusters(t *testing.T) {
+	targets := []workv1alpha2.TargetCluster{{Name: "member1", Replicas: 1}, {Name: "member2", Replicas: 2}}
+	rbIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	crbIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := rbIndexer.Add(&workv1alpha2.ResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-deployment"},
+		Spec:       workv1alpha2.ResourceBindingSpec{Clusters: targets},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := crbIndexer.Add(&workv1alpha2.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "viewer-clusterrole"},
+		Spec:       workv1alpha2.ResourceBindingSpec{Clusters: targets[:1]},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	rbLister := worklisters.NewResourceBindingLister(rbIndexer)
+	crbLister := worklisters.NewClusterResourceBindingLister(crbIndexer)
+
+	tests := []struct {
+		name         string
+		kind         string
+		namespace    string
+		resource     string
+		want         []string
+		wantNotFound bool
+	}{
+		{name: "namespaced resource", kind: "Deployment", namespace: "default", resource: "nginx", want: []string{"member1", "member2"}},
+		{name: "cluster scoped resource", kind: "ClusterRole", resource: "viewer", want: []string{"member1"}},
+		{name: "resource in another namespace", kind: "Deployment", namespace: "other", resource: "nginx", wantNotFound: true},
+		{name: "not propagated resource", kind: "ClusterRole", resource: "admin", wantNotFound: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ScheduledClusters(rbLister, crbLister, tt.kind, tt.namespace, tt.resource)
+			if tt.wantNotFound {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("ScheduledClusters() error = %v, want NotFound", err)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ScheduledClusters() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -17,15 +17,14 @@ limitations under the License.
 package cluster
 
 import (
-	"context"
 	"log"
 
 	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
-	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	clusterlisters "github.com/karmada-io/karmada/pkg/generated/listers/cluster/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/karmada-io/dashboard/pkg/common/errors"
-	"github.com/karmada-io/dashboard/pkg/common/helpers"
 	"github.com/karmada-io/dashboard/pkg/common/types"
 	"github.com/karmada-io/dashboard/pkg/dataselect"
 )
@@ -49,17 +48,17 @@ type ClusterList struct {
 	Errors []error `json:"errors"`
 }
 
-// GetClusterList returns a list of all Nodes in the cluster.
-func GetClusterList(client karmadaclientset.Interface, dsQuery *dataselect.DataSelectQuery) (*ClusterList, error) {
-	clusters, err := client.ClusterV1alpha1().Clusters().List(context.TODO(), helpers.ListEverything)
+// GetClusterList returns a list of all clusters in Karmada control plane.
+func GetClusterList(lister clusterlisters.ClusterLister, dsQuery *dataselect.DataSelectQuery) (*ClusterList, error) {
+	clusters, err := lister.List(labels.Everything())
 	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
-	return toClusterList(client, clusters.Items, nonCriticalErrors, dsQuery), nil
+	return toClusterList(fromObjects(clusters), nonCriticalErrors, dsQuery), nil
 }
 
-func toClusterList(_ karmadaclientset.Interface, clusters []v1alpha1.Cluster, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *ClusterList {
+func toClusterList(clusters []v1alpha1.Cluster, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *ClusterList {
 	clusterList := &ClusterList{
 		Clusters: make([]Cluster, 0),
 		ListMeta: types.ListMeta{TotalItems: len(clusters)},
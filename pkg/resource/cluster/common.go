@@ -55,3 +55,12 @@ func fromCells(cells []dataselect.DataCell) []v1alpha1.Cluster {
 	}
 	return std
 }
+
+// fromObjects copies the clusters returned by a lister, which must not be modified.
+func fromObjects(objects []*v1alpha1.Cluster) []v1alpha1.Cluster {
+	std := make([]v1alpha1.Cluster, len(objects))
+	for i := range objects {
+		std[i] = *objects[i]
+	}
+	return std
+}
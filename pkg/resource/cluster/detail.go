@@ -17,15 +17,13 @@ limitations under the License.
 package cluster
 
 import (
-	"context"
 	"fmt"
 	"log"
 
 	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
-	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	clusterlisters "github.com/karmada-io/karmada/pkg/generated/listers/cluster/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ClusterAllocatedResources is the resource summary of a cluster.
@@ -96,9 +94,9 @@ type ClusterDetail struct {
 }
 
 // GetClusterDetail gets details of cluster.
-func GetClusterDetail(client karmadaclientset.Interface, clusterName string) (*ClusterDetail, error) {
+func GetClusterDetail(lister clusterlisters.ClusterLister, clusterName string) (*ClusterDetail, error) {
 	log.Printf("Getting details of %s cluster", clusterName)
-	cluster, err := client.ClusterV1alpha1().Clusters().Get(context.TODO(), clusterName, metav1.GetOptions{})
+	cluster, err := lister.Get(clusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"sort"
+
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CordonTaint is the taint added by cordon. It is the same taint as the one `karmadactl cordon` adds, so that
+// clusters cordoned by either tool can be uncordoned by the other.
+// CordonTaint 是禁止调度集群时添加的污点，与 karmadactl cordon 添加的污点相同
+var CordonTaint = corev1.Taint{Key: v1alpha1.TaintClusterUnscheduler, Effect: corev1.TaintEffectNoSchedule}
+
+// DrainTaint is the taint added by drain, Karmada evicts the workloads that do not tolerate it from the cluster.
+// DrainTaint 是驱逐集群时添加的污点，Karmada 会将不容忍该污点的工作负载驱逐出集群
+var DrainTaint = corev1.Taint{Key: v1alpha1.TaintClusterUnscheduler, Effect: corev1.TaintEffectNoExecute}
+
+// EvictingBinding is a ResourceBinding or ClusterResourceBinding which still places its resource on a draining cluster.
+// EvictingBinding 是仍将资源调度到正在驱逐的集群上的 ResourceBinding 或 ClusterResourceBinding
+type EvictingBinding struct {
+	// Kind 是 ResourceBinding 或 ClusterResourceBinding
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Resource 是绑定引用的资源模板
+	Resource workv1alpha2.ObjectReference `json:"resource"`
+	// Scheduled 为 true 时集群仍在绑定的调度结果中
+	Scheduled bool `json:"scheduled"`
+	// Tolerated 为 true 时绑定的调度策略永久容忍驱逐污点，资源不会被驱逐
+	Tolerated bool `json:"tolerated"`
+	// GracefulEvictionTasks 是绑定中从该集群优雅驱逐资源的任务
+	GracefulEvictionTasks []workv1alpha2.GracefulEvictionTask `json:"gracefulEvictionTasks"`
+}
+
+// DrainStatus is the progress of draining a cluster.
+// DrainStatus 是驱逐集群的进度
+type DrainStatus struct {
+	// Cordoned 为 true 时集群不再被调度新的工作负载
+	Cordoned bool `json:"cordoned"`
+	// Draining 为 true 时集群带有驱逐污点
+	Draining bool `json:"draining"`
+	// Completed 为 true 时所有不容忍驱逐污点的工作负载都已迁移到其他集群
+	Completed bool `json:"completed"`
+	// Bindings 是仍将资源调度到该集群或正在从该集群优雅驱逐资源的绑定
+	Bindings []EvictingBinding `json:"bindings"`
+}
+
+// HasTaint reports whether the cluster has a taint with the key and effect of taint.
+// HasTaint 判断集群是否带有与 taint 键和效果相同的污点
+func HasTaint(cluster *v1alpha1.Cluster, taint corev1.Taint) bool {
+	for i := range cluster.Spec.Taints {
+		if cluster.Spec.Taints[i].MatchTaint(&taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTaint adds taint to the cluster unless it already has it, and returns whether the cluster was changed.
+// AddTaint 在集群没有该污点时添加污点，返回集群是否被修改
+func AddTaint(cluster *v1alpha1.Cluster, taint corev1.Taint) bool {
+	if HasTaint(cluster, taint) {
+		return false
+	}
+	if taint.Effect == corev1.TaintEffectNoExecute && taint.TimeAdded == nil {
+		// Karmada 根据 TimeAdded 计算容忍时间到期后驱逐的时间
+		now := metav1.Now()
+		taint.TimeAdded = &now
+	}
+	cluster.Spec.Taints = append(cluster.Spec.Taints, taint)
+	return true
+}
+
+// RemoveTaints removes the taints with the key and effect of any of taints, and returns whether the cluster was
+// changed.
+// RemoveTaints 删除与任一 taints 键和效果相同的污点，返回集群是否被修改
+func RemoveTaints(cluster *v1alpha1.Cluster, taints ...corev1.Taint) bool {
+	kept := make([]corev1.Taint, 0, len(cluster.Spec.Taints))
+	for _, existing := range cluster.Spec.Taints {
+		matched := false
+		for i := range taints {
+			if existing.MatchTaint(&taints[i]) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			kept = append(kept, existing)
+		}
+	}
+	changed := len(kept) != len(cluster.Spec.Taints)
+	cluster.Spec.Taints = kept
+	return changed
+}
+
+// GetDrainStatus returns the progress of draining the cluster from the bindings in Karmada control plane.
+// GetDrainStatus 根据控制面中的绑定返回驱逐集群的进度
+func GetDrainStatus(cluster *v1alpha1.Cluster, rbs []*workv1alpha2.ResourceBinding, crbs []*workv1alpha2.ClusterResourceBinding) *DrainStatus {
+	status := &DrainStatus{
+		Cordoned: HasTaint(cluster, CordonTaint),
+		Draining: HasTaint(cluster, DrainTaint),
+		Bindings: []EvictingBinding{},
+	}
+	for _, rb := range rbs {
+		if binding, ok := evictingBinding(cluster.Name, &rb.Spec); ok {
+			binding.Kind, binding.Namespace, binding.Name = workv1alpha2.ResourceKindResourceBinding, rb.Namespace, rb.Name
+			status.Bindings = append(status.Bindings, binding)
+		}
+	}
+	for _, crb := range crbs {
+		if binding, ok := evictingBinding(cluster.Name, &crb.Spec); ok {
+			binding.Kind, binding.Name = workv1alpha2.ResourceKindClusterResourceBinding, crb.Name
+			status.Bindings = append(status.Bindings, binding)
+		}
+	}
+	sort.SliceStable(status.Bindings, func(i, j int) bool {
+		a, b := status.Bindings[i], status.Bindings[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	status.Completed = status.Draining
+	for _, binding := range status.Bindings {
+		if !binding.Tolerated {
+			status.Completed = false
+		}
+	}
+	return status
+}
+
+// evictingBinding 判断绑定是否仍将资源调度到集群上或正在从集群优雅驱逐资源
+func evictingBinding(clusterName string, spec *workv1alpha2.ResourceBindingSpec) (EvictingBinding, bool) {
+	binding := EvictingBinding{
+		Resource:              spec.Resource,
+		Scheduled:             spec.TargetContains(clusterName),
+		Tolerated:             toleratesDrain(spec.Placement),
+		GracefulEvictionTasks: []workv1alpha2.GracefulEvictionTask{},
+	}
+	for _, task := range spec.GracefulEvictionTasks {
+		if task.FromCluster == clusterName {
+			binding.GracefulEvictionTasks = append(binding.GracefulEvictionTasks, task)
+		}
+	}
+	return binding, binding.Scheduled || len(binding.GracefulEvictionTasks) > 0
+}
+
+// toleratesDrain 判断调度策略是否永久容忍驱逐污点，带有容忍时间的容忍在到期后仍会被驱逐
+func toleratesDrain(placement *policyv1alpha1.Placement) bool {
+	if placement == nil {
+		return false
+	}
+	for _, toleration := range placement.ClusterTolerations {
+		if toleration.TolerationSeconds == nil && toleration.ToleratesTaint(&DrainTaint) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTaints(t *testing.T) {
+	cluster := &v1alpha1.Cluster{}
+	if !AddTaint(cluster, CordonTaint) || AddTaint(cluster, CordonTaint) {
+		t.Fatalf("AddTaint() should only add the cordon taint once")
+	}
+	AddTaint(cluster, DrainTaint)
+	if len(cluster.Spec.Taints) != 2 || cluster.Spec.Taints[1].TimeAdded == nil {
+		t.Fatalf("AddTaint() expected the drain taint with its time added, got %v", cluster.Spec.Taints)
+	}
+	if !RemoveTaints(cluster, CordonTaint, DrainTaint) || len(cluster.Spec.Taints) != 0 {
+		t.Errorf("RemoveTaints() left %v", cluster.Spec.Taints)
+	}
+}
+
+func TestGetDrainStatus(t *testing.T) {
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "member1"},
+		Spec:       v1alpha1.ClusterSpec{Taints: []corev1.Taint{CordonTaint, DrainTaint}},
+	}
+	rb := func(name string, spec workv1alpha2.ResourceBindingSpec) *workv1alpha2.ResourceBinding {
+		return &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}, Spec: spec}
+	}
+	evicting := rb("evicting", workv1alpha2.ResourceBindingSpec{
+		GracefulEvictionTasks: []workv1alpha2.GracefulEvictionTask{{FromCluster: "member1"}, {FromCluster: "member2"}},
+	})
+	moved := rb("moved", workv1alpha2.ResourceBindingSpec{Clusters: []workv1alpha2.TargetCluster{{Name: "member2"}}})
+	tolerated := rb("tolerated", workv1alpha2.ResourceBindingSpec{
+		Clusters: []workv1alpha2.TargetCluster{{Name: "member1"}},
+		Placement: &policyv1alpha1.Placement{ClusterTolerations: []corev1.Toleration{{
+			Key: v1alpha1.TaintClusterUnscheduler, Operator: corev1.TolerationOpExists,
+		}}},
+	})
+
+	status := GetDrainStatus(cluster, []*workv1alpha2.ResourceBinding{tolerated, moved, evicting}, nil)
+	if !status.Cordoned || !status.Draining || status.Completed {
+		t.Errorf("GetDrainStatus() = %+v, expected a draining cluster that is not completed", status)
+	}
+	if len(status.Bindings) != 2 || status.Bindings[0].Name != "evicting" || status.Bindings[1].Name != "tolerated" {
+		t.Fatalf("GetDrainStatus() bindings = %+v, expected evicting and tolerated", status.Bindings)
+	}
+	if tasks := status.Bindings[0].GracefulEvictionTasks; len(tasks) != 1 || tasks[0].FromCluster != "member1" {
+		t.Errorf("GetDrainStatus() eviction tasks = %+v, expected the task from member1", tasks)
+	}
+	if !status.Bindings[1].Tolerated || !status.Bindings[1].Scheduled {
+		t.Errorf("GetDrainStatus() tolerated binding = %+v", status.Bindings[1])
+	}
+
+	status = GetDrainStatus(cluster, []*workv1alpha2.ResourceBinding{tolerated, moved}, nil)
+	if !status.Completed {
+		t.Errorf("GetDrainStatus() expected completed when only tolerating bindings are left")
+	}
+}
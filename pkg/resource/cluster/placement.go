@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// PlacementCluster is the result of evaluating a placement against one cluster.
+// PlacementCluster 是调度策略对一个集群的评估结果
+type PlacementCluster struct {
+	Name string `json:"name"`
+	// Selected 为 true 时集群满足调度策略的亲和性、容忍和分布约束
+	Selected bool `json:"selected"`
+	// Ready 为集群的 Ready 条件是否为 True
+	Ready bool `json:"ready"`
+	// Reasons 是集群未被选择的原因
+	Reasons []string `json:"reasons"`
+}
+
+// PlacementResult is the result of evaluating a placement against the clusters.
+// PlacementResult 是调度策略对所有集群的评估结果
+type PlacementResult struct {
+	// SelectedClusters 是满足调度策略的集群名称，按名称排序
+	SelectedClusters []string `json:"selectedClusters"`
+	// Clusters 是每个集群的评估结果，按名称排序
+	Clusters []PlacementCluster `json:"clusters"`
+	// Warnings 是分布约束无法满足或调度器只会选择部分集群的警告
+	Warnings []string `json:"warnings"`
+}
+
+// EvaluatePlacement evaluates placement against the clusters with their current labels, taints and status, in the
+// same way as the filter plugins of the Karmada scheduler: cluster affinity, taint toleration and the fields required
+// by spread constraints. When placement has several cluster affinity terms, the first term is used, which is the one
+// the scheduler tries first. Scoring is not simulated, so the warnings tell when the spread constraints make the
+// scheduler pick only some of the selected clusters.
+// EvaluatePlacement 按 Karmada 调度器过滤插件的规则，根据集群当前的标签、污点和状态评估调度策略
+func EvaluatePlacement(placement *policyv1alpha1.Placement, clusters []*v1alpha1.Cluster) *PlacementResult {
+	if placement == nil {
+		placement = &policyv1alpha1.Placement{}
+	}
+	result := &PlacementResult{
+		SelectedClusters: []string{},
+		Clusters:         make([]PlacementCluster, 0, len(clusters)),
+		Warnings:         []string{},
+	}
+	var selected []*v1alpha1.Cluster
+	for _, cluster := range sortedClusters(clusters) {
+		evaluated := PlacementCluster{
+			Name:    cluster.Name,
+			Ready:   meta.IsStatusConditionTrue(cluster.Status.Conditions, v1alpha1.ClusterConditionReady),
+			Reasons: placementReasons(placement, cluster),
+		}
+		evaluated.Selected = len(evaluated.Reasons) == 0
+		if evaluated.Selected {
+			result.SelectedClusters = append(result.SelectedClusters, cluster.Name)
+			selected = append(selected, cluster)
+		}
+		result.Clusters = append(result.Clusters, evaluated)
+	}
+	for _, constraint := range placement.SpreadConstraints {
+		if warning := spreadWarning(constraint, selected); warning != "" {
+			result.Warnings = append(result.Warnings, warning)
+		}
+	}
+	return result
+}
+
+// PlacementClusters returns the clusters that pass the filters of EvaluatePlacement, sorted by name.
+// PlacementClusters 返回满足调度策略的集群，按名称排序
+func PlacementClusters(placement *policyv1alpha1.Placement, clusters []*v1alpha1.Cluster) []*v1alpha1.Cluster {
+	if placement == nil {
+		placement = &policyv1alpha1.Placement{}
+	}
+	selected := make([]*v1alpha1.Cluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		if len(placementReasons(placement, cluster)) == 0 {
+			selected = append(selected, cluster)
+		}
+	}
+	return sortedClusters(selected)
+}
+
+// placementReasons 返回集群不满足调度策略的原因，满足时返回空列表
+func placementReasons(placement *policyv1alpha1.Placement, cluster *v1alpha1.Cluster) []string {
+	reasons := []string{}
+	affinity := placement.ClusterAffinity
+	if affinity == nil && len(placement.ClusterAffinities) > 0 {
+		affinity = &placement.ClusterAffinities[0].ClusterAffinity
+	}
+	if affinity != nil && !karmadautil.ClusterMatches(cluster, *affinity) {
+		reasons = append(reasons, "cluster does not match the cluster affinity")
+	}
+	if taint := untoleratedTaint(cluster.Spec.Taints, placement.ClusterTolerations); taint != nil {
+		reasons = append(reasons, fmt.Sprintf("taint %s is not tolerated", taint.ToString()))
+	}
+	for _, constraint := range placement.SpreadConstraints {
+		if constraint.SpreadByField != "" && spreadValue(cluster, constraint) == "" {
+			reasons = append(reasons, fmt.Sprintf("cluster has no %s required by the spread constraint", constraint.SpreadByField))
+		}
+	}
+	return reasons
+}
+
+// untoleratedTaint 返回第一个阻止调度且未被 tolerations 容忍的污点，与 Karmada 调度器的 TaintToleration 插件一致
+func untoleratedTaint(taints []corev1.Taint, tolerations []corev1.Toleration) *corev1.Taint {
+	for i := range taints {
+		if taints[i].Effect != corev1.TaintEffectNoSchedule && taints[i].Effect != corev1.TaintEffectNoExecute {
+			continue
+		}
+		tolerated := false
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(&taints[i]) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return &taints[i]
+		}
+	}
+	return nil
+}
+
+// spreadValue 返回集群在分布约束中所属的分组
+func spreadValue(cluster *v1alpha1.Cluster, constraint policyv1alpha1.SpreadConstraint) string {
+	if constraint.SpreadByLabel != "" {
+		return cluster.Labels[constraint.SpreadByLabel]
+	}
+	switch constraint.SpreadByField {
+	case policyv1alpha1.SpreadByFieldCluster:
+		return cluster.Name
+	case policyv1alpha1.SpreadByFieldProvider:
+		return cluster.Spec.Provider
+	case policyv1alpha1.SpreadByFieldRegion:
+		return cluster.Spec.Region
+	case policyv1alpha1.SpreadByFieldZone:
+		if cluster.Spec.Zone != "" {
+			return cluster.Spec.Zone
+		}
+		if len(cluster.Spec.Zones) > 0 {
+			return cluster.Spec.Zones[0]
+		}
+	}
+	return ""
+}
+
+// spreadWarning 返回分布约束在被选择的集群上无法满足或只会选择部分分组的警告
+func spreadWarning(constraint policyv1alpha1.SpreadConstraint, selected []*v1alpha1.Cluster) string {
+	by := string(constraint.SpreadByField)
+	if constraint.SpreadByLabel != "" {
+		by = "label " + constraint.SpreadByLabel
+	}
+	groups := sets.New[string]()
+	for _, cluster := range selected {
+		if value := spreadValue(cluster, constraint); value != "" {
+			groups.Insert(value)
+		}
+	}
+	switch {
+	case groups.Len() < constraint.MinGroups:
+		return fmt.Sprintf("the spread constraint by %s requires at least %d groups, but only %d are available, scheduling will fail",
+			by, constraint.MinGroups, groups.Len())
+	case constraint.MaxGroups > 0 && groups.Len() > constraint.MaxGroups:
+		return fmt.Sprintf("the spread constraint by %s allows at most %d groups, the scheduler will pick %d of the %d available",
+			by, constraint.MaxGroups, constraint.MaxGroups, groups.Len())
+	}
+	return ""
+}
@@ -56,3 +56,12 @@ func fromCells(cells []dataselect.DataCell) []v1alpha1.ClusterOverridePolicy {
 	}
 	return std
 }
+
+// fromObjects 复制 Lister 返回的对象，Lister 返回的对象不能被修改。
+func fromObjects(objects []*v1alpha1.ClusterOverridePolicy) []v1alpha1.ClusterOverridePolicy {
+	std := make([]v1alpha1.ClusterOverridePolicy, len(objects))
+	for i := range objects {
+		std[i] = *objects[i]
+	}
+	return std
+}
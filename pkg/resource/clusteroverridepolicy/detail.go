@@ -17,11 +17,8 @@ limitations under the License.
 package clusteroverridepolicy
 
 import (
-	"context"
-
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
-	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	policylisters "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
 
 	"github.com/karmada-io/dashboard/pkg/common/errors"
 )
@@ -37,8 +34,8 @@ type ClusterOverridePolicyDetail struct {
 }
 
 // GetClusterOverridePolicyDetail 获取集群传播策略的详细信息。
-func GetClusterOverridePolicyDetail(client karmadaclientset.Interface, name string) (*ClusterOverridePolicyDetail, error) {
-	overridepolicyData, err := client.PolicyV1alpha1().ClusterOverridePolicies().Get(context.TODO(), name, metaV1.GetOptions{})
+func GetClusterOverridePolicyDetail(lister policylisters.ClusterOverridePolicyLister, name string) (*ClusterOverridePolicyDetail, error) {
+	overridepolicyData, err := lister.Get(name)
 	if err != nil {
 		return nil, err
 	}
@@ -17,13 +17,11 @@ limitations under the License.
 package clusteroverridepolicy
 
 import (
-	"context"
-
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
-	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	policylisters "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/karmada-io/dashboard/pkg/common/errors"
-	"github.com/karmada-io/dashboard/pkg/common/helpers"
 	"github.com/karmada-io/dashboard/pkg/common/types"
 	"github.com/karmada-io/dashboard/pkg/dataselect"
 )
@@ -50,14 +48,14 @@ type ClusterOverridePolicy struct {
 }
 
 // GetClusterOverridePolicyList 返回Karmada控制平面中所有覆盖策略的列表。
-func GetClusterOverridePolicyList(client karmadaclientset.Interface, dsQuery *dataselect.DataSelectQuery) (*ClusterOverridePolicyList, error) {
-	clusterOverridePolicies, err := client.PolicyV1alpha1().ClusterOverridePolicies().List(context.TODO(), helpers.ListEverything)
+func GetClusterOverridePolicyList(lister policylisters.ClusterOverridePolicyLister, dsQuery *dataselect.DataSelectQuery) (*ClusterOverridePolicyList, error) {
+	clusterOverridePolicies, err := lister.List(labels.Everything())
 	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toClusterOverridePolicyList(clusterOverridePolicies.Items, nonCriticalErrors, dsQuery), nil
+	return toClusterOverridePolicyList(fromObjects(clusterOverridePolicies), nonCriticalErrors, dsQuery), nil
 }
 
 // toClusterOverridePolicyList 将v1alpha1.ClusterOverridePolicy对象列表转换为ClusterOverridePolicyList对象。
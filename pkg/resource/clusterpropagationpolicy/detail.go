@@ -17,11 +17,8 @@ limitations under the License.
 package clusterpropagationpolicy
 
 import (
-	"context"
-
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
-	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	policylisters "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
 
 	"github.com/karmada-io/dashboard/pkg/common/errors"
 )
@@ -37,8 +34,8 @@ type ClusterPropagationPolicyDetail struct {
 }
 
 // GetClusterPropagationPolicyDetail 获取集群传播策略的详细信息。
-func GetClusterPropagationPolicyDetail(client karmadaclientset.Interface, name string) (*ClusterPropagationPolicyDetail, error) {
-	propagationpolicyData, err := client.PolicyV1alpha1().ClusterPropagationPolicies().Get(context.TODO(), name, metaV1.GetOptions{})
+func GetClusterPropagationPolicyDetail(lister policylisters.ClusterPropagationPolicyLister, name string) (*ClusterPropagationPolicyDetail, error) {
+	propagationpolicyData, err := lister.Get(name)
 	if err != nil {
 		return nil, err
 	}
@@ -17,13 +17,11 @@ limitations under the License.
 package clusterpropagationpolicy
 
 import (
-	"context"
-
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
-	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	policylisters "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/karmada-io/dashboard/pkg/common/errors"
-	"github.com/karmada-io/dashboard/pkg/common/helpers"
 	"github.com/karmada-io/dashboard/pkg/common/types"
 	"github.com/karmada-io/dashboard/pkg/dataselect"
 )
@@ -50,14 +48,14 @@ type ClusterPropagationPolicy struct {
 }
 
 // GetClusterPropagationPolicyList 返回Karmada控制平面中所有传播策略的列表。
-func GetClusterPropagationPolicyList(client karmadaclientset.Interface, dsQuery *dataselect.DataSelectQuery) (*ClusterPropagationPolicyList, error) {
-	clusterPropagationPolicies, err := client.PolicyV1alpha1().ClusterPropagationPolicies().List(context.TODO(), helpers.ListEverything)
+func GetClusterPropagationPolicyList(lister policylisters.ClusterPropagationPolicyLister, dsQuery *dataselect.DataSelectQuery) (*ClusterPropagationPolicyList, error) {
+	clusterPropagationPolicies, err := lister.List(labels.Everything())
 	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toClusterPropagationPolicyList(clusterPropagationPolicies.Items, nonCriticalErrors, dsQuery), nil
+	return toClusterPropagationPolicyList(fromObjects(clusterPropagationPolicies), nonCriticalErrors, dsQuery), nil
 }
 
 // toClusterPropagationPolicyList 将v1alpha1.ClusterPropagationPolicy对象列表转换为ClusterPropagationPolicyList对象。
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+)
+
+// defaultNamespace is used when neither the request nor the manifest sets a namespace.
+const defaultNamespace = "default"
+
+// ParseManifest decodes a YAML or JSON manifest of a namespaced resource of the given kind. The namespace and name
+// sent on the request are filled into the object when the manifest leaves them empty, and a manifest that sets a
+// different namespace or name is rejected. An empty namespace means the namespace of the manifest, or "default".
+// ParseManifest 解析 YAML 或 JSON 格式的资源清单，并检查清单的命名空间和名称与请求是否一致
+func ParseManifest(kind types.ResourceKind, content, namespace, name string) (*unstructured.Unstructured, error) {
+	gvr, ok := kind.GroupVersionResource()
+	if !ok || !kind.Namespaced() {
+		return nil, errors.NewBadRequest(fmt.Sprintf("unsupported resource kind %q", kind))
+	}
+	data, err := yaml.YAMLToJSON([]byte(content))
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid manifest: %v", err))
+	}
+	obj := &unstructured.Unstructured{}
+	if err = obj.UnmarshalJSON(data); err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid manifest: %v", err))
+	}
+
+	gvk := obj.GroupVersionKind()
+	if gvk.GroupVersion() != gvr.GroupVersion() || strings.ToLower(gvk.Kind) != string(kind) {
+		return nil, errors.NewBadRequest(fmt.Sprintf("expected a %s of %s, got %s of %s",
+			kind, gvr.GroupVersion(), gvk.Kind, gvk.GroupVersion()))
+	}
+
+	switch {
+	case namespace == "" && obj.GetNamespace() == "":
+		obj.SetNamespace(defaultNamespace)
+	case obj.GetNamespace() == "":
+		obj.SetNamespace(namespace)
+	case namespace != "" && obj.GetNamespace() != namespace:
+		return nil, errors.NewBadRequest(errors.MsgDeployNamespaceMismatchError)
+	}
+
+	switch {
+	case obj.GetName() == "":
+		obj.SetName(name)
+	case name != "" && obj.GetName() != name:
+		return nil, errors.NewBadRequest("the name of the provided object does not match the name sent on the request")
+	}
+	return obj, nil
+}
+
+// CreateResource creates a namespaced resource of the given kind from a YAML or JSON manifest.
+// CreateResource 通过资源清单创建指定类型的资源
+func CreateResource(client dynamic.Interface, kind types.ResourceKind, namespace, content string) (*unstructured.Unstructured, error) {
+	obj, err := ParseManifest(kind, content, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	gvr, _ := kind.GroupVersionResource()
+	return client.Resource(gvr).Namespace(obj.GetNamespace()).Create(context.TODO(), obj, metav1.CreateOptions{})
+}
+
+// UpdateResource replaces the named resource of the given kind with a YAML or JSON manifest. The latest
+// resourceVersion is used when the manifest does not carry one, otherwise a stale resourceVersion fails with a
+// Conflict error.
+// UpdateResource 使用资源清单更新指定的资源
+func UpdateResource(client dynamic.Interface, kind types.ResourceKind, namespace, name, content string) (*unstructured.Unstructured, error) {
+	obj, err := ParseManifest(kind, content, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	gvr, _ := kind.GroupVersionResource()
+	resource := client.Resource(gvr).Namespace(obj.GetNamespace())
+	if obj.GetResourceVersion() == "" {
+		current, err := resource.Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		obj.SetResourceVersion(current.GetResourceVersion())
+	}
+	return resource.Update(context.TODO(), obj, metav1.UpdateOptions{})
+}
+
+// DeleteResource deletes the named resource of the given kind, together with its dependents.
+// DeleteResource 删除指定的资源及其附属资源
+func DeleteResource(client dynamic.Interface, kind types.ResourceKind, namespace, name string) error {
+	gvr, ok := kind.GroupVersionResource()
+	if !ok || !kind.Namespaced() {
+		return errors.NewBadRequest(fmt.Sprintf("unsupported resource kind %q", kind))
+	}
+	// Job 默认不删除其 Pod，因此显式使用后台级联删除
+	propagationPolicy := metav1.DeletePropagationBackground
+	return client.Resource(gvr).Namespace(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{
+		PropagationPolicy: &propagationPolicy,
+	})
+}
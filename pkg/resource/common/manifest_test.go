@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+	"github.com/karmada-io/dashboard/pkg/common/types"
+)
+
+const configMapManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  namespace: karmada-system
+data:
+  key: value
+`
+
+func TestParseManifest(t *testing.T) {
+	cases := []struct {
+		name          string
+		kind          types.ResourceKind
+		content       string
+		namespace     string
+		objName       string
+		wantNamespace string
+		wantErr       string
+	}{
+		{
+			name:          "namespace taken from manifest",
+			kind:          types.ResourceKindConfigMap,
+			content:       configMapManifest,
+			wantNamespace: "karmada-system",
+		},
+		{
+			name:          "namespace matches request",
+			kind:          types.ResourceKindConfigMap,
+			content:       configMapManifest,
+			namespace:     "karmada-system",
+			objName:       "app-config",
+			wantNamespace: "karmada-system",
+		},
+		{
+			name:          "namespace filled from request",
+			kind:          types.ResourceKindConfigMap,
+			content:       `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "app-config"}}`,
+			namespace:     "default",
+			wantNamespace: "default",
+		},
+		{
+			name:      "namespace mismatch",
+			kind:      types.ResourceKindConfigMap,
+			content:   configMapManifest,
+			namespace: "default",
+			wantErr:   errors.MsgDeployNamespaceMismatchError,
+		},
+		{
+			name:      "name mismatch",
+			kind:      types.ResourceKindConfigMap,
+			content:   configMapManifest,
+			namespace: "karmada-system",
+			objName:   "other",
+			wantErr:   "the name of the provided object does not match the name sent on the request",
+		},
+		{
+			name:    "kind mismatch",
+			kind:    types.ResourceKindSecret,
+			content: configMapManifest,
+			wantErr: "expected a secret of v1, got ConfigMap of v1",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			obj, err := ParseManifest(c.kind, c.content, c.namespace, c.objName)
+			if c.wantErr != "" {
+				if err == nil || err.Error() != c.wantErr {
+					t.Fatalf("ParseManifest() error = %v, expected %q", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseManifest() unexpected error: %v", err)
+			}
+			if obj.GetNamespace() != c.wantNamespace {
+				t.Errorf("ParseManifest() namespace = %q, expected %q", obj.GetNamespace(), c.wantNamespace)
+			}
+		})
+	}
+}
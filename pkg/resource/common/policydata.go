@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	"github.com/karmada-io/dashboard/pkg/common/errors"
+)
+
+// PolicyData is a Karmada policy with a spec of type S to create or update, given either as YAML or as typed fields.
+// Exactly one of YAML and Spec must be set. The policy packages build their policies from it with Decode, Create and
+// Update, which fill the metadata and the spec of the policy.
+// PolicyData 是要创建或更新的 Karmada 策略，S 是策略 spec 的类型，使用 YAML 或结构化字段表示，YAML 和 Spec 必须且只能提供一个
+type PolicyData[S any] struct {
+	// YAML 是策略的 YAML
+	YAML string
+	// Name 是使用 Spec 创建时的策略名称
+	Name string
+	// Namespace 是命名空间级策略的命名空间，YAML 中未指定命名空间时也使用该命名空间
+	Namespace string
+	// Labels 和 Annotations 是使用 Spec 时的策略标签和注解，更新时为空表示保持不变
+	Labels      map[string]string
+	Annotations map[string]string
+	// Spec 是结构化的策略
+	Spec *S
+	// ResourceVersion 是更新时期望的策略版本，为空时使用 YAML 中的 resourceVersion，都为空时更新最新版本
+	ResourceVersion string
+}
+
+// Decode decodes the YAML into policy and checks that exactly one of YAML and Spec is set. dataField is the field of
+// the request holding the YAML, used in the error messages.
+// Decode 将 YAML 解码到 policy，并检查 YAML 和 Spec 必须且只能提供一个，dataField 是请求中 YAML 的字段名
+func (d *PolicyData[S]) Decode(policy interface{}, dataField string) error {
+	switch {
+	case d.YAML != "" && d.Spec != nil:
+		return errors.NewBadRequest(fmt.Sprintf("%s and spec cannot be set at the same time", dataField))
+	case d.YAML == "" && d.Spec == nil:
+		return errors.NewBadRequest(fmt.Sprintf("either %s or spec is required", dataField))
+	case d.YAML != "":
+		if err := yaml.Unmarshal([]byte(d.YAML), policy); err != nil {
+			return errors.NewBadRequest(fmt.Sprintf("invalid %s: %v", dataField, err))
+		}
+	}
+	return nil
+}
+
+// Create fills the metadata and the spec of a decoded policy to create. The namespace is used when the policy does
+// not set one, and is empty for cluster scoped policies.
+// Create 填充要创建的策略的元数据和 spec，策略未指定命名空间时使用 namespace，集群级策略的 namespace 为空
+func (d *PolicyData[S]) Create(meta *metav1.ObjectMeta, spec *S, namespace string) {
+	if d.Spec != nil {
+		*meta = metav1.ObjectMeta{Name: d.Name, Namespace: namespace, Labels: d.Labels, Annotations: d.Annotations}
+		*spec = *d.Spec
+	}
+	if meta.Namespace == "" {
+		meta.Namespace = namespace
+	}
+}
+
+// Update replaces the metadata of a decoded policy with a copy of old, keeping the decoded spec. For typed data the
+// spec and the labels and annotations that are set are replaced. The resourceVersion is taken from the data or from
+// the YAML when set, so that the update fails with a Conflict error if the policy has changed since it was read.
+// Update 使用 old 的元数据替换解码后的策略的元数据，结构化数据提供标签或注解时一并替换；
+// 请求或 YAML 指定 resourceVersion 时使用该版本，策略在读取后被修改则更新返回冲突错误
+func (d *PolicyData[S]) Update(meta *metav1.ObjectMeta, spec *S, old *metav1.ObjectMeta) {
+	// only spec can be updated
+	decoded := meta.ResourceVersion
+	*meta = *old.DeepCopy()
+	if d.Spec != nil {
+		*spec = *d.Spec
+		if d.Labels != nil {
+			meta.Labels = d.Labels
+		}
+		if d.Annotations != nil {
+			meta.Annotations = d.Annotations
+		}
+	}
+	switch {
+	case d.ResourceVersion != "":
+		meta.ResourceVersion = d.ResourceVersion
+	case decoded != "":
+		meta.ResourceVersion = decoded
+	}
+}
+
+// ValidatePolicy validates the metadata of a policy of the given kind and returns an Invalid error with the causes
+// of both the metadata and the spec errors, or nil when there are none.
+// ValidatePolicy 校验策略的元数据，并与 spec 的校验错误一起返回 Invalid 错误，错误原因指向出错的字段
+func ValidatePolicy(kind schema.GroupKind, meta *metav1.ObjectMeta, namespaced bool, specErrs field.ErrorList) error {
+	errs := apivalidation.ValidateObjectMeta(meta, namespaced, apivalidation.NameIsDNSSubdomain, field.NewPath("metadata"))
+	errs = append(errs, specErrs...)
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(kind, meta.Name, errs)
+	}
+	return nil
+}
+
+// PolicyDeleteOptions returns the options to delete the policy read with meta. The UID precondition keeps a new
+// policy with the same name from being deleted, and resourceVersion, when set, makes the delete fail with a Conflict
+// error if the policy has changed since it was read.
+// PolicyDeleteOptions 返回删除策略的选项，使用 UID 避免删除同名的新策略，并在指定 resourceVersion 时检查版本
+func PolicyDeleteOptions(meta *metav1.ObjectMeta, resourceVersion string) metav1.DeleteOptions {
+	preconditions := &metav1.Preconditions{UID: &meta.UID}
+	if resourceVersion != "" {
+		preconditions.ResourceVersion = &resourceVersion
+	}
+	return metav1.DeleteOptions{Preconditions: preconditions}
+}
@@ -22,7 +22,9 @@ import (
 	"github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
 
 	"github.com/karmada-io/dashboard/pkg/common/errors"
 	"github.com/karmada-io/dashboard/pkg/common/types"
@@ -59,6 +61,19 @@ func GetNodeList(client kubernetes.Interface, dsQuery *dataselect.DataSelectQuer
 	return GetNodeListFromChannels(channels, dsQuery)
 }
 
+// GetNodeListFromLister returns a list of all Nodes in the cluster read from the informer cache.
+func GetNodeListFromLister(lister corelisters.NodeLister, dsQuery *dataselect.DataSelectQuery) (*NodeList, error) {
+	nodes, err := lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	items := make([]v1.Node, 0, len(nodes))
+	for _, node := range nodes {
+		items = append(items, *node)
+	}
+	return toNodeList(items, nil, dsQuery), nil
+}
+
 // GetNodeListFromChannels returns a list of all Nodes in the cluster reading required resource list once from the channels.
 func GetNodeListFromChannels(channels *common.ResourceChannels, dsQuery *dataselect.DataSelectQuery) (*NodeList, error) {
 	nodes := <-channels.NodeList.List
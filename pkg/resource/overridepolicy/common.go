@@ -59,3 +59,12 @@ func fromCells(cells []dataselect.DataCell) []v1alpha1.OverridePolicy {
 	}
 	return std
 }
+
+// fromObjects 复制 Lister 返回的对象，Lister 返回的对象不能被修改。
+func fromObjects(objects []*v1alpha1.OverridePolicy) []v1alpha1.OverridePolicy {
+	std := make([]v1alpha1.OverridePolicy, len(objects))
+	for i := range objects {
+		std[i] = *objects[i]
+	}
+	return std
+}
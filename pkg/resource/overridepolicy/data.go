@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+
+	"github.com/karmada-io/dashboard/pkg/resource/common"
+)
+
+// dataField 是请求中策略 YAML 的字段名
+const dataField = "overrideData"
+
+// PolicyData is a OverridePolicy or ClusterOverridePolicy to create or update, given either as YAML or as
+// typed fields. Exactly one of YAML and Spec must be set.
+// PolicyData 是要创建或更新的覆盖策略，使用 YAML 或结构化字段表示，YAML 和 Spec 必须且只能提供一个
+type PolicyData common.PolicyData[v1alpha1.OverrideSpec]
+
+// data 返回通用的策略数据
+func (d *PolicyData) data() *common.PolicyData[v1alpha1.OverrideSpec] {
+	return (*common.PolicyData[v1alpha1.OverrideSpec])(d)
+}
+
+// OverridePolicy returns the validated OverridePolicy to create.
+// OverridePolicy 返回要创建的 OverridePolicy，并按 Karmada webhook 的规则校验
+func (d *PolicyData) OverridePolicy() (*v1alpha1.OverridePolicy, error) {
+	policy := &v1alpha1.OverridePolicy{}
+	if err := d.data().Decode(policy, dataField); err != nil {
+		return nil, err
+	}
+	d.data().Create(&policy.ObjectMeta, &policy.Spec, d.Namespace)
+	if err := ValidateOverridePolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ClusterOverridePolicy returns the validated ClusterOverridePolicy to create.
+// ClusterOverridePolicy 返回要创建的 ClusterOverridePolicy，并按 Karmada webhook 的规则校验
+func (d *PolicyData) ClusterOverridePolicy() (*v1alpha1.ClusterOverridePolicy, error) {
+	policy := &v1alpha1.ClusterOverridePolicy{}
+	if err := d.data().Decode(policy, dataField); err != nil {
+		return nil, err
+	}
+	d.data().Create(&policy.ObjectMeta, &policy.Spec, "")
+	if err := ValidateClusterOverridePolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// UpdateOverridePolicy returns old with the spec replaced and, for typed data, the labels and annotations that are
+// set replaced. The rest of the metadata of old is kept. The resourceVersion is taken from the data or from the YAML
+// when set, so that the update fails with a Conflict error if the policy has changed since it was read.
+// UpdateOverridePolicy 返回替换了 spec 的 old，结构化数据提供标签或注解时一并替换，其余元数据保持不变；
+// 请求或 YAML 指定 resourceVersion 时使用该版本，策略在读取后被修改则更新返回冲突错误
+func (d *PolicyData) UpdateOverridePolicy(old *v1alpha1.OverridePolicy) (*v1alpha1.OverridePolicy, error) {
+	policy := &v1alpha1.OverridePolicy{}
+	if err := d.data().Decode(policy, dataField); err != nil {
+		return nil, err
+	}
+	policy.TypeMeta = old.TypeMeta
+	d.data().Update(&policy.ObjectMeta, &policy.Spec, &old.ObjectMeta)
+	if err := ValidateOverridePolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// UpdateClusterOverridePolicy returns old with the spec replaced and, for typed data, the labels and annotations
+// that are set replaced. The rest of the metadata of old is kept, see UpdateOverridePolicy for the resourceVersion.
+// UpdateClusterOverridePolicy 返回替换了 spec 的 old，结构化数据提供标签或注解时一并替换，其余元数据保持不变
+func (d *PolicyData) UpdateClusterOverridePolicy(old *v1alpha1.ClusterOverridePolicy) (*v1alpha1.ClusterOverridePolicy, error) {
+	policy := &v1alpha1.ClusterOverridePolicy{}
+	if err := d.data().Decode(policy, dataField); err != nil {
+		return nil, err
+	}
+	policy.TypeMeta = old.TypeMeta
+	d.data().Update(&policy.ObjectMeta, &policy.Spec, &old.ObjectMeta)
+	if err := ValidateClusterOverridePolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
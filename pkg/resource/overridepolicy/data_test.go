@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"testing"
+
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPolicyData(t *testing.T) {
+	spec := &v1alpha1.OverrideSpec{
+		ResourceSelectors: []v1alpha1.ResourceSelector{{APIVersion: "apps/v1", Kind: "Deployment"}},
+	}
+
+	// YAML 中未指定命名空间时使用请求的命名空间
+	data := &PolicyData{YAML: "metadata:\n  name: nginx\nspec:\n  resourceSelectors:\n  - apiVersion: apps/v1\n    kind: Deployment\n", Namespace: "default"}
+	policy, err := data.OverridePolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy.Namespace != "default" || len(policy.Spec.ResourceSelectors) != 1 {
+		t.Errorf("OverridePolicy() = %+v, expected the decoded policy in namespace default", policy)
+	}
+
+	if _, err = (&PolicyData{YAML: data.YAML, Spec: spec}).OverridePolicy(); !apierrors.IsBadRequest(err) {
+		t.Errorf("OverridePolicy() with both YAML and spec returned %v, expected a BadRequest error", err)
+	}
+	if _, err = (&PolicyData{Name: "nginx"}).ClusterOverridePolicy(); !apierrors.IsBadRequest(err) {
+		t.Errorf("ClusterOverridePolicy() without YAML and spec returned %v, expected a BadRequest error", err)
+	}
+
+	// 集群级策略不使用请求的命名空间
+	clusterPolicy, err := (&PolicyData{Name: "nginx", Namespace: "default", Spec: spec}).ClusterOverridePolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clusterPolicy.Name != "nginx" || clusterPolicy.Namespace != "" {
+		t.Errorf("ClusterOverridePolicy() = %+v, expected a cluster scoped policy named nginx", clusterPolicy.ObjectMeta)
+	}
+
+	// 校验错误指向出错的字段
+	invalid := spec.DeepCopy()
+	invalid.OverrideRules = []v1alpha1.RuleWithCluster{{}}
+	invalid.TargetCluster = &v1alpha1.ClusterAffinity{ClusterNames: []string{"member1"}} //nolint:staticcheck
+	_, err = (&PolicyData{Name: "nginx", Spec: invalid}).ClusterOverridePolicy()
+	if !apierrors.IsInvalid(err) {
+		t.Fatalf("ClusterOverridePolicy() returned %v, expected an Invalid error", err)
+	}
+	causes := err.(apierrors.APIStatus).Status().Details.Causes
+	if len(causes) != 1 || causes[0].Field != "spec.targetCluster" {
+		t.Errorf("ClusterOverridePolicy() returned causes %+v, expected spec.targetCluster", causes)
+	}
+
+	// 更新时保留已有的元数据，只替换 spec 和请求提供的标签
+	old := &v1alpha1.OverridePolicy{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default", Name: "nginx", ResourceVersion: "7",
+		Labels: map[string]string{"app": "nginx"}, Annotations: map[string]string{"owner": "web"},
+	}}
+	updated, err := (&PolicyData{Spec: spec, Labels: map[string]string{"app": "web"}}).UpdateOverridePolicy(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.ResourceVersion != "7" || updated.Labels["app"] != "web" || updated.Annotations["owner"] != "web" ||
+		len(updated.Spec.ResourceSelectors) != 1 {
+		t.Errorf("UpdateOverridePolicy() = %+v, expected the metadata of old with the new labels and spec", updated)
+	}
+	if old.Labels["app"] != "nginx" {
+		t.Errorf("UpdateOverridePolicy() modified old")
+	}
+
+	// 更新时使用请求或 YAML 中的 resourceVersion 进行冲突检查
+	oldCluster := &v1alpha1.ClusterOverridePolicy{ObjectMeta: metav1.ObjectMeta{Name: "nginx", ResourceVersion: "9", UID: "uid"}}
+	yamlData := &PolicyData{YAML: "metadata:\n  name: other\n  resourceVersion: \"8\"\nspec:\n  resourceSelectors:\n  - apiVersion: apps/v1\n    kind: Deployment\n"}
+	updatedCluster, err := yamlData.UpdateClusterOverridePolicy(oldCluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updatedCluster.Name != "nginx" || updatedCluster.UID != "uid" || updatedCluster.ResourceVersion != "8" {
+		t.Errorf("UpdateClusterOverridePolicy() = %+v, expected the metadata of old with resourceVersion 8", updatedCluster.ObjectMeta)
+	}
+	yamlData.ResourceVersion = "5"
+	if updatedCluster, err = yamlData.UpdateClusterOverridePolicy(oldCluster); err != nil || updatedCluster.ResourceVersion != "5" {
+		t.Errorf("UpdateClusterOverridePolicy() = %v, %v, expected resourceVersion 5 from the request", updatedCluster, err)
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"context"
+
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/karmada-io/dashboard/pkg/resource/common"
+)
+
+// DeleteClusterOverridePolicy deletes the ClusterOverridePolicy with the same preconditions as the propagation
+// policies: the UID of the policy read, and the resourceVersion when set, in which case the delete fails with a
+// Conflict error if the policy has changed.
+// DeleteClusterOverridePolicy 删除 ClusterOverridePolicy，resourceVersion 不为空时，策略已被修改则返回冲突错误
+func DeleteClusterOverridePolicy(ctx context.Context, client karmadaclientset.Interface, name, resourceVersion string) error {
+	policies := client.PolicyV1alpha1().ClusterOverridePolicies()
+	policy, err := policies.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	return policies.Delete(ctx, name, common.PolicyDeleteOptions(&policy.ObjectMeta, resourceVersion))
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDeleteClusterOverridePolicy(t *testing.T) {
+	client := karmadafake.NewSimpleClientset(
+		&v1alpha1.ClusterOverridePolicy{ObjectMeta: metav1.ObjectMeta{Name: "nginx", UID: "uid-1", ResourceVersion: "7"}},
+	)
+	// fake 客户端本身不检查删除的前置条件
+	var preconditions *metav1.Preconditions
+	client.PrependReactor("delete", "clusteroverridepolicies", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		preconditions = action.(k8stesting.DeleteAction).GetDeleteOptions().Preconditions
+		if preconditions != nil && preconditions.ResourceVersion != nil && *preconditions.ResourceVersion != "7" {
+			return true, nil, apierrors.NewConflict(v1alpha1.Resource("clusteroverridepolicies"), "nginx", nil)
+		}
+		return false, nil, nil
+	})
+	ctx := context.Background()
+
+	if err := DeleteClusterOverridePolicy(ctx, client, "nginx", "6"); !apierrors.IsConflict(err) {
+		t.Errorf("DeleteClusterOverridePolicy() of a changed policy returned %v, expected a Conflict error", err)
+	}
+	if err := DeleteClusterOverridePolicy(ctx, client, "nginx", "7"); err != nil {
+		t.Fatalf("DeleteClusterOverridePolicy() returned %v, expected no error", err)
+	}
+	if preconditions == nil || preconditions.UID == nil || *preconditions.UID != "uid-1" {
+		t.Errorf("DeleteClusterOverridePolicy() preconditions = %+v, expected the UID of the policy", preconditions)
+	}
+	if _, err := client.PolicyV1alpha1().ClusterOverridePolicies().Get(ctx, "nginx", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("ClusterOverridePolicy is not deleted: %v", err)
+	}
+}
@@ -17,11 +17,8 @@ limitations under the License.
 package overridepolicy
 
 import (
-	"context"
-
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
-	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	policylisters "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
 
 	"github.com/karmada-io/dashboard/pkg/common/errors"
 )
@@ -39,8 +36,8 @@ type OverridePolicyDetail struct {
 }
 
 // GetOverridePolicyDetail 获取OverridePolicy的详细信息。
-func GetOverridePolicyDetail(client karmadaclientset.Interface, namespace, name string) (*OverridePolicyDetail, error) {
-	OverridepolicyData, err := client.PolicyV1alpha1().OverridePolicies(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+func GetOverridePolicyDetail(lister policylisters.OverridePolicyLister, namespace, name string) (*OverridePolicyDetail, error) {
+	OverridepolicyData, err := lister.OverridePolicies(namespace).Get(name)
 	if err != nil {
 		return nil, err
 	}
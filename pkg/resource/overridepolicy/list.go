@@ -17,15 +17,13 @@ limitations under the License.
 package overridepolicy
 
 import (
-	"context"
 	"log"
 
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
-	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
-	"k8s.io/client-go/kubernetes"
+	policylisters "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/karmada-io/dashboard/pkg/common/errors"
-	"github.com/karmada-io/dashboard/pkg/common/helpers"
 	"github.com/karmada-io/dashboard/pkg/common/types"
 	"github.com/karmada-io/dashboard/pkg/dataselect"
 	"github.com/karmada-io/dashboard/pkg/resource/common"
@@ -53,19 +51,25 @@ type OverridePolicy struct {
 }
 
 // GetOverridePolicyList 返回Karmada控制平面中所有覆盖策略的列表。
-func GetOverridePolicyList(client karmadaclientset.Interface, k8sClient kubernetes.Interface, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*OverridePolicyList, error) {
+func GetOverridePolicyList(lister policylisters.OverridePolicyLister, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*OverridePolicyList, error) {
 	log.Println("Getting list of overridepolicy")
-	overridePolicies, err := client.PolicyV1alpha1().OverridePolicies(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+	var overridePolicies []*v1alpha1.OverridePolicy
+	var err error
+	if namespace := nsQuery.ToRequestParam(); namespace != "" {
+		overridePolicies, err = lister.OverridePolicies(namespace).List(labels.Everything())
+	} else {
+		overridePolicies, err = lister.List(labels.Everything())
+	}
 	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toOverridePolicyList(k8sClient, overridePolicies.Items, nonCriticalErrors, dsQuery), nil
+	return toOverridePolicyList(fromObjects(overridePolicies), nonCriticalErrors, dsQuery), nil
 }
 
 // toOverridePolicyList 将v1alpha1.OverridePolicy对象列表转换为OverridePolicyList对象。
-func toOverridePolicyList(_ kubernetes.Interface, overridepolicies []v1alpha1.OverridePolicy, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *OverridePolicyList {
+func toOverridePolicyList(overridepolicies []v1alpha1.OverridePolicy, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *OverridePolicyList {
 	overridepolicyList := &OverridePolicyList{
 		OverridePolicys: make([]OverridePolicy, 0),
 		ListMeta:        types.ListMeta{TotalItems: len(overridepolicies)},
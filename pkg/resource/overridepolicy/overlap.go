@@ -0,0 +1,276 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// imageField 是未设置 predicate 的 imageOverrider 修改的字段，即资源中所有容器的镜像
+const imageField = "image"
+
+// overriderField 是覆盖器修改的字段
+type overriderField struct {
+	// path 是字段的路径
+	path string
+	// component 是 imageOverrider 修改的镜像组成部分，为空时修改整个字段
+	component v1alpha1.ImageComponent
+}
+
+// String 返回字段的名称，镜像组成部分附加在路径之后，例如 /spec/template/spec/containers/0/image (Tag)
+func (f overriderField) String() string {
+	if f.component == "" {
+		return f.path
+	}
+	return fmt.Sprintf("%s (%s)", f.path, f.component)
+}
+
+// Overlap is a pair of override policies that override the same fields of a resource for the same clusters.
+// Overlap 是对同一资源在相同集群上覆盖相同字段的两个覆盖策略
+type Overlap struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Policies 是按应用顺序排列的两个策略，后应用的策略的值生效
+	Policies []string `json:"policies"`
+	// Fields 是两个策略都覆盖的字段，按名称排序
+	Fields []string `json:"fields"`
+	// Clusters 是两个策略都覆盖这些字段的集群，按名称排序
+	Clusters []string `json:"clusters"`
+}
+
+// overriddenField 是一个策略在一个集群上覆盖的字段
+type overriddenField struct {
+	policy string
+	field  overriderField
+}
+
+// Overlaps returns the pairs of override policies that override the same fields of resource, or a field and one of
+// its subfields, for any of the clusters. The policies are applied in the same order as Karmada does, so the last
+// policy of each pair is the one whose value ends up in the member cluster.
+// Overlaps 返回在任一集群上覆盖资源相同字段（或字段及其子字段）的覆盖策略对
+func Overlaps(resource *unstructured.Unstructured, clusters []*clusterv1alpha1.Cluster, policies *Policies) []Overlap {
+	ordered := orderedOverrides(resource, policies)
+	byPolicies := map[string]*Overlap{}
+	var keys []string
+	for _, cluster := range clusters {
+		var fields []overriddenField
+		for _, override := range ordered {
+			for _, rule := range override.rules {
+				if rule.TargetCluster != nil && !karmadautil.ClusterMatches(cluster, *rule.TargetCluster) {
+					continue
+				}
+				for _, field := range overriderFields(resource.GetKind(), rule.Overriders) {
+					fields = append(fields, overriddenField{policy: override.name, field: field})
+				}
+			}
+		}
+		for i := range fields {
+			for j := i + 1; j < len(fields); j++ {
+				first, second := fields[i], fields[j]
+				if first.policy == second.policy || !fieldsOverlap(first.field, second.field) {
+					continue
+				}
+				key := first.policy + "\n" + second.policy
+				overlap, ok := byPolicies[key]
+				if !ok {
+					overlap = &Overlap{
+						APIVersion: resource.GetAPIVersion(),
+						Kind:       resource.GetKind(),
+						Namespace:  resource.GetNamespace(),
+						Name:       resource.GetName(),
+						Policies:   []string{first.policy, second.policy},
+					}
+					byPolicies[key] = overlap
+					keys = append(keys, key)
+				}
+				overlap.Fields = appendUnique(overlap.Fields, first.field.String())
+				overlap.Fields = appendUnique(overlap.Fields, second.field.String())
+				overlap.Clusters = appendUnique(overlap.Clusters, cluster.Name)
+			}
+		}
+	}
+
+	overlaps := make([]Overlap, 0, len(keys))
+	for _, key := range keys {
+		overlap := byPolicies[key]
+		sort.Strings(overlap.Fields)
+		sort.Strings(overlap.Clusters)
+		overlaps = append(overlaps, *overlap)
+	}
+	return overlaps
+}
+
+// policyOverrides 是一个选中资源的覆盖策略及其规则
+type policyOverrides struct {
+	name     string
+	priority karmadautil.ImplicitPriority
+	rules    []v1alpha1.RuleWithCluster
+}
+
+// orderedOverrides 按 Karmada 的应用顺序返回选中资源的覆盖策略：先应用 ClusterOverridePolicy，再应用资源命名空间中的
+// OverridePolicy，同类策略按隐式优先级从低到高、再按名称排序
+func orderedOverrides(resource *unstructured.Unstructured, policies *Policies) []policyOverrides {
+	var clusterScoped, namespaced []policyOverrides
+	for _, policy := range policies.ClusterOverridePolicies {
+		if priority, ok := overridePriority(resource, &policy.Spec); ok {
+			clusterScoped = append(clusterScoped, policyOverrides{
+				name:     v1alpha1.ResourceKindClusterOverridePolicy + " " + policy.Name,
+				priority: priority,
+				rules:    overrideRules(&policy.Spec),
+			})
+		}
+	}
+	for _, policy := range policies.OverridePolicies {
+		if policy.Namespace != resource.GetNamespace() {
+			continue
+		}
+		if priority, ok := overridePriority(resource, &policy.Spec); ok {
+			namespaced = append(namespaced, policyOverrides{
+				name:     fmt.Sprintf("%s %s/%s", v1alpha1.ResourceKindOverridePolicy, policy.Namespace, policy.Name),
+				priority: priority,
+				rules:    overrideRules(&policy.Spec),
+			})
+		}
+	}
+	sortOverrides(clusterScoped)
+	sortOverrides(namespaced)
+	return append(clusterScoped, namespaced...)
+}
+
+// overridePriority 返回覆盖策略选中资源的隐式优先级，没有资源选择器的策略选中所有资源
+func overridePriority(resource *unstructured.Unstructured, spec *v1alpha1.OverrideSpec) (karmadautil.ImplicitPriority, bool) {
+	if len(spec.ResourceSelectors) == 0 {
+		return karmadautil.PriorityMatchAll, true
+	}
+	if !karmadautil.ResourceMatchSelectors(resource, spec.ResourceSelectors...) {
+		return karmadautil.PriorityMisMatch, false
+	}
+	return karmadautil.ResourceMatchSelectorsPriority(resource, spec.ResourceSelectors...), true
+}
+
+// overrideRules 返回覆盖策略的规则，兼容已废弃的 targetCluster 和 overriders 字段
+func overrideRules(spec *v1alpha1.OverrideSpec) []v1alpha1.RuleWithCluster {
+	if len(spec.OverrideRules) > 0 {
+		return spec.OverrideRules
+	}
+	//nolint:staticcheck
+	return []v1alpha1.RuleWithCluster{{TargetCluster: spec.TargetCluster, Overriders: spec.Overriders}}
+}
+
+// sortOverrides 按隐式优先级从低到高、再按名称排序覆盖策略，优先级高的策略后应用
+func sortOverrides(overrides []policyOverrides) {
+	sort.SliceStable(overrides, func(i, j int) bool {
+		if overrides[i].priority != overrides[j].priority {
+			return overrides[i].priority < overrides[j].priority
+		}
+		return overrides[i].name < overrides[j].name
+	})
+}
+
+// overriderFields 返回覆盖器修改的字段，路径使用 JSON Pointer 格式，但命令和参数的路径中使用容器名称代替容器的下标，
+// 未设置 predicate 的 imageOverrider 修改所有容器的镜像，路径为 image
+func overriderFields(kind string, overriders v1alpha1.Overriders) []overriderField {
+	var fields []overriderField
+	for _, overrider := range overriders.ImageOverrider {
+		path := imageField
+		if overrider.Predicate != nil {
+			path = overrider.Predicate.Path
+		}
+		fields = append(fields, overriderField{path: path, component: overrider.Component})
+	}
+	containers := containersPath(kind)
+	for _, overrider := range overriders.CommandOverrider {
+		fields = append(fields, overriderField{path: containers + "/" + escapePointer(overrider.ContainerName) + "/command"})
+	}
+	for _, overrider := range overriders.ArgsOverrider {
+		fields = append(fields, overriderField{path: containers + "/" + escapePointer(overrider.ContainerName) + "/args"})
+	}
+	for _, overrider := range overriders.LabelsOverrider {
+		for key := range overrider.Value {
+			fields = append(fields, overriderField{path: "/metadata/labels/" + escapePointer(key)})
+		}
+	}
+	for _, overrider := range overriders.AnnotationsOverrider {
+		for key := range overrider.Value {
+			fields = append(fields, overriderField{path: "/metadata/annotations/" + escapePointer(key)})
+		}
+	}
+	for _, overrider := range overriders.FieldOverrider {
+		for _, operation := range overrider.JSON {
+			fields = append(fields, overriderField{path: overrider.FieldPath + operation.SubPath})
+		}
+		for _, operation := range overrider.YAML {
+			fields = append(fields, overriderField{path: overrider.FieldPath + operation.SubPath})
+		}
+	}
+	for _, overrider := range overriders.Plaintext {
+		fields = append(fields, overriderField{path: overrider.Path})
+	}
+	return fields
+}
+
+// containersPath 返回资源中容器列表的路径，与 Karmada 的 commandOverrider 和 argsOverrider 一致
+func containersPath(kind string) string {
+	if kind == karmadautil.PodKind {
+		return "/spec/containers"
+	}
+	return "/spec/template/spec/containers"
+}
+
+// escapePointer 按 JSON Pointer 的规则转义标签或注解的键
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// fieldsOverlap 判断两个字段是否相同或其中一个是另一个的子字段，修改镜像不同组成部分的 imageOverrider 不重叠
+func fieldsOverlap(a, b overriderField) bool {
+	if a.component != "" && b.component != "" && a.component != b.component {
+		return false
+	}
+	return pathsOverlap(a.path, b.path)
+}
+
+// pathsOverlap 判断两个路径是否相同或其中一个是另一个的子路径，所有容器的镜像与任一以 image 结尾的路径重叠
+func pathsOverlap(a, b string) bool {
+	switch {
+	case a == b:
+		return true
+	case a == imageField:
+		return strings.HasSuffix(b, "/"+imageField)
+	case b == imageField:
+		return strings.HasSuffix(a, "/"+imageField)
+	}
+	return strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
+
+// appendUnique 在 values 中不存在 value 时追加 value
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"reflect"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestOverriderFields(t *testing.T) {
+	overriders := v1alpha1.Overriders{
+		ImageOverrider: []v1alpha1.ImageOverrider{
+			{Component: v1alpha1.Tag},
+			{Component: v1alpha1.Registry, Predicate: &v1alpha1.ImagePredicate{Path: "/spec/template/spec/containers/0/image"}},
+		},
+		CommandOverrider: []v1alpha1.CommandArgsOverrider{{ContainerName: "nginx"}},
+		ArgsOverrider:    []v1alpha1.CommandArgsOverrider{{ContainerName: "nginx"}},
+		LabelsOverrider:  []v1alpha1.LabelAnnotationOverrider{{Value: map[string]string{"app.kubernetes.io/name": "nginx"}}},
+	}
+	var got []string
+	for _, field := range overriderFields("Deployment", overriders) {
+		got = append(got, field.String())
+	}
+	want := []string{
+		"image (Tag)",
+		"/spec/template/spec/containers/0/image (Registry)",
+		"/spec/template/spec/containers/nginx/command",
+		"/spec/template/spec/containers/nginx/args",
+		"/metadata/labels/app.kubernetes.io~1name",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("overriderFields() = %v, want %v", got, want)
+	}
+
+	fields := overriderFields("Pod", v1alpha1.Overriders{CommandOverrider: []v1alpha1.CommandArgsOverrider{{ContainerName: "nginx"}}})
+	if len(fields) != 1 || fields[0].path != "/spec/containers/nginx/command" {
+		t.Errorf("overriderFields() of a Pod = %v", fields)
+	}
+}
+
+func TestFieldsOverlap(t *testing.T) {
+	const image = "/spec/template/spec/containers/0/image"
+	tests := []struct {
+		name string
+		a, b overriderField
+		want bool
+	}{
+		{name: "same field", a: overriderField{path: "/spec/replicas"}, b: overriderField{path: "/spec/replicas"}, want: true},
+		{name: "subfield", a: overriderField{path: "/spec/template"}, b: overriderField{path: "/spec/template/spec"}, want: true},
+		{name: "sibling fields", a: overriderField{path: "/spec/replicas"}, b: overriderField{path: "/spec/replicasets"}},
+		{
+			name: "same image component",
+			a:    overriderField{path: imageField, component: v1alpha1.Tag},
+			b:    overriderField{path: image, component: v1alpha1.Tag},
+			want: true,
+		},
+		{
+			name: "different image components",
+			a:    overriderField{path: imageField, component: v1alpha1.Tag},
+			b:    overriderField{path: image, component: v1alpha1.Registry},
+		},
+		{name: "image and the whole image field", a: overriderField{path: image, component: v1alpha1.Tag}, b: overriderField{path: image}, want: true},
+		{name: "image and the container", a: overriderField{path: image, component: v1alpha1.Tag}, b: overriderField{path: "/spec/template/spec/containers/0"}, want: true},
+		{
+			name: "command and the commands of the containers",
+			a:    overriderField{path: "/spec/template/spec/containers/nginx/command"},
+			b:    overriderField{path: "/spec/template/spec/containers"},
+			want: true,
+		},
+		{
+			name: "command and args",
+			a:    overriderField{path: "/spec/template/spec/containers/nginx/command"},
+			b:    overriderField{path: "/spec/template/spec/containers/nginx/args"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldsOverlap(tt.a, tt.b); got != tt.want {
+				t.Errorf("fieldsOverlap(%v, %v) = %t, want %t", tt.a, tt.b, got, tt.want)
+			}
+			if got := fieldsOverlap(tt.b, tt.a); got != tt.want {
+				t.Errorf("fieldsOverlap(%v, %v) = %t, want %t", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	resource := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"namespace": "default", "name": "nginx"},
+	}}
+	clusters := []*clusterv1alpha1.Cluster{{ObjectMeta: metav1.ObjectMeta{Name: "member1"}}}
+	overridePolicy := func(name string, overriders v1alpha1.Overriders) *v1alpha1.OverridePolicy {
+		return &v1alpha1.OverridePolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       v1alpha1.OverrideSpec{OverrideRules: []v1alpha1.RuleWithCluster{{Overriders: overriders}}},
+		}
+	}
+
+	// registry 和 tag 修改镜像的不同组成部分，command 和 entrypoint 修改同一容器的命令
+	policies := &Policies{OverridePolicies: []*v1alpha1.OverridePolicy{
+		overridePolicy("registry", v1alpha1.Overriders{ImageOverrider: []v1alpha1.ImageOverrider{{Component: v1alpha1.Registry}}}),
+		overridePolicy("tag", v1alpha1.Overriders{ImageOverrider: []v1alpha1.ImageOverrider{{Component: v1alpha1.Tag}}}),
+		overridePolicy("command", v1alpha1.Overriders{CommandOverrider: []v1alpha1.CommandArgsOverrider{{ContainerName: "nginx"}}}),
+		overridePolicy("entrypoint", v1alpha1.Overriders{CommandOverrider: []v1alpha1.CommandArgsOverrider{{ContainerName: "nginx"}}}),
+	}}
+	expected := []Overlap{{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Namespace:  "default",
+		Name:       "nginx",
+		Policies:   []string{"OverridePolicy default/command", "OverridePolicy default/entrypoint"},
+		Fields:     []string{"/spec/template/spec/containers/nginx/command"},
+		Clusters:   []string{"member1"},
+	}}
+	if got := Overlaps(resource, clusters, policies); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Overlaps() = %+v, expected %+v", got, expected)
+	}
+}
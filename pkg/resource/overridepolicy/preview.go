@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"fmt"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/util/overridemanager"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/karmada-io/dashboard/pkg/common/diff"
+)
+
+// ClusterPreview is the resource rendered for one cluster after the override policies are applied.
+// ClusterPreview 是应用覆盖策略后为一个集群渲染的资源
+type ClusterPreview struct {
+	// Cluster 是目标集群名称
+	Cluster string `json:"cluster"`
+	// AppliedPolicies 是按应用顺序排列的策略，ClusterOverridePolicy 先于 OverridePolicy 应用
+	AppliedPolicies []string `json:"appliedPolicies"`
+	// Manifest 是渲染后的资源 YAML
+	Manifest string `json:"manifest"`
+	// Diff 是渲染后的资源相对于资源模板的统一格式差异，没有变化时为空
+	Diff string `json:"diff"`
+	// Error 是应用覆盖策略失败的原因，Karmada 在这种情况下不会向该集群分发资源
+	Error string `json:"error,omitempty"`
+}
+
+// Policies are the override policies that take part in a preview.
+// Policies 是参与预览的覆盖策略
+type Policies struct {
+	OverridePolicies        []*v1alpha1.OverridePolicy
+	ClusterOverridePolicies []*v1alpha1.ClusterOverridePolicy
+}
+
+// Preview applies the override policies to resource for each cluster with the override manager of Karmada, so the
+// policies are matched and applied in the same order as Karmada does: ClusterOverridePolicies first, then the
+// OverridePolicies in the namespace of the resource, each ordered by the implicit priority of their resource
+// selectors and then by name.
+// Preview 使用 Karmada 的 override manager 为每个集群应用覆盖策略，返回渲染后的资源和差异
+func Preview(resource *unstructured.Unstructured, clusters []*clusterv1alpha1.Cluster, policies *Policies) ([]ClusterPreview, error) {
+	template, err := yaml.Marshal(resource.Object)
+	if err != nil {
+		return nil, err
+	}
+	previews := make([]ClusterPreview, 0, len(clusters))
+	for _, cluster := range clusters {
+		// Karmada 的 override manager 会为应用的策略记录事件，预览时丢弃这些事件
+		manager := overridemanager.New(policyClient(cluster, policies), &record.FakeRecorder{})
+		rendered := resource.DeepCopy()
+		preview := ClusterPreview{Cluster: cluster.Name, AppliedPolicies: []string{}}
+		clusterOverrides, namespacedOverrides, err := manager.ApplyOverridePolicies(rendered, cluster.Name)
+		if err != nil {
+			preview.Error = err.Error()
+			previews = append(previews, preview)
+			continue
+		}
+		if clusterOverrides != nil {
+			for _, item := range clusterOverrides.AppliedItems {
+				preview.AppliedPolicies = append(preview.AppliedPolicies, v1alpha1.ResourceKindClusterOverridePolicy+" "+item.PolicyName)
+			}
+		}
+		if namespacedOverrides != nil {
+			for _, item := range namespacedOverrides.AppliedItems {
+				preview.AppliedPolicies = append(preview.AppliedPolicies,
+					fmt.Sprintf("%s %s/%s", v1alpha1.ResourceKindOverridePolicy, resource.GetNamespace(), item.PolicyName))
+			}
+		}
+		manifest, err := yaml.Marshal(rendered.Object)
+		if err != nil {
+			return nil, err
+		}
+		preview.Manifest = string(manifest)
+		preview.Diff = diff.Unified("template", cluster.Name, string(template), preview.Manifest)
+		previews = append(previews, preview)
+	}
+	return previews, nil
+}
+
+// previewScheme 包含 override manager 读取的集群和覆盖策略类型
+var previewScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clusterv1alpha1.AddToScheme(previewScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(previewScheme))
+}
+
+// policyClient 返回从内存中提供目标集群和参与预览的覆盖策略的客户端，override manager 只读取这些对象
+func policyClient(cluster *clusterv1alpha1.Cluster, policies *Policies) client.Client {
+	objects := []client.Object{cluster.DeepCopy()}
+	for _, policy := range policies.ClusterOverridePolicies {
+		objects = append(objects, policy.DeepCopy())
+	}
+	for _, policy := range policies.OverridePolicies {
+		objects = append(objects, policy.DeepCopy())
+	}
+	return fake.NewClientBuilder().WithScheme(previewScheme).WithObjects(objects...).Build()
+}
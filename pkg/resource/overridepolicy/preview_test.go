@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPreview(t *testing.T) {
+	resource := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"namespace": "default", "name": "nginx"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{map[string]interface{}{"name": "nginx", "image": "nginx:1.25"}},
+				},
+			},
+		},
+	}}
+	selector := []v1alpha1.ResourceSelector{{APIVersion: "apps/v1", Kind: "Deployment"}}
+	clusters := []*clusterv1alpha1.Cluster{
+		{ObjectMeta: metav1.ObjectMeta{Name: "member1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "member2"}},
+	}
+
+	// ClusterOverridePolicy 为所有集群添加标签，OverridePolicy 只替换 member1 的镜像仓库
+	policies := &Policies{
+		ClusterOverridePolicies: []*v1alpha1.ClusterOverridePolicy{{
+			ObjectMeta: metav1.ObjectMeta{Name: "labels"},
+			Spec: v1alpha1.OverrideSpec{
+				ResourceSelectors: selector,
+				OverrideRules: []v1alpha1.RuleWithCluster{{
+					Overriders: v1alpha1.Overriders{LabelsOverrider: []v1alpha1.LabelAnnotationOverrider{{
+						Operator: v1alpha1.OverriderOpAdd,
+						Value:    map[string]string{"team": "web"},
+					}}},
+				}},
+			},
+		}},
+		OverridePolicies: []*v1alpha1.OverridePolicy{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "registry"},
+			Spec: v1alpha1.OverrideSpec{
+				ResourceSelectors: selector,
+				OverrideRules: []v1alpha1.RuleWithCluster{{
+					TargetCluster: &v1alpha1.ClusterAffinity{ClusterNames: []string{"member1"}},
+					Overriders: v1alpha1.Overriders{ImageOverrider: []v1alpha1.ImageOverrider{{
+						Component: v1alpha1.Registry,
+						Operator:  v1alpha1.OverriderOpAdd,
+						Value:     "registry.example.com",
+					}}},
+				}},
+			},
+		}},
+	}
+
+	previews, err := Preview(resource, clusters, policies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(previews) != 2 {
+		t.Fatalf("Preview() returned %d previews, expected 2", len(previews))
+	}
+	member1, member2 := previews[0], previews[1]
+	expected := []string{"ClusterOverridePolicy labels", "OverridePolicy default/registry"}
+	if !reflect.DeepEqual(member1.AppliedPolicies, expected) {
+		t.Errorf("member1 applied policies %v, expected %v", member1.AppliedPolicies, expected)
+	}
+	if !strings.Contains(member1.Manifest, "image: registry.example.com/nginx:1.25") {
+		t.Errorf("member1 manifest does not have the overridden image:\n%s", member1.Manifest)
+	}
+	if !strings.Contains(member1.Diff, "+      - image: registry.example.com/nginx:1.25") ||
+		!strings.Contains(member1.Diff, "+    team: web") {
+		t.Errorf("member1 diff does not have the overridden fields:\n%s", member1.Diff)
+	}
+	if !reflect.DeepEqual(member2.AppliedPolicies, expected[:1]) {
+		t.Errorf("member2 applied policies %v, expected %v", member2.AppliedPolicies, expected[:1])
+	}
+	if strings.Contains(member2.Manifest, "registry.example.com") {
+		t.Errorf("member2 is not targeted by the OverridePolicy:\n%s", member2.Manifest)
+	}
+	if resource.GetLabels() != nil {
+		t.Errorf("Preview() modified the resource template")
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overridepolicy
+
+import (
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/util/validation"
+
+	"github.com/karmada-io/dashboard/pkg/resource/common"
+)
+
+// ValidateOverridePolicy validates the metadata and the spec of policy with the same rules as the Karmada webhook.
+// The causes of the returned Invalid error point to the offending fields, e.g. spec.overrideRules[0].overriders.
+// ValidateOverridePolicy 按 Karmada webhook 的规则校验 OverridePolicy，错误原因指向出错的字段
+func ValidateOverridePolicy(policy *v1alpha1.OverridePolicy) error {
+	return common.ValidatePolicy(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.ResourceKindOverridePolicy).GroupKind(),
+		&policy.ObjectMeta, true, validation.ValidateOverrideSpec(&policy.Spec))
+}
+
+// ValidateClusterOverridePolicy validates the metadata and the spec of policy with the same rules as the Karmada
+// webhook. The causes of the returned Invalid error point to the offending fields.
+// ValidateClusterOverridePolicy 按 Karmada webhook 的规则校验 ClusterOverridePolicy，错误原因指向出错的字段
+func ValidateClusterOverridePolicy(policy *v1alpha1.ClusterOverridePolicy) error {
+	return common.ValidatePolicy(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.ResourceKindClusterOverridePolicy).GroupKind(),
+		&policy.ObjectMeta, false, validation.ValidateOverrideSpec(&policy.Spec))
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/karmada-io/dashboard/pkg/resource/cluster"
+	"github.com/karmada-io/dashboard/pkg/resource/overridepolicy"
+)
+
+// Conflict is a resource template matched by more than one PropagationPolicy or ClusterPropagationPolicy.
+// Conflict 是被多个 PropagationPolicy 或 ClusterPropagationPolicy 选中的资源模板
+type Conflict struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Policies 是选中该资源的策略，按优先级从高到低排序
+	Policies []*Policy `json:"policies"`
+	// Winner 是按优先级认领该资源的策略
+	Winner *Policy `json:"winner"`
+	// ClaimedBy 是当前认领该资源的策略，未被认领时为空
+	ClaimedBy *Policy `json:"claimedBy,omitempty"`
+	// Reason 说明 Winner 胜出的原因，以及当前认领资源的策略不是 Winner 时资源的去向
+	Reason string `json:"reason"`
+}
+
+// Analysis is the result of analyzing the existing policies.
+// Analysis 是分析现有策略的结果
+type Analysis struct {
+	// Conflicts 是被多个策略选中的资源，按 API 版本、类型、命名空间和名称排序
+	Conflicts []Conflict `json:"conflicts"`
+	// UnmatchedPolicies 是没有选中任何资源的策略
+	UnmatchedPolicies []*Policy `json:"unmatchedPolicies"`
+	// OverrideOverlaps 是在被分发的资源上覆盖相同字段的覆盖策略
+	OverrideOverlaps []overridepolicy.Overlap `json:"overrideOverlaps"`
+	// Warnings 是无法列出资源的警告
+	Warnings []string `json:"warnings"`
+}
+
+// matchedObject 是被策略选中的资源及选中它的策略
+type matchedObject struct {
+	obj      *unstructured.Unstructured
+	policies []*Policy
+}
+
+// Analyze scans the resource templates selected by policies and reports the resources matched by more than one policy
+// with the policy that wins them and why, the policies that match nothing, and the override policies that override
+// the same fields of a propagated resource for the same clusters. The clusters of a resource are the ones selected by
+// the Placement of the policy that propagates it.
+// Analyze 分析现有策略：被多个策略选中的资源及胜出的策略和原因、没有选中资源的策略，以及在被分发的资源上覆盖相同字段的
+// 覆盖策略
+func Analyze(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, policies []*Policy,
+	overridePolicies *overridepolicy.Policies, clusters []*clusterv1alpha1.Cluster) (*Analysis, error) {
+	analysis := &Analysis{
+		Conflicts:         []Conflict{},
+		UnmatchedPolicies: []*Policy{},
+		OverrideOverlaps:  []overridepolicy.Overlap{},
+		Warnings:          []string{},
+	}
+
+	objects, err := matchObjects(ctx, client, mapper, policies, analysis)
+	if err != nil {
+		return nil, err
+	}
+	for _, object := range objects {
+		sort.SliceStable(object.policies, func(i, j int) bool {
+			wins, _ := outranks(object.obj, object.policies[i], object.policies[j])
+			return wins
+		})
+		winner := object.policies[0]
+		claimed := claimedBy(object.obj, winner, policies)
+		if len(object.policies) > 1 {
+			analysis.Conflicts = append(analysis.Conflicts, conflict(object, claimed))
+		}
+		// 资源已被其他策略认领且不会被抢占时，按认领资源的策略分发
+		propagating := winner
+		if claimed != nil && claimed.spec != nil && !canPreempt(winner, claimed, object.obj) {
+			propagating = claimed
+		}
+		if overridePolicies != nil {
+			targets := cluster.PlacementClusters(&propagating.spec.Placement, clusters)
+			analysis.OverrideOverlaps = append(analysis.OverrideOverlaps, overridepolicy.Overlaps(object.obj, targets, overridePolicies)...)
+		}
+	}
+	return analysis, nil
+}
+
+// matchObjects 返回策略选中的资源，按 API 版本、类型、命名空间和名称排序，并将没有选中资源的策略和无法列出资源的警告
+// 记录到 analysis
+func matchObjects(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, policies []*Policy,
+	analysis *Analysis) ([]*matchedObject, error) {
+	objects := map[string]*matchedObject{}
+	var keys []string
+	for _, policy := range policies {
+		matched := false
+		for _, selector := range policy.spec.ResourceSelectors {
+			objs, err := selectResources(ctx, client, mapper, policy, selector)
+			if err != nil {
+				if meta.IsNoMatchError(err) || apierrors.IsForbidden(err) {
+					analysis.Warnings = append(analysis.Warnings, fmt.Sprintf("%s cannot list %s %s: %v", policy, selector.Kind, selector.APIVersion, err))
+					continue
+				}
+				return nil, err
+			}
+			for _, obj := range objs {
+				matched = true
+				key := obj.GroupVersionKind().String() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+				object, ok := objects[key]
+				if !ok {
+					object = &matchedObject{obj: obj}
+					objects[key] = object
+					keys = append(keys, key)
+				}
+				if len(object.policies) == 0 || !object.policies[len(object.policies)-1].same(policy) {
+					object.policies = append(object.policies, policy)
+				}
+			}
+		}
+		if !matched {
+			analysis.UnmatchedPolicies = append(analysis.UnmatchedPolicies, policy)
+		}
+	}
+
+	sort.Strings(keys)
+	sorted := make([]*matchedObject, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, objects[key])
+	}
+	return sorted, nil
+}
+
+// conflict 返回被多个策略选中的资源的冲突，object 的策略已按优先级从高到低排序
+func conflict(object *matchedObject, claimed *Policy) Conflict {
+	obj := object.obj
+	winner, runnerUp := object.policies[0], object.policies[1]
+	_, rule := outranks(obj, winner, runnerUp)
+	result := Conflict{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Policies:   object.policies,
+		Winner:     winner,
+		ClaimedBy:  claimed,
+		Reason:     fmt.Sprintf("%s wins over %s: %s", winner, runnerUp, rule),
+	}
+	switch {
+	case claimed == nil || winner.same(claimed):
+	case canPreempt(winner, claimed, obj):
+		result.Reason += fmt.Sprintf(", it preempts %s if the PolicyPreemption feature gate is enabled", claimed)
+	default:
+		result.Reason += fmt.Sprintf(", but the resource stays with %s, which claimed it first", claimed)
+	}
+	return result
+}
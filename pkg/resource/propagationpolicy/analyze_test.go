@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/karmada-io/dashboard/pkg/resource/overridepolicy"
+)
+
+// replicasOverride 返回修改 Deployment 副本数的 OverridePolicy
+func replicasOverride(name string, selector v1alpha1.ResourceSelector) *v1alpha1.OverridePolicy {
+	selector.APIVersion, selector.Kind = "apps/v1", "Deployment"
+	return &v1alpha1.OverridePolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: v1alpha1.OverrideSpec{
+			ResourceSelectors: []v1alpha1.ResourceSelector{selector},
+			OverrideRules: []v1alpha1.RuleWithCluster{{
+				Overriders: v1alpha1.Overriders{Plaintext: []v1alpha1.PlaintextOverrider{{
+					Path:     "/spec/replicas",
+					Operator: v1alpha1.OverriderOpReplace,
+				}}},
+			}},
+		},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(gvr.GroupVersion().WithKind("Deployment"), meta.RESTScopeNamespace)
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "DeploymentList"},
+		deployment("web", map[string]string{"app": "web"}, nil),
+		deployment("api", map[string]string{"app": "api"}, nil),
+	)
+	clusters := []*clusterv1alpha1.Cluster{
+		{ObjectMeta: metav1.ObjectMeta{Name: "member1", Labels: map[string]string{"env": "prod"}}},
+	}
+
+	// by-kind 选中所有 Deployment，与按名称选中 api 的 named-api 和按标签选中 web 的 by-label 冲突
+	policies := []*Policy{
+		FromPropagationPolicy(propagationPolicy("named-api", 0, v1alpha1.ResourceSelector{Name: "api"})),
+		FromPropagationPolicy(propagationPolicy("by-label", 0, v1alpha1.ResourceSelector{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		})),
+		FromPropagationPolicy(propagationPolicy("by-kind", 0, v1alpha1.ResourceSelector{})),
+		FromPropagationPolicy(propagationPolicy("missing", 0, v1alpha1.ResourceSelector{Name: "missing"})),
+	}
+	overrides := &overridepolicy.Policies{OverridePolicies: []*v1alpha1.OverridePolicy{
+		replicasOverride("web-replicas", v1alpha1.ResourceSelector{Name: "web"}),
+		replicasOverride("default-replicas", v1alpha1.ResourceSelector{}),
+	}}
+	result, err := Analyze(context.TODO(), client, mapper, policies, overrides, clusters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Conflicts) != 2 {
+		t.Fatalf("Analyze() found %d conflicts, expected 2: %+v", len(result.Conflicts), result.Conflicts)
+	}
+	api, web := result.Conflicts[0], result.Conflicts[1]
+	if api.Winner.Name != "named-api" || !strings.Contains(api.Reason, "selecting by name takes precedence") {
+		t.Errorf("api is selected by name by the policy named-api, got %+v", api)
+	}
+	if web.Winner.Name != "by-label" || len(web.Policies) != 2 || web.Policies[1].Name != "by-kind" {
+		t.Errorf("web is selected by label by the policy by-label, got %+v", web)
+	}
+	if len(result.UnmatchedPolicies) != 1 || result.UnmatchedPolicies[0].Name != "missing" {
+		t.Errorf("Analyze() unmatched policies %v, expected missing", result.UnmatchedPolicies)
+	}
+
+	// 两个 OverridePolicy 都修改 web 的副本数，按名称选中的 web-replicas 后应用
+	expected := []overridepolicy.Overlap{{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Namespace:  "default",
+		Name:       "web",
+		Policies:   []string{"OverridePolicy default/default-replicas", "OverridePolicy default/web-replicas"},
+		Fields:     []string{"/spec/replicas"},
+		Clusters:   []string{"member1"},
+	}}
+	if !reflect.DeepEqual(result.OverrideOverlaps, expected) {
+		t.Errorf("Analyze() override overlaps %+v, expected %+v", result.OverrideOverlaps, expected)
+	}
+}
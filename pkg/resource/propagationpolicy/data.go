@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+
+	"github.com/karmada-io/dashboard/pkg/resource/common"
+)
+
+// dataField 是请求中策略 YAML 的字段名
+const dataField = "propagationData"
+
+// PolicyData is a PropagationPolicy or ClusterPropagationPolicy to create or update, given either as YAML or as
+// typed fields. Exactly one of YAML and Spec must be set.
+// PolicyData 是要创建或更新的传播策略，使用 YAML 或结构化字段表示，YAML 和 Spec 必须且只能提供一个
+type PolicyData common.PolicyData[v1alpha1.PropagationSpec]
+
+// data 返回通用的策略数据
+func (d *PolicyData) data() *common.PolicyData[v1alpha1.PropagationSpec] {
+	return (*common.PolicyData[v1alpha1.PropagationSpec])(d)
+}
+
+// PropagationPolicy returns the validated PropagationPolicy to create.
+// PropagationPolicy 返回要创建的 PropagationPolicy，并按 Karmada webhook 的规则校验
+func (d *PolicyData) PropagationPolicy() (*v1alpha1.PropagationPolicy, error) {
+	policy := &v1alpha1.PropagationPolicy{}
+	if err := d.data().Decode(policy, dataField); err != nil {
+		return nil, err
+	}
+	d.data().Create(&policy.ObjectMeta, &policy.Spec, d.Namespace)
+	if err := ValidatePropagationPolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ClusterPropagationPolicy returns the validated ClusterPropagationPolicy to create.
+// ClusterPropagationPolicy 返回要创建的 ClusterPropagationPolicy，并按 Karmada webhook 的规则校验
+func (d *PolicyData) ClusterPropagationPolicy() (*v1alpha1.ClusterPropagationPolicy, error) {
+	policy := &v1alpha1.ClusterPropagationPolicy{}
+	if err := d.data().Decode(policy, dataField); err != nil {
+		return nil, err
+	}
+	d.data().Create(&policy.ObjectMeta, &policy.Spec, "")
+	if err := ValidateClusterPropagationPolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// UpdatePropagationPolicy returns old with the spec replaced and, for typed data, the labels and annotations that are
+// set replaced. The rest of the metadata of old is kept. The resourceVersion is taken from the data or from the YAML
+// when set, so that the update fails with a Conflict error if the policy has changed since it was read.
+// UpdatePropagationPolicy 返回替换了 spec 的 old，结构化数据提供标签或注解时一并替换，其余元数据保持不变；
+// 请求或 YAML 指定 resourceVersion 时使用该版本，策略在读取后被修改则更新返回冲突错误
+func (d *PolicyData) UpdatePropagationPolicy(old *v1alpha1.PropagationPolicy) (*v1alpha1.PropagationPolicy, error) {
+	policy := &v1alpha1.PropagationPolicy{}
+	if err := d.data().Decode(policy, dataField); err != nil {
+		return nil, err
+	}
+	policy.TypeMeta = old.TypeMeta
+	d.data().Update(&policy.ObjectMeta, &policy.Spec, &old.ObjectMeta)
+	if err := ValidatePropagationPolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// UpdateClusterPropagationPolicy returns old with the spec replaced and, for typed data, the labels and annotations
+// that are set replaced. The rest of the metadata of old is kept, see UpdatePropagationPolicy for the resourceVersion.
+// UpdateClusterPropagationPolicy 返回替换了 spec 的 old，结构化数据提供标签或注解时一并替换，其余元数据保持不变
+func (d *PolicyData) UpdateClusterPropagationPolicy(old *v1alpha1.ClusterPropagationPolicy) (*v1alpha1.ClusterPropagationPolicy, error) {
+	policy := &v1alpha1.ClusterPropagationPolicy{}
+	if err := d.data().Decode(policy, dataField); err != nil {
+		return nil, err
+	}
+	policy.TypeMeta = old.TypeMeta
+	d.data().Update(&policy.ObjectMeta, &policy.Spec, &old.ObjectMeta)
+	if err := ValidateClusterPropagationPolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"testing"
+
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPolicyData(t *testing.T) {
+	spec := &v1alpha1.PropagationSpec{
+		ResourceSelectors: []v1alpha1.ResourceSelector{{APIVersion: "apps/v1", Kind: "Deployment"}},
+	}
+
+	// YAML 中未指定命名空间时使用请求的命名空间
+	data := &PolicyData{YAML: "metadata:\n  name: nginx\nspec:\n  resourceSelectors:\n  - apiVersion: apps/v1\n    kind: Deployment\n", Namespace: "default"}
+	policy, err := data.PropagationPolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy.Namespace != "default" || len(policy.Spec.ResourceSelectors) != 1 {
+		t.Errorf("PropagationPolicy() = %+v, expected the decoded policy in namespace default", policy)
+	}
+
+	if _, err = (&PolicyData{YAML: data.YAML, Spec: spec}).PropagationPolicy(); !apierrors.IsBadRequest(err) {
+		t.Errorf("PropagationPolicy() with both YAML and spec returned %v, expected a BadRequest error", err)
+	}
+
+	// 校验错误指向出错的字段
+	invalid := spec.DeepCopy()
+	invalid.Placement.SpreadConstraints = []v1alpha1.SpreadConstraint{{SpreadByField: v1alpha1.SpreadByFieldCluster, MinGroups: 3, MaxGroups: 1}}
+	_, err = (&PolicyData{Name: "nginx", Spec: invalid}).ClusterPropagationPolicy()
+	if !apierrors.IsInvalid(err) {
+		t.Fatalf("ClusterPropagationPolicy() returned %v, expected an Invalid error", err)
+	}
+	causes := err.(apierrors.APIStatus).Status().Details.Causes
+	if len(causes) != 1 || causes[0].Field != "spec.placement.spreadConstraints[0]" {
+		t.Errorf("ClusterPropagationPolicy() returned causes %+v, expected spec.placement.spreadConstraints[0]", causes)
+	}
+
+	// 更新时保留已有的元数据，只替换 spec 和请求提供的标签
+	old := &v1alpha1.PropagationPolicy{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default", Name: "nginx", ResourceVersion: "7",
+		Labels: map[string]string{"app": "nginx"}, Annotations: map[string]string{"owner": "web"},
+	}}
+	updated, err := (&PolicyData{Spec: spec, Labels: map[string]string{"app": "web"}}).UpdatePropagationPolicy(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.ResourceVersion != "7" || updated.Labels["app"] != "web" || updated.Annotations["owner"] != "web" ||
+		len(updated.Spec.ResourceSelectors) != 1 {
+		t.Errorf("UpdatePropagationPolicy() = %+v, expected the metadata of old with the new labels and spec", updated)
+	}
+	if old.Labels["app"] != "nginx" {
+		t.Errorf("UpdatePropagationPolicy() modified old")
+	}
+
+	// 更新时使用请求或 YAML 中的 resourceVersion 进行冲突检查
+	oldCluster := &v1alpha1.ClusterPropagationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "nginx", ResourceVersion: "9", UID: "uid"}}
+	yamlData := &PolicyData{YAML: "metadata:\n  name: other\n  resourceVersion: \"8\"\nspec:\n  resourceSelectors:\n  - apiVersion: apps/v1\n    kind: Deployment\n"}
+	updatedCluster, err := yamlData.UpdateClusterPropagationPolicy(oldCluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updatedCluster.Name != "nginx" || updatedCluster.UID != "uid" || updatedCluster.ResourceVersion != "8" {
+		t.Errorf("UpdateClusterPropagationPolicy() = %+v, expected the metadata of old with resourceVersion 8", updatedCluster.ObjectMeta)
+	}
+	yamlData.ResourceVersion = "5"
+	if updatedCluster, err = yamlData.UpdateClusterPropagationPolicy(oldCluster); err != nil || updatedCluster.ResourceVersion != "5" {
+		t.Errorf("UpdateClusterPropagationPolicy() = %v, %v, expected resourceVersion 5 from the request", updatedCluster, err)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"context"
+	"time"
+
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/resource/common"
+)
+
+var (
+	// releasePollInterval 是删除策略后检查绑定是否释放的间隔
+	releasePollInterval = 500 * time.Millisecond
+	// releaseTimeout 是删除策略后等待绑定释放的最长时间
+	releaseTimeout = 30 * time.Second
+)
+
+// DeleteResult is the result of a successful delete of a propagation policy.
+// DeleteResult 是成功删除传播策略的结果
+type DeleteResult struct {
+	// Released 表示已确认策略被删除且不再有绑定被其占用，未等待或等待超时时为 false，绑定仍在释放中
+	Released bool `json:"released"`
+}
+
+// DeletePropagationPolicy deletes the PropagationPolicy. When resourceVersion is set the delete fails with a Conflict
+// error if the policy has changed. When wait is set it then waits, for a limited time, until the policy is gone and
+// no ResourceBinding is claimed by it any more; a policy still releasing its bindings is not an error.
+// DeletePropagationPolicy 删除 PropagationPolicy，resourceVersion 不为空时，策略已被修改则返回冲突错误；
+// wait 为 true 时在限定时间内等待策略被删除且不再有 ResourceBinding 被其占用，绑定仍在释放中不视为错误
+func DeletePropagationPolicy(ctx context.Context, client karmadaclientset.Interface, namespace, name, resourceVersion string,
+	wait bool) (*DeleteResult, error) {
+	policies := client.PolicyV1alpha1().PropagationPolicies(namespace)
+	policy, err := policies.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if err = policies.Delete(ctx, name, common.PolicyDeleteOptions(&policy.ObjectMeta, resourceVersion)); err != nil {
+		return nil, err
+	}
+	if !wait {
+		return &DeleteResult{}, nil
+	}
+	selector := permanentIDSelector(v1alpha1.PropagationPolicyPermanentIDLabel, policy.Labels)
+	return waitForRelease(ctx, name, func(ctx context.Context) (bool, error) {
+		if _, err := policies.Get(ctx, name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		if selector == "" {
+			return true, nil
+		}
+		bindings, err := client.WorkV1alpha2().ResourceBindings(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return false, err
+		}
+		return len(bindings.Items) == 0, nil
+	}), nil
+}
+
+// DeleteClusterPropagationPolicy deletes the ClusterPropagationPolicy. When resourceVersion is set the delete fails
+// with a Conflict error if the policy has changed. When wait is set it then waits, for a limited time, until the
+// policy is gone and no ResourceBinding or ClusterResourceBinding is claimed by it any more.
+// DeleteClusterPropagationPolicy 删除 ClusterPropagationPolicy，resourceVersion 不为空时，策略已被修改则返回冲突错误；
+// wait 为 true 时在限定时间内等待策略被删除且不再有 ResourceBinding 或 ClusterResourceBinding 被其占用
+func DeleteClusterPropagationPolicy(ctx context.Context, client karmadaclientset.Interface, name, resourceVersion string,
+	wait bool) (*DeleteResult, error) {
+	policies := client.PolicyV1alpha1().ClusterPropagationPolicies()
+	policy, err := policies.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if err = policies.Delete(ctx, name, common.PolicyDeleteOptions(&policy.ObjectMeta, resourceVersion)); err != nil {
+		return nil, err
+	}
+	if !wait {
+		return &DeleteResult{}, nil
+	}
+	selector := permanentIDSelector(v1alpha1.ClusterPropagationPolicyPermanentIDLabel, policy.Labels)
+	return waitForRelease(ctx, name, func(ctx context.Context) (bool, error) {
+		if _, err := policies.Get(ctx, name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		if selector == "" {
+			return true, nil
+		}
+		bindings, err := client.WorkV1alpha2().ResourceBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil || len(bindings.Items) > 0 {
+			return false, err
+		}
+		clusterBindings, err := client.WorkV1alpha2().ClusterResourceBindings().List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return false, err
+		}
+		return len(clusterBindings.Items) == 0, nil
+	}), nil
+}
+
+// permanentIDSelector 返回选择被策略占用的绑定的标签选择器，策略没有永久 ID 时返回空
+func permanentIDSelector(key string, policyLabels map[string]string) string {
+	id := policyLabels[key]
+	if id == "" {
+		return ""
+	}
+	return labels.SelectorFromSet(labels.Set{key: id}).String()
+}
+
+// waitForRelease 轮询直到 released 返回 true，策略已经删除，超时或检查失败时只记录日志并返回绑定仍在释放中的结果
+func waitForRelease(ctx context.Context, name string, released wait.ConditionWithContextFunc) *DeleteResult {
+	if err := wait.PollUntilContextTimeout(ctx, releasePollInterval, releaseTimeout, true, released); err != nil {
+		klog.InfoS("Policy is deleted but its bindings are not released yet", "policy", name, "reason", err)
+		return &DeleteResult{}
+	}
+	return &DeleteResult{Released: true}
+}
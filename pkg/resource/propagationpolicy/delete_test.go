@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// shortRelease 缩短等待绑定释放的时间，返回恢复原值的函数
+func shortRelease() func() {
+	interval, timeout := releasePollInterval, releaseTimeout
+	releasePollInterval, releaseTimeout = 10*time.Millisecond, 100*time.Millisecond
+	return func() { releasePollInterval, releaseTimeout = interval, timeout }
+}
+
+func TestDeletePropagationPolicy(t *testing.T) {
+	defer shortRelease()()
+	claimed := map[string]string{v1alpha1.PropagationPolicyPermanentIDLabel: "id-1"}
+	client := karmadafake.NewSimpleClientset(
+		&v1alpha1.PropagationPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "claimed", Labels: claimed}},
+		&v1alpha1.PropagationPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "released", Labels: claimed}},
+		&v1alpha1.PropagationPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nowait", Labels: claimed}},
+		&v1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-deployment", Labels: claimed}},
+	)
+	ctx := context.Background()
+
+	// 绑定仍被占用时等待超时，删除依然成功
+	result, err := DeletePropagationPolicy(ctx, client, "default", "claimed", "", true)
+	if err != nil || result.Released {
+		t.Errorf("DeletePropagationPolicy() = %+v, %v, expected the bindings still releasing", result, err)
+	}
+	if _, err = client.PolicyV1alpha1().PropagationPolicies("default").Get(ctx, "claimed", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("PropagationPolicy claimed is not deleted: %v", err)
+	}
+
+	// 不等待时立即返回
+	if result, err = DeletePropagationPolicy(ctx, client, "default", "nowait", "", false); err != nil || result.Released {
+		t.Errorf("DeletePropagationPolicy() without wait = %+v, %v, expected the bindings still releasing", result, err)
+	}
+
+	if err = client.WorkV1alpha2().ResourceBindings("default").Delete(ctx, "nginx-deployment", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if result, err = DeletePropagationPolicy(ctx, client, "default", "released", "", true); err != nil || !result.Released {
+		t.Errorf("DeletePropagationPolicy() = %+v, %v, expected the bindings released", result, err)
+	}
+	if _, err = DeletePropagationPolicy(ctx, client, "default", "released", "", true); !apierrors.IsNotFound(err) {
+		t.Errorf("DeletePropagationPolicy() of a deleted policy returned %v, expected a NotFound error", err)
+	}
+}
+
+func TestDeleteClusterPropagationPolicy(t *testing.T) {
+	defer shortRelease()()
+	claimed := map[string]string{v1alpha1.ClusterPropagationPolicyPermanentIDLabel: "id-1"}
+	client := karmadafake.NewSimpleClientset(
+		&v1alpha1.ClusterPropagationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "claimed", Labels: claimed}},
+		&v1alpha1.ClusterPropagationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "released"}},
+		&v1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx-deployment", Labels: claimed}},
+	)
+	ctx := context.Background()
+
+	// 策略已删除但绑定仍被占用时等待超时，删除依然成功
+	result, err := DeleteClusterPropagationPolicy(ctx, client, "claimed", "", true)
+	if err != nil || result.Released {
+		t.Errorf("DeleteClusterPropagationPolicy() = %+v, %v, expected the bindings still releasing", result, err)
+	}
+	if _, err = client.PolicyV1alpha1().ClusterPropagationPolicies().Get(ctx, "claimed", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("ClusterPropagationPolicy claimed is not deleted: %v", err)
+	}
+
+	if result, err = DeleteClusterPropagationPolicy(ctx, client, "released", "", true); err != nil || !result.Released {
+		t.Errorf("DeleteClusterPropagationPolicy() = %+v, %v, expected the bindings released", result, err)
+	}
+	if _, err = DeleteClusterPropagationPolicy(ctx, client, "released", "", true); !apierrors.IsNotFound(err) {
+		t.Errorf("DeleteClusterPropagationPolicy() of a deleted policy returned %v, expected a NotFound error", err)
+	}
+}
+
+// checkPreconditions 返回检查删除前置条件的 reactor，fake 客户端本身不检查 UID 和 resourceVersion
+func checkPreconditions(uid, resourceVersion string) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		deleteAction := action.(k8stesting.DeleteAction)
+		preconditions := deleteAction.GetDeleteOptions().Preconditions
+		switch {
+		case preconditions == nil || preconditions.UID == nil || string(*preconditions.UID) != uid:
+			return true, nil, apierrors.NewConflict(v1alpha1.Resource(action.GetResource().Resource), deleteAction.GetName(), nil)
+		case preconditions.ResourceVersion != nil && *preconditions.ResourceVersion != resourceVersion:
+			return true, nil, apierrors.NewConflict(v1alpha1.Resource(action.GetResource().Resource), deleteAction.GetName(), nil)
+		}
+		return false, nil, nil
+	}
+}
+
+func TestDeleteResourceVersionConflict(t *testing.T) {
+	client := karmadafake.NewSimpleClientset(
+		&v1alpha1.PropagationPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nginx", UID: "uid-1", ResourceVersion: "7"}},
+		&v1alpha1.ClusterPropagationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "nginx", UID: "uid-2", ResourceVersion: "7"}},
+	)
+	client.PrependReactor("delete", "propagationpolicies", checkPreconditions("uid-1", "7"))
+	client.PrependReactor("delete", "clusterpropagationpolicies", checkPreconditions("uid-2", "7"))
+	ctx := context.Background()
+
+	if _, err := DeletePropagationPolicy(ctx, client, "default", "nginx", "6", false); !apierrors.IsConflict(err) {
+		t.Errorf("DeletePropagationPolicy() of a changed policy returned %v, expected a Conflict error", err)
+	}
+	if _, err := DeleteClusterPropagationPolicy(ctx, client, "nginx", "6", false); !apierrors.IsConflict(err) {
+		t.Errorf("DeleteClusterPropagationPolicy() of a changed policy returned %v, expected a Conflict error", err)
+	}
+	if _, err := client.PolicyV1alpha1().PropagationPolicies("default").Get(ctx, "nginx", metav1.GetOptions{}); err != nil {
+		t.Errorf("PropagationPolicy is deleted despite the conflict: %v", err)
+	}
+
+	if _, err := DeletePropagationPolicy(ctx, client, "default", "nginx", "7", false); err != nil {
+		t.Errorf("DeletePropagationPolicy() returned %v, expected no error", err)
+	}
+	if _, err := DeleteClusterPropagationPolicy(ctx, client, "nginx", "", false); err != nil {
+		t.Errorf("DeleteClusterPropagationPolicy() without resourceVersion returned %v, expected no error", err)
+	}
+}
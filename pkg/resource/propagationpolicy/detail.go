@@ -17,11 +17,8 @@ limitations under the License.
 package propagationpolicy
 
 import (
-	"context"
-
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
-	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	policylisters "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
 
 	"github.com/karmada-io/dashboard/pkg/common/errors"
 )
@@ -40,8 +37,8 @@ type PropagationPolicyDetail struct {
 
 // GetPropagationPolicyDetail gets propagationpolicy details.
 // GetPropagationPolicyDetail 获取PropagationPolicy的详细信息。
-func GetPropagationPolicyDetail(client karmadaclientset.Interface, namespace, name string) (*PropagationPolicyDetail, error) {
-	propagationpolicyData, err := client.PolicyV1alpha1().PropagationPolicies(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+func GetPropagationPolicyDetail(lister policylisters.PropagationPolicyLister, namespace, name string) (*PropagationPolicyDetail, error) {
+	propagationpolicyData, err := lister.PropagationPolicies(namespace).Get(name)
 	if err != nil {
 		return nil, err
 	}
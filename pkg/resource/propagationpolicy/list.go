@@ -17,18 +17,16 @@ limitations under the License.
 package propagationpolicy
 
 import (
-	"context"
 	"fmt"
 	"log"
 	"strings"
 
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
-	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
-	"k8s.io/client-go/kubernetes"
+	policylisters "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
 
-	"github.com/karmada-io/dashboard/pkg/client"
+	dashboardclient "github.com/karmada-io/dashboard/pkg/client"
 	"github.com/karmada-io/dashboard/pkg/common/errors"
-	"github.com/karmada-io/dashboard/pkg/common/helpers"
 	"github.com/karmada-io/dashboard/pkg/common/types"
 	"github.com/karmada-io/dashboard/pkg/dataselect"
 	"github.com/karmada-io/dashboard/pkg/resource/common"
@@ -57,19 +55,26 @@ type PropagationPolicy struct {
 }
 
 // GetPropagationPolicyList 返回Karmada控制平面中所有传播的列表。
-func GetPropagationPolicyList(client karmadaclientset.Interface, k8sClient kubernetes.Interface, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*PropagationPolicyList, error) {
+// verber 用于检查策略选中的资源是否存在，调用方应传入使用请求令牌构建的客户端。
+func GetPropagationPolicyList(lister policylisters.PropagationPolicyLister, verber dashboardclient.ResourceVerber, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*PropagationPolicyList, error) {
 	log.Println("Getting list of namespaces")
-	propagationpolicies, err := client.PolicyV1alpha1().PropagationPolicies(nsQuery.ToRequestParam()).List(context.TODO(), helpers.ListEverything)
+	var propagationpolicies []*v1alpha1.PropagationPolicy
+	var err error
+	if namespace := nsQuery.ToRequestParam(); namespace != "" {
+		propagationpolicies, err = lister.PropagationPolicies(namespace).List(labels.Everything())
+	} else {
+		propagationpolicies, err = lister.List(labels.Everything())
+	}
 	nonCriticalErrors, criticalError := errors.ExtractErrors(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toPropagationPolicyList(k8sClient, propagationpolicies.Items, nonCriticalErrors, dsQuery), nil
+	return toPropagationPolicyList(verber, fromObjects(propagationpolicies), nonCriticalErrors, dsQuery), nil
 }
 
 // toPropagationPolicyList 将v1alpha1.PropagationPolicy对象列表转换为PropagationPolicyList对象。
-func toPropagationPolicyList(_ kubernetes.Interface, propagationpolicies []v1alpha1.PropagationPolicy, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *PropagationPolicyList {
+func toPropagationPolicyList(verberClient dashboardclient.ResourceVerber, propagationpolicies []v1alpha1.PropagationPolicy, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *PropagationPolicyList {
 	propagationpolicyList := &PropagationPolicyList{
 		PropagationPolicys: make([]PropagationPolicy, 0),
 		ListMeta:           types.ListMeta{TotalItems: len(propagationpolicies)},
@@ -79,10 +84,6 @@ func toPropagationPolicyList(_ kubernetes.Interface, propagationpolicies []v1alp
 	propagationpolicyList.ListMeta = types.ListMeta{TotalItems: filteredTotal}
 	propagationpolicyList.Errors = nonCriticalErrors
 
-	verberClient, err := client.VerberClient(nil)
-	if err != nil {
-		panic(err)
-	}
 	for _, propagationpolicy := range propagationpolicies {
 		relatedResources := make([]string, 0)
 		for _, rs := range propagationpolicy.Spec.ResourceSelectors {
@@ -90,7 +91,7 @@ func toPropagationPolicyList(_ kubernetes.Interface, propagationpolicies []v1alp
 			if getErr != nil {
 				continue
 			}
-			if errors.IsNotFound(err) || getRes == nil {
+			if getRes == nil {
 				continue
 			}
 			relatedResources = append(relatedResources, fmt.Sprintf("%s/%s", rs.Namespace, rs.Name))
@@ -0,0 +1,380 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/karmada-io/dashboard/pkg/resource/cluster"
+)
+
+// Policy is a PropagationPolicy or ClusterPropagationPolicy that takes part in a simulation.
+// Policy 是参与模拟的 PropagationPolicy 或 ClusterPropagationPolicy
+type Policy struct {
+	// Kind 是 PropagationPolicy 或 ClusterPropagationPolicy
+	Kind string `json:"kind"`
+	// Namespace 是 PropagationPolicy 的命名空间，ClusterPropagationPolicy 为空
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Priority 是策略的显式优先级
+	Priority int32 `json:"priority"`
+	// Preemption 是策略的抢占行为
+	Preemption v1alpha1.PreemptionBehavior `json:"preemption,omitempty"`
+
+	spec *v1alpha1.PropagationSpec
+}
+
+// FromPropagationPolicy returns the Policy of a PropagationPolicy.
+// FromPropagationPolicy 返回 PropagationPolicy 对应的 Policy
+func FromPropagationPolicy(policy *v1alpha1.PropagationPolicy) *Policy {
+	return &Policy{
+		Kind:       v1alpha1.ResourceKindPropagationPolicy,
+		Namespace:  policy.Namespace,
+		Name:       policy.Name,
+		Priority:   policy.ExplicitPriority(),
+		Preemption: policy.Spec.Preemption,
+		spec:       &policy.Spec,
+	}
+}
+
+// FromClusterPropagationPolicy returns the Policy of a ClusterPropagationPolicy.
+// FromClusterPropagationPolicy 返回 ClusterPropagationPolicy 对应的 Policy
+func FromClusterPropagationPolicy(policy *v1alpha1.ClusterPropagationPolicy) *Policy {
+	return &Policy{
+		Kind:       v1alpha1.ResourceKindClusterPropagationPolicy,
+		Name:       policy.Name,
+		Priority:   policy.ExplicitPriority(),
+		Preemption: policy.Spec.Preemption,
+		spec:       &policy.Spec,
+	}
+}
+
+// same 判断两个策略是否是同一个对象
+func (p *Policy) same(other *Policy) bool {
+	return other != nil && p.Kind == other.Kind && p.Namespace == other.Namespace && p.Name == other.Name
+}
+
+// clusterScoped 判断策略是否是 ClusterPropagationPolicy
+func (p *Policy) clusterScoped() bool {
+	return p.Kind == v1alpha1.ResourceKindClusterPropagationPolicy
+}
+
+// String 返回策略的类型和名称
+func (p *Policy) String() string {
+	if p.Namespace == "" {
+		return p.Kind + " " + p.Name
+	}
+	return p.Kind + " " + p.Namespace + "/" + p.Name
+}
+
+// MatchedResource is a resource template selected by the ResourceSelectors of the simulated policy.
+// MatchedResource 是被模拟策略的资源选择器选中的资源模板
+type MatchedResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// ClaimedBy 是当前认领该资源的策略，未被认领时为空
+	ClaimedBy *Policy `json:"claimedBy,omitempty"`
+	// MatchedBy 是其他同样选中该资源的策略
+	MatchedBy []*Policy `json:"matchedBy"`
+	// Winner 是按优先级选中该资源的策略
+	Winner *Policy `json:"winner,omitempty"`
+	// Propagated 为 true 时保存策略后资源将按该策略分发
+	Propagated bool `json:"propagated"`
+	// Reason 说明资源是否将按该策略分发
+	Reason string `json:"reason"`
+}
+
+// Simulation is what a PropagationPolicy or ClusterPropagationPolicy would do if it was saved.
+// Simulation 是保存 PropagationPolicy 或 ClusterPropagationPolicy 后的模拟结果
+type Simulation struct {
+	// Resources 是策略的资源选择器选中的控制面资源
+	Resources []MatchedResource `json:"resources"`
+	// Placement 是策略的调度策略在当前集群上的评估结果
+	Placement *cluster.PlacementResult `json:"placement"`
+	// Warnings 是无法列出资源或选中的集群不提供被分发资源类型的警告
+	Warnings []string `json:"warnings"`
+}
+
+// Simulate returns what policy would do if it was saved: the existing resource templates that its ResourceSelectors
+// match, which policy would win each of them by priority, and the clusters its Placement would select. policies are
+// the existing policies which may claim the same resources, an existing policy with the same name as policy is
+// replaced by it.
+// Simulate 模拟保存策略后的效果：选中的资源、按优先级胜出的策略以及调度策略选择的集群
+func Simulate(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, policy *Policy, policies []*Policy,
+	clusters []*clusterv1alpha1.Cluster) (*Simulation, error) {
+	others := make([]*Policy, 0, len(policies))
+	for _, other := range policies {
+		if !policy.same(other) {
+			others = append(others, other)
+		}
+	}
+	simulation := &Simulation{
+		Resources: []MatchedResource{},
+		Placement: cluster.EvaluatePlacement(&policy.spec.Placement, clusters),
+		Warnings:  []string{},
+	}
+
+	seen := map[string]bool{}
+	for _, selector := range policy.spec.ResourceSelectors {
+		objs, err := selectResources(ctx, client, mapper, policy, selector)
+		if err != nil {
+			if meta.IsNoMatchError(err) || apierrors.IsForbidden(err) {
+				simulation.Warnings = append(simulation.Warnings, fmt.Sprintf("cannot list %s %s: %v", selector.Kind, selector.APIVersion, err))
+				continue
+			}
+			return nil, err
+		}
+		for _, obj := range objs {
+			key := obj.GroupVersionKind().String() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			simulation.Resources = append(simulation.Resources, matchResource(obj, policy, others))
+		}
+	}
+
+	gvks := make([]schema.GroupVersionKind, 0, len(policy.spec.ResourceSelectors))
+	for _, selector := range policy.spec.ResourceSelectors {
+		gvks = append(gvks, schema.FromAPIVersionAndKind(selector.APIVersion, selector.Kind))
+	}
+	check := cluster.CheckAPIEnablement(cluster.PlacementClusters(&policy.spec.Placement, clusters), gvks)
+	simulation.Warnings = append(simulation.Warnings, check.Warnings...)
+
+	sort.SliceStable(simulation.Resources, func(i, j int) bool {
+		a, b := simulation.Resources[i], simulation.Resources[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return simulation, nil
+}
+
+// selectResources 列出资源选择器选中的资源模板，PropagationPolicy 只选择其命名空间中的资源
+func selectResources(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, policy *Policy,
+	selector v1alpha1.ResourceSelector) ([]*unstructured.Unstructured, error) {
+	gvk := schema.FromAPIVersionAndKind(selector.APIVersion, selector.Kind)
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+	if !policy.clusterScoped() {
+		if !namespaced {
+			return nil, nil
+		}
+		selector.Namespace = policy.Namespace
+	}
+	resource := dynamic.ResourceInterface(client.Resource(mapping.Resource))
+	if namespaced {
+		resource = client.Resource(mapping.Resource).Namespace(selector.Namespace)
+	}
+
+	var items []unstructured.Unstructured
+	if selector.Name != "" {
+		obj, err := resource.Get(ctx, selector.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *obj)
+	} else {
+		opts := metav1.ListOptions{}
+		if selector.LabelSelector != nil {
+			labelSelector, err := metav1.LabelSelectorAsSelector(selector.LabelSelector)
+			if err != nil {
+				return nil, err
+			}
+			opts.LabelSelector = labelSelector.String()
+		}
+		list, err := resource.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		items = list.Items
+	}
+
+	objs := make([]*unstructured.Unstructured, 0, len(items))
+	for i := range items {
+		if karmadautil.ResourceMatches(&items[i], selector) {
+			objs = append(objs, &items[i])
+		}
+	}
+	return objs, nil
+}
+
+// matchResource 返回资源当前认领的策略、其他选中资源的策略，以及保存 policy 后按优先级和抢占规则认领资源的策略
+func matchResource(obj *unstructured.Unstructured, policy *Policy, others []*Policy) MatchedResource {
+	matched := MatchedResource{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		MatchedBy:  []*Policy{},
+	}
+	var candidates []*Policy
+	for _, other := range others {
+		if policySelects(other, obj) {
+			matched.MatchedBy = append(matched.MatchedBy, other)
+			candidates = append(candidates, other)
+		}
+	}
+	candidates = append(candidates, policy)
+	matched.Winner = highestPriority(obj, candidates)
+	matched.ClaimedBy = claimedBy(obj, policy, others)
+
+	claimed := matched.ClaimedBy
+	switch {
+	case claimed == nil:
+		matched.Propagated = policy.same(matched.Winner)
+		if matched.Propagated {
+			matched.Reason = "the resource is not claimed yet and the policy has the highest priority"
+		} else {
+			matched.Reason = fmt.Sprintf("the resource is not claimed yet, %s has a higher priority", matched.Winner)
+		}
+	case policy.same(claimed):
+		matched.Propagated = true
+		matched.Reason = "the resource is already claimed by the policy"
+	case canPreempt(policy, claimed, obj):
+		matched.Propagated = true
+		matched.Reason = fmt.Sprintf("the policy preempts %s if the PolicyPreemption feature gate is enabled", claimed)
+	default:
+		matched.Reason = fmt.Sprintf("the resource stays with %s, which claimed it first", claimed)
+	}
+	return matched
+}
+
+// policySelects 判断策略是否可以选中资源，PropagationPolicy 只能选中其命名空间中的资源
+func policySelects(policy *Policy, obj *unstructured.Unstructured) bool {
+	if !policy.clusterScoped() && (obj.GetNamespace() == "" || policy.Namespace != obj.GetNamespace()) {
+		return false
+	}
+	return karmadautil.ResourceMatchSelectors(obj, policy.spec.ResourceSelectors...)
+}
+
+// highestPriority 按 Karmada 的规则返回选中资源的策略中优先级最高的策略
+func highestPriority(obj *unstructured.Unstructured, candidates []*Policy) *Policy {
+	var winner *Policy
+	for _, candidate := range candidates {
+		if winner == nil {
+			winner = candidate
+			continue
+		}
+		if wins, _ := outranks(obj, candidate, winner); wins {
+			winner = candidate
+		}
+	}
+	return winner
+}
+
+// outranks 判断策略 a 认领资源的优先级是否高于策略 b，并返回决定胜负的规则：命名空间中的 PropagationPolicy 优先于
+// ClusterPropagationPolicy，其次比较显式优先级、隐式优先级，最后名称较小的策略胜出
+func outranks(obj *unstructured.Unstructured, a, b *Policy) (bool, string) {
+	if a.clusterScoped() != b.clusterScoped() {
+		return !a.clusterScoped(), "a PropagationPolicy takes precedence over a ClusterPropagationPolicy"
+	}
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority, fmt.Sprintf("explicit priority %d is higher than %d", max(a.Priority, b.Priority), min(a.Priority, b.Priority))
+	}
+	implicitA := karmadautil.ResourceMatchSelectorsPriority(obj, a.spec.ResourceSelectors...)
+	implicitB := karmadautil.ResourceMatchSelectorsPriority(obj, b.spec.ResourceSelectors...)
+	if implicitA != implicitB {
+		return implicitA > implicitB, fmt.Sprintf("%s takes precedence over %s",
+			implicitPriorityName(max(implicitA, implicitB)), implicitPriorityName(min(implicitA, implicitB)))
+	}
+	return a.Name < b.Name, "the priorities are equal and the policy with the smaller name wins"
+}
+
+// implicitPriorityName 描述资源选择器选中资源的方式
+func implicitPriorityName(priority karmadautil.ImplicitPriority) string {
+	switch priority {
+	case karmadautil.PriorityMatchName:
+		return "selecting by name"
+	case karmadautil.PriorityMatchLabelSelector:
+		return "selecting by label selector"
+	default:
+		return "selecting all resources of the kind"
+	}
+}
+
+// claimedBy 根据资源的注解返回当前认领资源的策略
+func claimedBy(obj *unstructured.Unstructured, policy *Policy, others []*Policy) *Policy {
+	annotations := obj.GetAnnotations()
+	claimed := &Policy{
+		Kind:      v1alpha1.ResourceKindPropagationPolicy,
+		Namespace: annotations[v1alpha1.PropagationPolicyNamespaceAnnotation],
+		Name:      annotations[v1alpha1.PropagationPolicyNameAnnotation],
+	}
+	if claimed.Name == "" {
+		claimed = &Policy{Kind: v1alpha1.ResourceKindClusterPropagationPolicy, Name: annotations[v1alpha1.ClusterPropagationPolicyAnnotation]}
+	}
+	if claimed.Name == "" {
+		return nil
+	}
+	if policy.same(claimed) {
+		return policy
+	}
+	for _, other := range others {
+		if other.same(claimed) {
+			return other
+		}
+	}
+	// 认领资源的策略已被删除或不可见时，仅返回其名称
+	return claimed
+}
+
+// canPreempt 按 Karmada 的抢占规则判断 policy 是否可以从 claimed 抢占资源：PropagationPolicy 可以抢占
+// ClusterPropagationPolicy 和优先级更低的 PropagationPolicy，ClusterPropagationPolicy 只能抢占优先级更低的
+// ClusterPropagationPolicy。Karmada 只抢占按名称选中的资源
+func canPreempt(policy, claimed *Policy, obj *unstructured.Unstructured) bool {
+	if policy.Preemption != v1alpha1.PreemptAlways {
+		return false
+	}
+	namedSelector := false
+	for _, selector := range policy.spec.ResourceSelectors {
+		if selector.Name == obj.GetName() && karmadautil.ResourceMatches(obj, selector) {
+			namedSelector = true
+		}
+	}
+	if !namedSelector {
+		return false
+	}
+	if !policy.clusterScoped() && claimed.clusterScoped() {
+		return true
+	}
+	return policy.clusterScoped() == claimed.clusterScoped() && policy.Priority > claimed.Priority
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"context"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// deployment 返回带有标签和注解的 Deployment
+func deployment(name string, labels, annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	return obj
+}
+
+// propagationPolicy 返回选择 Deployment 的 PropagationPolicy
+func propagationPolicy(name string, priority int32, selector v1alpha1.ResourceSelector) *v1alpha1.PropagationPolicy {
+	selector.APIVersion, selector.Kind = "apps/v1", "Deployment"
+	return &v1alpha1.PropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: v1alpha1.PropagationSpec{
+			ResourceSelectors: []v1alpha1.ResourceSelector{selector},
+			Priority:          &priority,
+			Placement: v1alpha1.Placement{
+				ClusterAffinity: &v1alpha1.ClusterAffinity{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				},
+			},
+		},
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(gvr.GroupVersion().WithKind("Deployment"), meta.RESTScopeNamespace)
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "DeploymentList"},
+		deployment("web", map[string]string{"app": "web"}, nil),
+		deployment("api", map[string]string{"app": "api"}, map[string]string{
+			v1alpha1.PropagationPolicyNamespaceAnnotation: "default",
+			v1alpha1.PropagationPolicyNameAnnotation:      "existing",
+		}),
+		deployment("db", map[string]string{"app": "db"}, nil),
+	)
+	clusters := []*clusterv1alpha1.Cluster{
+		{ObjectMeta: metav1.ObjectMeta{Name: "member1", Labels: map[string]string{"env": "prod"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "member2", Labels: map[string]string{"env": "test"}}},
+	}
+
+	// 模拟的策略选中所有 Deployment，已有的策略按名称选中 db 和 api
+	simulated := propagationPolicy("simulated", 1, v1alpha1.ResourceSelector{})
+	existing := []*Policy{
+		FromPropagationPolicy(propagationPolicy("existing", 5, v1alpha1.ResourceSelector{Name: "api"})),
+		FromPropagationPolicy(propagationPolicy("named", 0, v1alpha1.ResourceSelector{Name: "db"})),
+	}
+	result, err := Simulate(context.TODO(), client, mapper, FromPropagationPolicy(simulated), existing, clusters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Resources) != 3 {
+		t.Fatalf("Simulate() matched %d resources, expected 3", len(result.Resources))
+	}
+	byName := map[string]MatchedResource{}
+	for _, resource := range result.Resources {
+		byName[resource.Name] = resource
+	}
+	if api := byName["api"]; api.Propagated || api.ClaimedBy == nil || api.ClaimedBy.Name != "existing" {
+		t.Errorf("api is claimed by a policy with a higher priority, got %+v", api)
+	}
+	if db := byName["db"]; !db.Propagated || db.Winner == nil || db.Winner.Name != "simulated" {
+		t.Errorf("db is not claimed, the simulated policy has the higher explicit priority, got %+v", db)
+	}
+	if web := byName["web"]; !web.Propagated {
+		t.Errorf("web is only matched by the simulated policy, got %+v", web)
+	}
+	if len(result.Placement.SelectedClusters) != 1 || result.Placement.SelectedClusters[0] != "member1" {
+		t.Errorf("Simulate() selected clusters %v, expected member1", result.Placement.SelectedClusters)
+	}
+}
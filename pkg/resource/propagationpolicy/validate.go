@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagationpolicy
+
+import (
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"github.com/karmada-io/karmada/pkg/util/validation"
+
+	"github.com/karmada-io/dashboard/pkg/resource/common"
+)
+
+// ValidatePropagationPolicy validates the metadata and the spec of policy with the same rules as the Karmada webhook.
+// The causes of the returned Invalid error point to the offending fields, e.g. spec.placement.clusterAffinity.
+// ValidatePropagationPolicy 按 Karmada webhook 的规则校验 PropagationPolicy，错误原因指向出错的字段
+func ValidatePropagationPolicy(policy *v1alpha1.PropagationPolicy) error {
+	return common.ValidatePolicy(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.ResourceKindPropagationPolicy).GroupKind(),
+		&policy.ObjectMeta, true, validation.ValidatePropagationSpec(policy.Spec))
+}
+
+// ValidateClusterPropagationPolicy validates the metadata and the spec of policy with the same rules as the Karmada
+// webhook. The causes of the returned Invalid error point to the offending fields.
+// ValidateClusterPropagationPolicy 按 Karmada webhook 的规则校验 ClusterPropagationPolicy，错误原因指向出错的字段
+func ValidateClusterPropagationPolicy(policy *v1alpha1.ClusterPropagationPolicy) error {
+	return common.ValidatePolicy(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.ResourceKindClusterPropagationPolicy).GroupKind(),
+		&policy.ObjectMeta, false, validation.ValidatePropagationSpec(policy.Spec))
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+	"github.com/karmada-io/dashboard/pkg/resource/common"
+)
+
+// ObjectCell wraps an unstructured object so that it can be filtered by the dataselect package.
+// ObjectCell 包装非结构化对象，使其可以被 dataselect 过滤
+type ObjectCell unstructured.Unstructured
+
+// GetProperty is used to get property of the object.
+func (c ObjectCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	obj := unstructured.Unstructured(c)
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(obj.GetName())
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(obj.GetCreationTimestamp().Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(obj.GetNamespace())
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+// filter 根据命名空间和 dataselect 过滤条件过滤事件
+type filter struct {
+	namespace  *common.NamespaceQuery
+	dataSelect *dataselect.DataSelectQuery
+}
+
+// newFilter 创建事件过滤器
+func newFilter(namespace *common.NamespaceQuery, dataSelect *dataselect.DataSelectQuery) *filter {
+	return &filter{
+		namespace:  namespace,
+		dataSelect: dataSelect,
+	}
+}
+
+// matches reports whether the object matches the namespace query and the dataselect filter. The supported filter
+// properties (name, namespace and creationTimestamp) are immutable, so an object never starts or stops matching
+// while it exists, and the events of the apiserver can be passed through unchanged.
+// matches 判断对象是否满足过滤条件
+func (f *filter) matches(obj *unstructured.Unstructured) bool {
+	if !f.namespace.Matches(obj.GetNamespace()) {
+		return false
+	}
+	if f.dataSelect == nil || f.dataSelect.FilterQuery == nil {
+		return true
+	}
+	selector := dataselect.DataSelector{
+		GenericDataList: []dataselect.DataCell{ObjectCell(*obj)},
+		DataSelectQuery: f.dataSelect,
+	}
+	return len(selector.Filter().GenericDataList) > 0
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+	"github.com/karmada-io/dashboard/pkg/resource/common"
+)
+
+func newObject(namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func TestFilterMatches(t *testing.T) {
+	cases := []struct {
+		name       string
+		namespace  *common.NamespaceQuery
+		dataSelect *dataselect.DataSelectQuery
+		obj        *unstructured.Unstructured
+		want       bool
+	}{
+		{
+			name:      "all namespaces",
+			namespace: common.NewNamespaceQuery(nil),
+			obj:       newObject("kube-system", "coredns"),
+			want:      true,
+		},
+		{
+			name:      "namespace matches",
+			namespace: common.NewNamespaceQuery([]string{"default", "karmada-system"}),
+			obj:       newObject("karmada-system", "karmada-scheduler"),
+			want:      true,
+		},
+		{
+			name:      "namespace does not match",
+			namespace: common.NewNamespaceQuery([]string{"default"}),
+			obj:       newObject("kube-system", "coredns"),
+			want:      false,
+		},
+		{
+			name:      "name filter matches",
+			namespace: common.NewNamespaceQuery(nil),
+			dataSelect: dataselect.NewDataSelectQuery(dataselect.NoPagination, dataselect.NoSort,
+				dataselect.NewFilterQuery([]string{dataselect.NameProperty, "web"})),
+			obj:  newObject("default", "web-1"),
+			want: true,
+		},
+		{
+			name:      "name filter does not match",
+			namespace: common.NewNamespaceQuery(nil),
+			dataSelect: dataselect.NewDataSelectQuery(dataselect.NoPagination, dataselect.NoSort,
+				dataselect.NewFilterQuery([]string{dataselect.NameProperty, "web"})),
+			obj:  newObject("default", "nginx"),
+			want: false,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f := newFilter(c.namespace, c.dataSelect)
+			if got := f.matches(c.obj); got != c.want {
+				t.Errorf("matches(%s/%s) = %v, expected %v", c.obj.GetNamespace(), c.obj.GetName(), got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Karmada Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+	"github.com/karmada-io/dashboard/pkg/resource/common"
+)
+
+// EventType is the type of the event sent to the watcher.
+type EventType string
+
+// List of the event types sent to the watcher.
+const (
+	// EventAdded is sent when an object is created, or starts to match the label selector.
+	EventAdded EventType = EventType(watch.Added)
+	// EventModified is sent when a matching object is updated.
+	EventModified EventType = EventType(watch.Modified)
+	// EventDeleted is sent when an object is deleted, or stops matching the label selector.
+	EventDeleted EventType = EventType(watch.Deleted)
+	// EventHeartbeat is sent periodically so that clients and proxies can tell that the stream is alive.
+	EventHeartbeat EventType = "HEARTBEAT"
+	// EventError is sent once before the stream ends because of an error. If the reason is Expired or Gone,
+	// the resourceVersion is too old and the client should list again before watching.
+	EventError EventType = "ERROR"
+)
+
+// DefaultHeartbeatInterval is the default interval between two heartbeat events.
+const DefaultHeartbeatInterval = 30 * time.Second
+
+// Event is a change of an object sent to the watcher.
+// Event 是发送给监听者的对象变更事件
+type Event struct {
+	Type EventType `json:"type"`
+	// ResourceVersion is the latest resourceVersion seen by the stream, which can be used to resume the watch.
+	ResourceVersion string                     `json:"resourceVersion,omitempty"`
+	Object          *unstructured.Unstructured `json:"object,omitempty"`
+	// Status is set on ERROR events.
+	Status *metav1.Status `json:"status,omitempty"`
+}
+
+// Options holds the options of a watch stream.
+// Options 保存监听流的选项
+type Options struct {
+	// Namespace restricts the stream to the objects of the given namespaces.
+	Namespace *common.NamespaceQuery
+	// DataSelect restricts the stream to the objects matching its filter. Sorting and pagination are ignored.
+	DataSelect *dataselect.DataSelectQuery
+	// LabelSelector restricts the stream to the objects matching the label selector.
+	LabelSelector string
+	// ResourceVersion resumes the stream after the given resourceVersion. When empty, the current objects are sent as
+	// ADDED events first.
+	ResourceVersion string
+	// Heartbeat is the interval between two heartbeat events, DefaultHeartbeatInterval is used if it is not positive.
+	Heartbeat time.Duration
+}
+
+// Run watches the resource and calls send for every change that matches opts, until ctx is done, send returns an
+// error or the watch fails. Watches that are closed by the apiserver are resumed from the last seen resourceVersion.
+// A watch failure is sent as an ERROR event before Run returns it.
+// Run 监听资源并为每个满足条件的变更调用 send，apiserver 关闭监听时从最后的 resourceVersion 继续监听
+func Run(ctx context.Context, resource dynamic.NamespaceableResourceInterface, opts Options, send func(*Event) error) error {
+	if opts.Namespace == nil {
+		opts.Namespace = common.NewNamespaceQuery(nil)
+	}
+	if opts.Heartbeat <= 0 {
+		opts.Heartbeat = DefaultHeartbeatInterval
+	}
+	heartbeat := time.NewTicker(opts.Heartbeat)
+	defer heartbeat.Stop()
+
+	f := newFilter(opts.Namespace, opts.DataSelect)
+	resourceVersion := opts.ResourceVersion
+	for {
+		watcher, err := resource.Namespace(opts.Namespace.ToRequestParam()).Watch(ctx, metav1.ListOptions{
+			LabelSelector:       opts.LabelSelector,
+			ResourceVersion:     resourceVersion,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			return sendError(ctx, send, resourceVersion, err)
+		}
+		resourceVersion, err = consume(ctx, watcher, f, resourceVersion, heartbeat.C, send)
+		watcher.Stop()
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		klog.V(4).InfoS("Watch closed by apiserver, resuming", "resourceVersion", resourceVersion)
+	}
+}
+
+// consume 处理一次监听的事件，直到监听关闭，返回最后的 resourceVersion
+func consume(ctx context.Context, watcher watch.Interface, f *filter, resourceVersion string,
+	heartbeat <-chan time.Time, send func(*Event) error) (string, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, nil
+		case <-heartbeat:
+			if err := send(&Event{Type: EventHeartbeat, ResourceVersion: resourceVersion}); err != nil {
+				return resourceVersion, err
+			}
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion, nil
+			}
+			if event.Type == watch.Error {
+				return resourceVersion, sendError(ctx, send, resourceVersion, errors.FromObject(event.Object))
+			}
+			obj, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			if obj.GetResourceVersion() != "" {
+				resourceVersion = obj.GetResourceVersion()
+			}
+			if event.Type == watch.Bookmark {
+				continue
+			}
+			if !f.matches(obj) {
+				continue
+			}
+			if err := send(&Event{Type: EventType(event.Type), ResourceVersion: resourceVersion, Object: obj}); err != nil {
+				return resourceVersion, err
+			}
+		}
+	}
+}
+
+// sendError 发送 ERROR 事件并返回原始错误
+func sendError(ctx context.Context, send func(*Event) error, resourceVersion string, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	status := metav1.Status{Status: metav1.StatusFailure, Message: err.Error(), Reason: metav1.StatusReasonUnknown}
+	if apiStatus, ok := err.(errors.APIStatus); ok {
+		status = apiStatus.Status()
+	}
+	if sendErr := send(&Event{Type: EventError, ResourceVersion: resourceVersion, Status: &status}); sendErr != nil {
+		klog.V(4).InfoS("Failed to send watch error event", "err", sendErr)
+	}
+	return err
+}
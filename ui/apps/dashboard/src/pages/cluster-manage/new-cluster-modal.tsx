@@ -23,6 +23,7 @@ import {
   ClusterDetail,
   CreateCluster,
   UpdateCluster,
+  WaitClusterOperation,
 } from '@/services/cluster';
 import type { LabelParam, TaintParam } from '@/services/cluster';
 import { IResponse } from '@/services/base.ts';
@@ -120,7 +121,11 @@ const NewClusterModal: FC<NewClusterModalProps> = (props) => {
               kubeconfig: submitData.kubeconfig,
               mode: submitData.mode,
             });
-            onOk(ret);
+            // the cluster is joined in the background, wait for the join
+            // operation to finish before reporting the result
+            onOk(
+              ret.code === 200 ? await WaitClusterOperation(ret.data.id) : ret,
+            );
           }
         } catch (e) {
           console.log('e', e);
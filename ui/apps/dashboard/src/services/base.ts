@@ -31,10 +31,40 @@ export const karmadaClient = axios.create({
   baseURL,
 });
 
+// Failed requests answer with a non-2xx status but the same response envelope,
+// resolve them so callers can keep checking `code` instead of catching.
+karmadaClient.interceptors.response.use(undefined, (error) => {
+  if (
+    axios.isAxiosError(error) &&
+    typeof error.response?.data?.code === 'number'
+  ) {
+    return error.response;
+  }
+  return Promise.reject(error);
+});
+
+export interface IResponseErrorStatus {
+  reason: string;
+  details?: {
+    name?: string;
+    group?: string;
+    kind?: string;
+    uid?: string;
+    retryAfterSeconds?: number;
+  };
+  causes?: {
+    reason?: string;
+    message?: string;
+    field?: string;
+  }[];
+  messageKey?: string;
+}
+
 export interface IResponse<Data = {}> {
   code: number;
   message: string;
   data: Data;
+  error?: IResponseErrorStatus;
 }
 
 export interface DataSelectQuery {
@@ -82,14 +82,63 @@ export async function CreateCluster(params: {
   mode: 'Push' | 'Pull';
 }) {
   // /api/v1/cluster
-  const resp = await karmadaClient.post<IResponse<string>>(`/cluster`, {
-    memberClusterKubeconfig: params.kubeconfig,
-    memberClusterName: params.clusterName,
-    syncMode: params.mode,
-  });
+  const resp = await karmadaClient.post<IResponse<ClusterOperation>>(
+    `/cluster`,
+    {
+      memberClusterKubeconfig: params.kubeconfig,
+      memberClusterName: params.clusterName,
+      syncMode: params.mode,
+    },
+  );
+  return resp.data;
+}
+
+export type ClusterOperationPhase = 'Running' | 'Succeeded' | 'Failed';
+
+export interface ClusterOperationStep {
+  name: string;
+  phase: 'Pending' | 'Running' | 'Succeeded' | 'Failed' | 'RolledBack';
+  message?: string;
+  startTime?: string;
+  completionTime?: string;
+}
+
+export interface ClusterOperation {
+  id: string;
+  kind: string;
+  target: string;
+  phase: ClusterOperationPhase;
+  steps: ClusterOperationStep[];
+  error?: string;
+  startTime: string;
+  completionTime?: string;
+}
+
+export async function GetClusterOperation(id: string) {
+  const resp = await karmadaClient.get<IResponse<ClusterOperation>>(
+    `/cluster/operation/${id}`,
+  );
   return resp.data;
 }
 
+// WaitClusterOperation polls the operation until it finishes, a failed
+// operation is reported with a non-200 code and its error as the message.
+export async function WaitClusterOperation(id: string, interval = 2000) {
+  for (;;) {
+    const ret = await GetClusterOperation(id);
+    if (ret.code !== 200) {
+      return ret;
+    }
+    if (ret.data.phase === 'Failed') {
+      return { ...ret, code: 500, message: ret.data.error || ret.message };
+    }
+    if (ret.data.phase === 'Succeeded') {
+      return ret;
+    }
+    await new Promise((resolve) => setTimeout(resolve, interval));
+  }
+}
+
 export interface LabelParam {
   key: string;
   value: string;
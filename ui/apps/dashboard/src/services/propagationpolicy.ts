@@ -102,7 +102,7 @@ export async function DeletePropagationPolicy(params: {
   namespace: string;
   name: string;
 }) {
-  const resp = await karmadaClient.delete<IResponse<string>>(
+  const resp = await karmadaClient.delete<IResponse<{ released: boolean }>>(
     '/propagationpolicy',
     {
       data: params,